@@ -24,11 +24,11 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	kfv1alpha1 "github.com/google/kf/pkg/client/clientset/versioned/typed/kf/v1alpha1"
 	apiconfig "github.com/knative/serving/pkg/apis/config"
 	"github.com/knative/serving/pkg/apis/serving/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
-	cv1alpha3 "knative.dev/pkg/client/clientset/versioned/typed/istio/v1alpha3"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/logging/logkey"
@@ -80,9 +80,9 @@ func main() {
 		logger.Fatalw("Version check failed", err)
 	}
 
-	istioClient, err := cv1alpha3.NewForConfig(clusterConfig)
+	kfClient, err := kfv1alpha1.NewForConfig(clusterConfig)
 	if err != nil {
-		logger.Fatalw("Failed to get the istio client set", zap.Error(err))
+		logger.Fatalw("Failed to get the kf client set", zap.Error(err))
 	}
 
 	// Watch the logging config map and dynamically update logging levels.
@@ -108,18 +108,19 @@ func main() {
 		Client:  kubeClient,
 		Options: options,
 		Handlers: map[schema.GroupVersionKind]webhook.GenericCRD{
-			v1alpha1.SchemeGroupVersion.WithKind("Space"): &v1alpha1.Space{},
-			v1alpha1.SchemeGroupVersion.WithKind("App"):   &v1alpha1.App{},
-			v1alpha1.SchemeGroupVersion.WithKind("Route"): &v1alpha1.Route{},
+			v1alpha1.SchemeGroupVersion.WithKind("Space"):  &v1alpha1.Space{},
+			v1alpha1.SchemeGroupVersion.WithKind("App"):    &v1alpha1.App{},
+			v1alpha1.SchemeGroupVersion.WithKind("Route"):  &v1alpha1.Route{},
+			v1alpha1.SchemeGroupVersion.WithKind("Task"):   &v1alpha1.Task{},
+			v1alpha1.SchemeGroupVersion.WithKind("Domain"): &v1alpha1.Domain{},
 		},
 		Logger:                logger,
 		DisallowUnknownFields: true,
 
 		// Decorate contexts with the current state of the config.
 		WithContext: func(ctx context.Context) context.Context {
-			// XXX: Route webhook needs to look at what VirtualServices are
-			// deployed.
-			ctx = v1alpha1.SetupIstioClient(ctx, istioClient)
+			// Route webhook needs to check the ownership of Domains.
+			ctx = v1alpha1.SetupDomainGetter(ctx, kfClient.Domains())
 
 			return v1beta1.WithUpgradeViaDefaulting(store.ToContext(ctx))
 		},
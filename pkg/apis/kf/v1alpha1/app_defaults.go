@@ -20,12 +20,17 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"knative.dev/pkg/apis"
 )
 
 var (
 	defaultMem     = resource.MustParse("1Gi")
 	defaultStorage = resource.MustParse("1Gi")
 	defaultCPU     = resource.MustParse("1")
+
+	// DefaultBuildCacheSize is the amount of storage requested for an App's
+	// build cache if the App doesn't set one.
+	DefaultBuildCacheSize = resource.MustParse("2Gi")
 )
 
 const (
@@ -41,11 +46,42 @@ const (
 // SetDefaults implements apis.Defaultable
 func (k *App) SetDefaults(ctx context.Context) {
 	k.Spec.SetDefaults(ctx)
+	k.setLastModifier(ctx)
+}
+
+// setLastModifier records the user making the request so deployments can be
+// attributed to them.
+func (k *App) setLastModifier(ctx context.Context) {
+	if apis.IsInStatusUpdate(ctx) {
+		return
+	}
+
+	userInfo := apis.GetUserInfo(ctx)
+	if userInfo == nil || userInfo.Username == "" {
+		return
+	}
+
+	if k.Annotations == nil {
+		k.Annotations = make(map[string]string)
+	}
+	k.Annotations[LastModifierAnnotation] = userInfo.Username
 }
 
 // SetDefaults implements apis.Defaultable
 func (k *AppSpec) SetDefaults(ctx context.Context) {
 	k.Template.SetDefaults(ctx)
+	k.Source.SetDefaults(ctx)
+
+	if k.BuildCache != nil {
+		k.BuildCache.SetDefaults(ctx)
+	}
+}
+
+// SetDefaults implements apis.Defaultable
+func (k *AppSpecBuildCache) SetDefaults(ctx context.Context) {
+	if k.Size.IsZero() {
+		k.Size = DefaultBuildCacheSize.DeepCopy()
+	}
 }
 
 // SetDefaults implements apis.Defaultable
@@ -19,8 +19,10 @@ import (
 	"testing"
 
 	"github.com/google/kf/pkg/kf/testutil"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"knative.dev/pkg/apis"
 )
 
 func TestAppSpec_SetDefaults_BlankContainer(t *testing.T) {
@@ -33,6 +35,64 @@ func TestAppSpec_SetDefaults_BlankContainer(t *testing.T) {
 	testutil.AssertEqual(t, "spec.template.spec.containers.name", "", app.Spec.Template.Spec.Containers[0].Name)
 }
 
+func TestAppSpec_SetDefaults_DockerfilePath(t *testing.T) {
+	t.Parallel()
+
+	app := &App{}
+	app.Spec.Source.Dockerfile.Source = "some-source-image"
+	app.SetDefaults(context.Background())
+
+	testutil.AssertEqual(t, "spec.source.dockerfile.path", DefaultDockerfilePath, app.Spec.Source.Dockerfile.Path)
+}
+
+func TestAppSpec_SetDefaults_BuildCacheSize(t *testing.T) {
+	t.Parallel()
+
+	app := &App{}
+	app.Spec.BuildCache = &AppSpecBuildCache{}
+	app.SetDefaults(context.Background())
+
+	testutil.AssertEqual(t, "spec.buildCache.size", DefaultBuildCacheSize.String(), app.Spec.BuildCache.Size.String())
+
+	app.Spec.BuildCache.Size = resource.MustParse("10Gi")
+	app.SetDefaults(context.Background())
+
+	testutil.AssertEqual(t, "spec.buildCache.size", "10Gi", app.Spec.BuildCache.Size.String())
+}
+
+func TestApp_SetDefaults_LastModifier(t *testing.T) {
+	t.Parallel()
+
+	userCtx := apis.WithUserInfo(context.Background(), &authenticationv1.UserInfo{
+		Username: "user@example.com",
+	})
+
+	cases := map[string]struct {
+		ctx      context.Context
+		expected string
+	}{
+		"no user": {
+			ctx: context.Background(),
+		},
+		"user": {
+			ctx:      userCtx,
+			expected: "user@example.com",
+		},
+		"status update": {
+			ctx: apis.WithinSubResourceUpdate(userCtx, nil, "status"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			app := &App{}
+			app.SetDefaults(tc.ctx)
+
+			testutil.AssertEqual(t, "last modifier", tc.expected, app.Annotations[LastModifierAnnotation])
+		})
+	}
+}
+
 func TestAppSpec_SetDefaults_ResourceLimits_AlreadySet(t *testing.T) {
 	t.Parallel()
 
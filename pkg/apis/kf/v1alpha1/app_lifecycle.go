@@ -15,6 +15,9 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"path"
+
 	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
@@ -46,6 +49,7 @@ func (status *AppStatus) manage() apis.ConditionManager {
 		AppConditionSourceReady,
 		AppConditionKnativeServiceReady,
 		AppConditionSpaceReady,
+		AppConditionRouteReady,
 	).Manage(status)
 }
 
@@ -64,6 +68,13 @@ func (status *AppStatus) SourceCondition() SingleConditionManager {
 	return NewSingleConditionManager(status.manage(), AppConditionSourceReady, "Source")
 }
 
+// BuildCacheCondition gets a manager for the state of the build cache. Build
+// cache errors block the source from being built so they're reported on the
+// source's condition.
+func (status *AppStatus) BuildCacheCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), AppConditionSourceReady, "Build Cache")
+}
+
 // KnativeServiceCondition gets a manager for the state of the Knative Service.
 func (status *AppStatus) KnativeServiceCondition() SingleConditionManager {
 	return NewSingleConditionManager(status.manage(), AppConditionKnativeServiceReady, "Knative Service")
@@ -96,6 +107,61 @@ func (status *AppStatus) PropagateKnativeServiceStatus(service *serving.Service)
 	}
 }
 
+// PropagateRouteStatus updates the route readiness of the App to reflect the
+// Routes in its spec. A Route is ready for the App once its VirtualService has
+// been synced with the App in it. The Routes' AppsReady condition is ignored
+// because it depends on the App's own readiness.
+func (status *AppStatus) PropagateRouteStatus(appName string, routes []*Route) {
+	for _, route := range routes {
+		if cond := route.Status.GetCondition(RouteConditionHostnameReserved); cond != nil && cond.IsFalse() {
+			status.manage().MarkFalse(AppConditionRouteReady, cond.Reason,
+				fmt.Sprintf("Route %s can't be used: %s", route.Name, cond.Message))
+			return
+		}
+
+		cond := route.Status.GetCondition(RouteConditionVirtualServiceReady)
+		if cond != nil && cond.IsFalse() {
+			status.manage().MarkFalse(AppConditionRouteReady, cond.Reason,
+				fmt.Sprintf("Route %s isn't ready: %s", route.Name, cond.Message))
+			return
+		}
+
+		if cond == nil ||
+			cond.IsUnknown() ||
+			route.Status.ObservedGeneration != route.Generation ||
+			!route.Spec.HasApp(appName) {
+			status.manage().MarkUnknown(AppConditionRouteReady, "RouteReconciling",
+				fmt.Sprintf("Waiting for Route %s to be synced.", route.Name))
+			return
+		}
+	}
+
+	status.manage().MarkTrue(AppConditionRouteReady)
+}
+
+// PropagateRouteURL points the App's URL at one of its routes. HTTPS is used
+// if the route's domain has TLS configured.
+func (status *AppStatus) PropagateRouteURL(route RouteSpecFields, https bool) {
+	url := &apis.URL{
+		Scheme: "http",
+		Host:   route.Domain,
+	}
+
+	if https {
+		url.Scheme = "https"
+	}
+
+	if route.Hostname != "" {
+		url.Host = route.Hostname + "." + route.Domain
+	}
+
+	if urlPath := path.Join("/", route.Path); urlPath != "/" {
+		url.Path = urlPath
+	}
+
+	status.URL = url
+}
+
 // MarkSpaceHealthy notes that the space was able to be retrieved and
 // defaults can be applied from it.
 func (status *AppStatus) MarkSpaceHealthy() {
@@ -106,3 +172,45 @@ func (status *AppStatus) MarkSpaceHealthy() {
 func (status *AppStatus) MarkSpaceUnhealthy(reason, message string) {
 	status.manage().MarkFalse(AppConditionSpaceReady, reason, message)
 }
+
+// RecordRevision adds a successful deployment to the App's history. The
+// revision is numbered automatically and ignored if it's the same as the
+// latest deployment. Only the latest MaxAppRevisions are kept.
+func (status *AppStatus) RecordRevision(revision AppRevision) {
+	if latest := status.LatestRevision(); latest != nil {
+		if latest.Image == revision.Image && latest.TemplateHash == revision.TemplateHash {
+			return
+		}
+
+		revision.Number = latest.Number + 1
+	} else {
+		revision.Number = 1
+	}
+
+	status.Revisions = append(status.Revisions, revision)
+	if overflow := len(status.Revisions) - MaxAppRevisions; overflow > 0 {
+		status.Revisions = status.Revisions[overflow:]
+	}
+}
+
+// LatestRevision returns the most recent deployment of the App or nil if
+// it hasn't been deployed.
+func (status *AppStatus) LatestRevision() *AppRevision {
+	if len(status.Revisions) == 0 {
+		return nil
+	}
+
+	return &status.Revisions[len(status.Revisions)-1]
+}
+
+// FindRevision returns the deployment with the given number or nil if it's
+// not in the App's history.
+func (status *AppStatus) FindRevision(number int) *AppRevision {
+	for i := range status.Revisions {
+		if status.Revisions[i].Number == number {
+			return &status.Revisions[i]
+		}
+	}
+
+	return nil
+}
@@ -14,4 +14,140 @@
 
 package v1alpha1
 
-// TODO (#403) Test Methods
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAppStatus_RecordRevision(t *testing.T) {
+	t.Parallel()
+
+	status := &AppStatus{}
+	testutil.AssertEqual(t, "empty latest", (*AppRevision)(nil), status.LatestRevision())
+
+	status.RecordRevision(AppRevision{Image: "image-1", TemplateHash: "hash-1"})
+	status.RecordRevision(AppRevision{Image: "image-1", TemplateHash: "hash-1"})
+	testutil.AssertEqual(t, "duplicates ignored", 1, len(status.Revisions))
+	testutil.AssertEqual(t, "first number", 1, status.LatestRevision().Number)
+
+	status.RecordRevision(AppRevision{Image: "image-1", TemplateHash: "hash-2"})
+	testutil.AssertEqual(t, "template change recorded", 2, status.LatestRevision().Number)
+
+	for i := 0; i < MaxAppRevisions; i++ {
+		status.RecordRevision(AppRevision{Image: fmt.Sprintf("image-%d", i+2)})
+	}
+
+	testutil.AssertEqual(t, "bounded", MaxAppRevisions, len(status.Revisions))
+	testutil.AssertEqual(t, "oldest", 3, status.Revisions[0].Number)
+	testutil.AssertEqual(t, "latest", MaxAppRevisions+2, status.LatestRevision().Number)
+}
+
+func TestAppStatus_FindRevision(t *testing.T) {
+	t.Parallel()
+
+	status := &AppStatus{
+		Revisions: []AppRevision{
+			{Number: 4, Image: "image-4"},
+			{Number: 5, Image: "image-5"},
+		},
+	}
+
+	testutil.AssertEqual(t, "found", "image-4", status.FindRevision(4).Image)
+	testutil.AssertEqual(t, "missing", (*AppRevision)(nil), status.FindRevision(3))
+}
+
+func ExampleAppStatus_PropagateRouteURL() {
+	status := &AppStatus{}
+
+	status.PropagateRouteURL(RouteSpecFields{Hostname: "my-app", Domain: "example.com"}, false)
+	fmt.Println(status.URL.String())
+
+	status.PropagateRouteURL(RouteSpecFields{Hostname: "my-app", Domain: "example.com", Path: "/api"}, true)
+	fmt.Println(status.URL.String())
+
+	// Output: http://my-app.example.com
+	// https://my-app.example.com/api
+}
+
+func TestAppStatus_PropagateRouteStatus(t *testing.T) {
+	t.Parallel()
+
+	syncedRoute := func(name string, appNames ...string) *Route {
+		route := &Route{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Generation: 2},
+			Spec:       RouteSpec{AppNames: appNames},
+		}
+		route.Status.InitializeConditions()
+		route.Status.MarkHostnameReserved()
+		route.Status.MarkVirtualServiceReady()
+		route.Status.ObservedGeneration = 2
+		return route
+	}
+
+	cases := map[string]struct {
+		routes     []*Route
+		wantStatus corev1.ConditionStatus
+		wantReason string
+	}{
+		"no routes": {
+			wantStatus: corev1.ConditionTrue,
+		},
+		"synced routes": {
+			routes: []*Route{
+				syncedRoute("route-a", "my-app"),
+				syncedRoute("route-b", "other-app", "my-app"),
+			},
+			wantStatus: corev1.ConditionTrue,
+		},
+		"route reserved by other space": {
+			routes: func() []*Route {
+				route := syncedRoute("route-a", "my-app")
+				route.Status.MarkHostnameReservedByOtherSpace("host", "example.com", "other-space")
+				return []*Route{route}
+			}(),
+			wantStatus: corev1.ConditionFalse,
+			wantReason: "ReservedByOtherSpace",
+		},
+		"virtual service failed": {
+			routes: func() []*Route {
+				route := syncedRoute("route-a", "my-app")
+				route.Status.VirtualServiceCondition().MarkReconciliationError("updating", fmt.Errorf("some-error"))
+				return []*Route{route}
+			}(),
+			wantStatus: corev1.ConditionFalse,
+			wantReason: "ReconciliationError",
+		},
+		"route not reconciled yet": {
+			routes: func() []*Route {
+				route := syncedRoute("route-a", "my-app")
+				route.Generation = 3
+				return []*Route{route}
+			}(),
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: "RouteReconciling",
+		},
+		"app missing from route": {
+			routes: []*Route{
+				syncedRoute("route-a", "other-app"),
+			},
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: "RouteReconciling",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &AppStatus{}
+			status.InitializeConditions()
+			status.PropagateRouteStatus("my-app", tc.routes)
+
+			cond := status.GetCondition(AppConditionRouteReady)
+			testutil.AssertEqual(t, "status", tc.wantStatus, cond.Status)
+			testutil.AssertEqual(t, "reason", tc.wantReason, cond.Reason)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
@@ -33,6 +34,33 @@ const (
 	// ComponentLabel holds the standard label key for Kubernetes app component
 	// identifiers.
 	ComponentLabel = "app.kubernetes.io/component"
+	// StageComponent is the component label of Sources that build an App
+	// without deploying it.
+	StageComponent = "stage"
+	// LastModifierAnnotation holds the name of the user that last modified
+	// the App's spec.
+	LastModifierAnnotation = "kf-last-modifier"
+	// PromotedFromAnnotation holds the space and name of the App an App's
+	// image was promoted from as SPACE/APP.
+	PromotedFromAnnotation = "kf-promoted-from"
+	// PromotedImageAnnotation holds the image, by digest, that was promoted
+	// to an App.
+	PromotedImageAnnotation = "kf-promoted-image"
+	// PromotedSourceImageAnnotation holds the image of the App the image was
+	// promoted from.
+	PromotedSourceImageAnnotation = "kf-promoted-source-image"
+	// ServiceBindingsAppAnnotation holds the name of another App in the same
+	// space whose service bindings are exposed to the App in VCAP_SERVICES.
+	// It's set on the copy of an App that serves traffic during a blue-green
+	// push so the copy keeps its services.
+	ServiceBindingsAppAnnotation = "kf-service-bindings-app"
+	// CancelledBuildAnnotation holds the name of the build a user asked to
+	// cancel. The Source reconciler cancels the build if it's still the
+	// Source's build.
+	CancelledBuildAnnotation = "kf-cancelled-build"
+	// MaxAppRevisions holds the number of deployments kept in an App's
+	// history.
+	MaxAppRevisions = 10
 )
 
 // +genclient
@@ -65,6 +93,12 @@ func (app *App) ComponentLabels(component string) map[string]string {
 	}
 }
 
+// BuildCacheName gets the name of the PersistentVolumeClaim that caches the
+// App's buildpack builds.
+func (app *App) BuildCacheName() string {
+	return fmt.Sprintf("%s-build-cache", app.Name)
+}
+
 // AppSpec is the desired configuration for an App.
 type AppSpec struct {
 
@@ -83,6 +117,25 @@ type AppSpec struct {
 	// +optional
 	// +patchStrategy=merge
 	Routes []RouteSpecFields `json:"routes,omitempty"`
+
+	// BuildCache configures a persistent cache for the App's buildpack
+	// builds. Builds aren't cached if it's nil.
+	// +optional
+	BuildCache *AppSpecBuildCache `json:"buildCache,omitempty"`
+}
+
+// AppSpecBuildCache configures the persistent cache shared by an App's
+// buildpack builds.
+type AppSpecBuildCache struct {
+
+	// Size is the amount of storage requested for the cache.
+	// +optional
+	Size resource.Quantity `json:"size,omitempty"`
+
+	// Skip builds the App without the cache. The cache is kept for later
+	// builds.
+	// +optional
+	Skip bool `json:"skip,omitempty"`
 }
 
 // AppSpecTemplate defines an app's runtime configuration.
@@ -185,6 +238,40 @@ type AppStatus struct {
 	// LatestCreatedSourceName contains the name of the source that was most
 	// recently created.
 	LatestCreatedSourceName string `json:"latestSource,omitempty"`
+
+	// Revisions contains the most recent successful deployments of the App,
+	// oldest first. At most MaxAppRevisions are kept.
+	// +optional
+	Revisions []AppRevision `json:"revisions,omitempty"`
+}
+
+// AppRevision records a successful deployment of an App.
+type AppRevision struct {
+	// Number identifies the deployment. It increases with every deployment
+	// of the App.
+	Number int `json:"number"`
+
+	// SourceName is the name of the Source that produced the image.
+	// +optional
+	SourceName string `json:"sourceName,omitempty"`
+
+	// Image is the container image that was deployed.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// TemplateHash is a hash of the template, including the environment
+	// variables, that was deployed.
+	// +optional
+	TemplateHash string `json:"templateHash,omitempty"`
+
+	// DeployedAt is the time the deployment became ready.
+	// +optional
+	DeployedAt metav1.Time `json:"deployedAt,omitempty"`
+
+	// DeployedBy is the user that last modified the App before it was
+	// deployed.
+	// +optional
+	DeployedBy string `json:"deployedBy,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
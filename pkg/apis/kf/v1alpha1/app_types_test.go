@@ -168,3 +168,12 @@ func ExampleApp_ComponentLabels() {
 	// managed-by: kf
 	// component: database
 }
+
+func ExampleApp_BuildCacheName() {
+	app := App{}
+	app.Name = "my-app"
+
+	fmt.Println(app.BuildCacheName())
+
+	// Output: my-app-build-cache
+}
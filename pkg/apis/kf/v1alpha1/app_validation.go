@@ -40,6 +40,10 @@ func (spec *AppSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(ValidatePodSpec(spec.Template.Spec).ViaField("template.spec"))
 	errs = errs.Also(spec.Instances.Validate(ctx).ViaField("instances"))
 
+	if spec.BuildCache != nil {
+		errs = errs.Also(spec.BuildCache.Validate(ctx).ViaField("buildCache"))
+	}
+
 	return errs
 }
 
@@ -77,6 +81,15 @@ func (instances *AppSpecInstances) Validate(ctx context.Context) (errs *apis.Fie
 	return errs
 }
 
+// Validate checks that the build cache requests storage.
+func (cache *AppSpecBuildCache) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if cache.Size.Sign() <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(cache.Size.String(), "size"))
+	}
+
+	return errs
+}
+
 // ValidatePodSpec proxies Knative Serving's checks on PodSpec, except for
 // one condition. We don't allow setting the container image directly on the
 // PodSpec because it'll be set by the source instead.
@@ -20,6 +20,7 @@ import (
 
 	"github.com/google/kf/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -59,6 +60,19 @@ func TestApp_Validate(t *testing.T) {
 			},
 			want: apis.ErrInvalidValue(-1, "spec.instances.exactly"),
 		},
+		"invalid build cache": {
+			spec: App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: AppSpec{
+					Template:   goodTemplate,
+					Instances:  goodInstances,
+					BuildCache: &AppSpecBuildCache{Size: resource.MustParse("-1Gi")},
+				},
+			},
+			want: apis.ErrInvalidValue("-1Gi", "spec.buildCache.size"),
+		},
 		"invalid template": {
 			spec: App{
 				ObjectMeta: metav1.ObjectMeta{
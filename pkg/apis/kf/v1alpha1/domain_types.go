@@ -0,0 +1,95 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Domain is a cluster-wide domain that Routes can be created on. The name of
+// the Domain is the DNS name it represents.
+type Domain struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec DomainSpec `json:"spec,omitempty"`
+}
+
+// DomainSpec contains the specification for a Domain.
+type DomainSpec struct {
+	// OwnerSpace is the space a private Domain belongs to. Domains without an
+	// owner are shared and Routes can be created on them in every space.
+	// +optional
+	OwnerSpace string `json:"ownerSpace,omitempty"`
+
+	// AllowedSpaces holds additional spaces that can create Routes on a
+	// private Domain.
+	// +optional
+	AllowedSpaces []string `json:"allowedSpaces,omitempty"`
+
+	// Default is set if the Domain should be used as the default domain of
+	// newly created spaces. Only shared Domains can be the default.
+	// +optional
+	Default bool `json:"default,omitempty"`
+
+	// TLS configures HTTPS for Routes on the Domain.
+	// +optional
+	TLS *DomainTLS `json:"tls,omitempty"`
+}
+
+// DomainTLS holds the TLS configuration of a domain.
+type DomainTLS struct {
+	// SecretName is the name of a kubernetes.io/tls Secret in the ingress
+	// gateway's namespace holding the certificate for the domain and its
+	// subdomains. Secrets issued by a cert-manager Certificate can be
+	// referenced directly.
+	SecretName string `json:"secretName"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DomainList is a list of Domain resources
+type DomainList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Domain `json:"items"`
+}
+
+// IsPrivate returns true if the Domain belongs to a single space.
+func (spec *DomainSpec) IsPrivate() bool {
+	return spec.OwnerSpace != ""
+}
+
+// AllowsSpace returns true if Routes on the Domain can be created in the
+// given space.
+func (spec *DomainSpec) AllowsSpace(space string) bool {
+	if !spec.IsPrivate() || spec.OwnerSpace == space {
+		return true
+	}
+
+	for _, allowed := range spec.AllowedSpaces {
+		if allowed == space {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// Validate checks for errors in the Domain's fields.
+func (domain *Domain) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if domain.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	} else if len(validation.IsDNS1123Subdomain(domain.Name)) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(domain.Name, "name"))
+	}
+
+	errs = errs.Also(domain.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+	return errs
+}
+
+// Validate makes sure that a DomainSpec is properly configured.
+func (spec *DomainSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if !spec.IsPrivate() && len(spec.AllowedSpaces) > 0 {
+		errs = errs.Also(&apis.FieldError{
+			Message: "only private domains can be limited to spaces",
+			Paths:   []string{"allowedSpaces"},
+		})
+	}
+
+	if spec.IsPrivate() && spec.Default {
+		errs = errs.Also(&apis.FieldError{
+			Message: "private domains can't be the default",
+			Paths:   []string{"default"},
+		})
+	}
+
+	if spec.TLS != nil {
+		errs = errs.Also(spec.TLS.Validate(ctx).ViaField("tls"))
+	}
+
+	return errs
+}
+
+// Validate makes sure that DomainTLS is properly configured.
+func (tls *DomainTLS) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if tls.SecretName == "" {
+		errs = errs.Also(apis.ErrMissingField("secretName"))
+	}
+
+	return errs
+}
@@ -0,0 +1,125 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestDomainValidation(t *testing.T) {
+	cases := map[string]struct {
+		domain *Domain
+		want   *apis.FieldError
+	}{
+		"valid shared": {
+			domain: &Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: "example.com"},
+				Spec:       DomainSpec{Default: true},
+			},
+		},
+		"valid private": {
+			domain: &Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: "apps.example.com"},
+				Spec: DomainSpec{
+					OwnerSpace:    "my-space",
+					AllowedSpaces: []string{"other-space"},
+				},
+			},
+		},
+		"valid TLS": {
+			domain: &Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: "example.com"},
+				Spec: DomainSpec{
+					TLS: &DomainTLS{SecretName: "example-com-tls"},
+				},
+			},
+		},
+		"TLS missing secret": {
+			domain: &Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: "example.com"},
+				Spec: DomainSpec{
+					TLS: &DomainTLS{},
+				},
+			},
+			want: apis.ErrMissingField("spec.tls.secretName"),
+		},
+		"missing name": {
+			domain: &Domain{},
+			want:   apis.ErrMissingField("name"),
+		},
+		"invalid name": {
+			domain: &Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: "Example.com"},
+			},
+			want: apis.ErrInvalidValue("Example.com", "name"),
+		},
+		"shared with allowed spaces": {
+			domain: &Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: "example.com"},
+				Spec: DomainSpec{
+					AllowedSpaces: []string{"other-space"},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "only private domains can be limited to spaces",
+				Paths:   []string{"spec.allowedSpaces"},
+			},
+		},
+		"private default": {
+			domain: &Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: "example.com"},
+				Spec: DomainSpec{
+					OwnerSpace: "my-space",
+					Default:    true,
+				},
+			},
+			want: &apis.FieldError{
+				Message: "private domains can't be the default",
+				Paths:   []string{"spec.default"},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.domain.Validate(context.Background())
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func ExampleDomainSpec_AllowsSpace() {
+	spec := DomainSpec{
+		OwnerSpace:    "my-space",
+		AllowedSpaces: []string{"other-space"},
+	}
+
+	fmt.Println("Private:", spec.IsPrivate())
+	fmt.Println("my-space:", spec.AllowsSpace("my-space"))
+	fmt.Println("other-space:", spec.AllowsSpace("other-space"))
+	fmt.Println("third-space:", spec.AllowsSpace("third-space"))
+
+	// Output: Private: true
+	// my-space: true
+	// other-space: true
+	// third-space: false
+}
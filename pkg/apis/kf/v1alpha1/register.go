@@ -48,12 +48,16 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		SchemeGroupVersion,
 		&App{},
 		&AppList{},
+		&Domain{},
+		&DomainList{},
 		&Source{},
 		&SourceList{},
 		&Space{},
 		&SpaceList{},
 		&Route{},
 		&RouteList{},
+		&Task{},
+		&TaskList{},
 		&metav1.Status{},
 	)
 
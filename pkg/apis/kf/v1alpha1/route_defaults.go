@@ -42,6 +42,22 @@ func (k *RouteSpec) SetDefaults(ctx context.Context) {
 		algorithms.Strings(k.AppNames),
 	).(algorithms.Strings))
 
+	// Drop weights for Apps that are no longer bound to the route.
+	bound := make(map[string]bool)
+	for _, appName := range k.AppNames {
+		bound[appName] = true
+	}
+
+	for appName := range k.AppWeights {
+		if !bound[appName] {
+			delete(k.AppWeights, appName)
+		}
+	}
+
+	if len(k.AppWeights) == 0 {
+		k.AppWeights = nil
+	}
+
 	k.RouteSpecFields.SetDefaults(ctx)
 }
 
@@ -40,6 +40,49 @@ func TestRoute_SetDefaults_DedupeAppNames(t *testing.T) {
 	testutil.AssertContainsAll(t, strings.Join(r.Spec.AppNames, ""), []string{"a", "b", "c", "d"})
 }
 
+func TestRoute_SetDefaults_PruneAppWeights(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		appNames   []string
+		appWeights map[string]int
+		expected   map[string]int
+	}{
+		"no weights": {
+			appNames: []string{"a", "b"},
+		},
+		"keeps weights of bound apps": {
+			appNames:   []string{"a", "b"},
+			appWeights: map[string]int{"a": 20},
+			expected:   map[string]int{"a": 20},
+		},
+		"drops weights of unbound apps": {
+			appNames:   []string{"a"},
+			appWeights: map[string]int{"a": 20, "b": 80},
+			expected:   map[string]int{"a": 20},
+		},
+		"drops empty weights": {
+			appNames:   []string{"a"},
+			appWeights: map[string]int{"b": 80},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			r := &Route{
+				Spec: RouteSpec{
+					AppNames:   tc.appNames,
+					AppWeights: tc.appWeights,
+				},
+			}
+
+			r.SetDefaults(context.Background())
+
+			testutil.AssertEqual(t, "appWeights", tc.expected, r.Spec.AppWeights)
+		})
+	}
+}
+
 func ExampleRoute_SetDefaults_prefixRoutes() {
 	r := &Route{}
 	r.Spec.Path = "some-path"
@@ -15,10 +15,184 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"sort"
+
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 // GetGroupVersionKind returns the GroupVersionKind.
 func (r *Route) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("Route")
 }
+
+// ConditionType represents a Route condition value
+const (
+	// RouteConditionReady is set when the Route is configured and serving
+	// traffic to its Apps.
+	RouteConditionReady = apis.ConditionReady
+	// RouteConditionVirtualServiceReady is set when the VirtualService for
+	// the Route is ready.
+	RouteConditionVirtualServiceReady apis.ConditionType = "VirtualServiceReady"
+	// RouteConditionAppsReady is set when all the Apps bound to the Route
+	// exist and are ready.
+	RouteConditionAppsReady apis.ConditionType = "AppsReady"
+	// RouteConditionHostnameReserved is set when the Route's hostname and
+	// domain are reserved for its space.
+	RouteConditionHostnameReserved apis.ConditionType = "HostnameReserved"
+)
+
+func (status *RouteStatus) manage() apis.ConditionManager {
+	return apis.NewLivingConditionSet(
+		RouteConditionVirtualServiceReady,
+		RouteConditionAppsReady,
+		RouteConditionHostnameReserved,
+	).Manage(status)
+}
+
+// IsReady returns if the Route is ready to serve traffic.
+func (status *RouteStatus) IsReady() bool {
+	return status.manage().IsHappy()
+}
+
+// GetCondition returns the condition by name.
+func (status *RouteStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return status.manage().GetCondition(t)
+}
+
+// InitializeConditions sets the initial values to the conditions.
+func (status *RouteStatus) InitializeConditions() {
+	status.manage().InitializeConditions()
+}
+
+// VirtualServiceCondition gets a manager for the state of the VirtualService.
+func (status *RouteStatus) VirtualServiceCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), RouteConditionVirtualServiceReady, "VirtualService")
+}
+
+// MarkVirtualServiceReady notes that the VirtualService was reconciled.
+func (status *RouteStatus) MarkVirtualServiceReady() {
+	status.manage().MarkTrue(RouteConditionVirtualServiceReady)
+}
+
+// MarkHostnameReserved notes that the hostname and domain belong to the
+// Route's space.
+func (status *RouteStatus) MarkHostnameReserved() {
+	status.manage().MarkTrue(RouteConditionHostnameReserved)
+}
+
+// MarkHostnameReservedByOtherSpace notes that the hostname and domain are
+// already used by Routes in a different space.
+func (status *RouteStatus) MarkHostnameReservedByOtherSpace(hostname, domain, space string) {
+	status.manage().MarkFalse(RouteConditionHostnameReserved, "ReservedByOtherSpace",
+		fmt.Sprintf("The host %s and domain %s are reserved for space %q.", hostname, domain, space))
+}
+
+// PropagateAppsStatus updates the AppsReady condition to reflect the Apps
+// bound to the Route. Bound Apps that don't exist are reported by name.
+func (status *RouteStatus) PropagateAppsStatus(apps []*App, missing []string) {
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		status.manage().MarkFalse(RouteConditionAppsReady, "AppNotFound",
+			fmt.Sprintf("The bound App %q doesn't exist.", missing[0]))
+		return
+	}
+
+	for _, app := range apps {
+		cond := app.Status.GetCondition(AppConditionReady)
+		switch {
+		case cond == nil || cond.IsUnknown():
+			status.manage().MarkUnknown(RouteConditionAppsReady, "AppNotReady",
+				fmt.Sprintf("Waiting for App %q to become ready.", app.Name))
+			return
+		case cond.IsFalse():
+			status.manage().MarkFalse(RouteConditionAppsReady, "AppNotReady",
+				fmt.Sprintf("App %q isn't ready: %s", app.Name, cond.Message))
+			return
+		}
+	}
+
+	status.manage().MarkTrue(RouteConditionAppsReady)
+}
+
+func (status *RouteStatus) duck() *duckv1beta1.Status {
+	return &status.Status
+}
+
+// HasApp returns true if the App is bound to the Route.
+func (r *RouteSpec) HasApp(appName string) bool {
+	for _, name := range r.AppNames {
+		if name == appName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TrafficPercents returns the percentage of traffic each bound App receives.
+// Apps without a weight evenly split the traffic left over by weighted Apps.
+// If every App is weighted, the weights are scaled so they add up to 100.
+// Any rounding remainder is given to the first Apps sharing the traffic.
+func (r *RouteSpec) TrafficPercents() map[string]int {
+	percents := make(map[string]int)
+	if len(r.AppNames) == 0 {
+		return percents
+	}
+
+	var weighted, unweighted []string
+	total := 0
+	for _, appName := range r.AppNames {
+		weight, ok := r.AppWeights[appName]
+		switch {
+		case !ok:
+			unweighted = append(unweighted, appName)
+		case weight > 0:
+			weighted = append(weighted, appName)
+		}
+
+		percents[appName] = weight
+		total += weight
+	}
+
+	// sharing holds the Apps that split the traffic, and get the remainder.
+	var sharing []string
+	switch {
+	case len(unweighted) > 0:
+		sharing = unweighted
+		remaining := 100 - total
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		for _, appName := range unweighted {
+			percents[appName] = remaining / len(unweighted)
+		}
+	case total == 0:
+		// Every App has been drained but the traffic still has to go
+		// somewhere, so split it evenly.
+		sharing = r.AppNames
+		for _, appName := range r.AppNames {
+			percents[appName] = 100 / len(r.AppNames)
+		}
+	default:
+		sharing = weighted
+		for _, appName := range weighted {
+			percents[appName] = percents[appName] * 100 / total
+		}
+	}
+
+	assigned := 0
+	for _, percent := range percents {
+		assigned += percent
+	}
+
+	for i := 0; assigned < 100 && i < len(sharing); i++ {
+		percents[sharing[i]]++
+		assigned++
+	}
+
+	return percents
+}
@@ -15,11 +15,40 @@
 package v1alpha1
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	apitesting "knative.dev/pkg/apis/testing"
 )
 
+func TestRouteDuckTypes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		t    duck.Implementable
+	}{
+		{
+			name: "conditions",
+			t:    &duckv1beta1.Conditions{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := duck.VerifyType(&Route{}, test.t)
+			if err != nil {
+				t.Errorf("VerifyType(Route, %T) = %v", test.t, err)
+			}
+		})
+	}
+}
+
 func TestRouteGeneration(t *testing.T) {
 	route := Route{}
 	testutil.AssertEqual(t, "empty route generation", int64(0), route.GetGeneration())
@@ -28,3 +57,188 @@ func TestRouteGeneration(t *testing.T) {
 	route.SetGeneration(answer)
 	testutil.AssertEqual(t, "GetGeneration", answer, route.GetGeneration())
 }
+
+func TestRouteSpec_TrafficPercents(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		appNames   []string
+		appWeights map[string]int
+		expected   map[string]int
+	}{
+		"no apps": {
+			expected: map[string]int{},
+		},
+		"single app": {
+			appNames: []string{"a"},
+			expected: map[string]int{"a": 100},
+		},
+		"unweighted apps split evenly": {
+			appNames: []string{"a", "b", "c"},
+			expected: map[string]int{"a": 34, "b": 33, "c": 33},
+		},
+		"unweighted apps get the remainder": {
+			appNames:   []string{"a", "b", "c"},
+			appWeights: map[string]int{"a": 25},
+			expected:   map[string]int{"a": 25, "b": 38, "c": 37},
+		},
+		"canary": {
+			appNames:   []string{"stable", "canary"},
+			appWeights: map[string]int{"canary": 20},
+			expected:   map[string]int{"stable": 80, "canary": 20},
+		},
+		"weights are scaled": {
+			appNames:   []string{"a", "b"},
+			appWeights: map[string]int{"a": 10, "b": 30},
+			expected:   map[string]int{"a": 25, "b": 75},
+		},
+		"drained apps get nothing": {
+			appNames:   []string{"a", "b", "c"},
+			appWeights: map[string]int{"a": 0, "b": 1, "c": 2},
+			expected:   map[string]int{"a": 0, "b": 34, "c": 66},
+		},
+		"all apps drained": {
+			appNames:   []string{"a", "b"},
+			appWeights: map[string]int{"a": 0, "b": 0},
+			expected:   map[string]int{"a": 50, "b": 50},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			spec := &RouteSpec{
+				AppNames:   tc.appNames,
+				AppWeights: tc.appWeights,
+			}
+
+			testutil.AssertEqual(t, "percents", tc.expected, spec.TrafficPercents())
+		})
+	}
+}
+
+func TestRouteStatus_lifecycle(t *testing.T) {
+	t.Parallel()
+
+	readyApp := func(name string) *App {
+		app := &App{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		app.Status.InitializeConditions()
+		app.Status.manage().MarkTrue(AppConditionSourceReady)
+		app.Status.manage().MarkTrue(AppConditionKnativeServiceReady)
+		app.Status.manage().MarkTrue(AppConditionSpaceReady)
+		app.Status.manage().MarkTrue(AppConditionRouteReady)
+		return app
+	}
+
+	failedApp := func(name string) *App {
+		app := &App{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		app.Status.InitializeConditions()
+		app.Status.manage().MarkFalse(AppConditionSourceReady, "BuildFailed", "build failed")
+		return app
+	}
+
+	cases := map[string]struct {
+		Init func(*RouteStatus)
+
+		ExpectSucceeded []apis.ConditionType
+		ExpectFailed    []apis.ConditionType
+		ExpectOngoing   []apis.ConditionType
+	}{
+		"happy path": {
+			Init: func(status *RouteStatus) {
+				status.MarkHostnameReserved()
+				status.MarkVirtualServiceReady()
+				status.PropagateAppsStatus([]*App{readyApp("app-a"), readyApp("app-b")}, nil)
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionHostnameReserved,
+				RouteConditionVirtualServiceReady,
+				RouteConditionAppsReady,
+			},
+		},
+		"no apps": {
+			Init: func(status *RouteStatus) {
+				status.PropagateAppsStatus(nil, nil)
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				RouteConditionAppsReady,
+			},
+			ExpectOngoing: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionVirtualServiceReady,
+			},
+		},
+		"hostname reserved by other space": {
+			Init: func(status *RouteStatus) {
+				status.MarkHostnameReservedByOtherSpace("some-host", "example.com", "other-space")
+			},
+			ExpectFailed: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionHostnameReserved,
+			},
+		},
+		"virtual service error": {
+			Init: func(status *RouteStatus) {
+				status.MarkHostnameReserved()
+				status.VirtualServiceCondition().MarkReconciliationError("updating", errors.New("some-error"))
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				RouteConditionHostnameReserved,
+			},
+			ExpectFailed: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionVirtualServiceReady,
+			},
+		},
+		"missing app": {
+			Init: func(status *RouteStatus) {
+				status.PropagateAppsStatus([]*App{readyApp("app-a")}, []string{"app-b"})
+			},
+			ExpectFailed: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionAppsReady,
+			},
+		},
+		"failed app": {
+			Init: func(status *RouteStatus) {
+				status.PropagateAppsStatus([]*App{readyApp("app-a"), failedApp("app-b")}, nil)
+			},
+			ExpectFailed: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionAppsReady,
+			},
+		},
+		"app not ready yet": {
+			Init: func(status *RouteStatus) {
+				app := &App{ObjectMeta: metav1.ObjectMeta{Name: "app-a"}}
+				app.Status.InitializeConditions()
+				status.PropagateAppsStatus([]*App{app}, nil)
+			},
+			ExpectOngoing: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionAppsReady,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &RouteStatus{}
+			status.InitializeConditions()
+
+			tc.Init(status)
+
+			for _, exp := range tc.ExpectFailed {
+				apitesting.CheckConditionFailed(status.duck(), exp, t)
+			}
+
+			for _, exp := range tc.ExpectOngoing {
+				apitesting.CheckConditionOngoing(status.duck(), exp, t)
+			}
+
+			for _, exp := range tc.ExpectSucceeded {
+				apitesting.CheckConditionSucceeded(status.duck(), exp, t)
+			}
+		})
+	}
+}
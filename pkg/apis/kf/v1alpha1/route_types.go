@@ -16,11 +16,11 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
-// +genclient:noStatus
 
 // Route is a high level structure that encompasses an Istio VirtualService
 // and configuration applied to it.
@@ -31,6 +31,9 @@ type Route struct {
 
 	// +optional
 	Spec RouteSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status RouteStatus `json:"status,omitempty"`
 }
 
 // RouteSpec contains the specification for a route.
@@ -40,6 +43,17 @@ type RouteSpec struct {
 	// +patchStrategy=merge
 	AppNames []string `json:"appNames,omitempty"`
 
+	// AppWeights contains the percentage of traffic each App in AppNames
+	// receives. Apps without a weight evenly split the traffic that isn't
+	// assigned to weighted Apps.
+	// +optional
+	AppWeights map[string]int `json:"appWeights,omitempty"`
+
+	// HTTPSRedirect redirects plain HTTP requests for the route's host to
+	// HTTPS. It only has an effect if the route's domain has TLS configured.
+	// +optional
+	HTTPSRedirect bool `json:"httpsRedirect,omitempty"`
+
 	// RouteSpecFields contains the fields of a route.
 	RouteSpecFields `json:",inline"`
 }
@@ -61,6 +75,12 @@ type RouteSpecFields struct {
 	Path string `json:"path,omitempty"`
 }
 
+// RouteStatus represents information about the status of a Route.
+type RouteStatus struct {
+	// Pull in the fields from Knative's duckv1beta1 status field.
+	duckv1beta1.Status `json:",inline"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // RouteList is a list of Route resources
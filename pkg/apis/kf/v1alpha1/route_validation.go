@@ -46,27 +46,19 @@ func (r *Route) Validate(ctx context.Context) (errs *apis.FieldError) {
 		return errs
 	}
 
-	// XXX: We probably shouldn't be fetching VirtualServices in a webhook,
-	// however we need to ensure the resulting VirtualService doesn't
-	// conflict.
-	vs, err := IstioClientFromContext(ctx).
-		VirtualServices(KfNamespace).
-		Get(GenerateName(r.Spec.Hostname, r.Spec.Domain), metav1.GetOptions{})
-
-	if apierrs.IsNotFound(err) {
-		vs = nil
-	} else if err != nil {
-		return errs.Also(&apis.FieldError{
-			Message: "failed to validate hostname + domain collisions",
-			Details: fmt.Sprintf("failed to fetch VirtualServices: %s", err),
+	domain, err := DomainGetterFromContext(ctx).Get(r.Spec.Domain, metav1.GetOptions{})
+	switch {
+	case apierrs.IsNotFound(err):
+		// Domains that are only configured on spaces aren't restricted.
+	case err != nil:
+		errs = errs.Also(&apis.FieldError{
+			Message: "failed to validate domain ownership",
+			Details: fmt.Sprintf("failed to fetch Domain: %s", err),
 		})
-	}
-
-	if vs != nil && vs.Annotations["space"] != r.GetNamespace() {
+	case !domain.Spec.AllowsSpace(r.GetNamespace()):
 		errs = errs.Also(&apis.FieldError{
-			Message: "Immutable field changed",
-			Paths:   []string{"namespace"},
-			Details: fmt.Sprintf("The route is invalid: Routes for this host and domain have been reserved for another space."),
+			Message: fmt.Sprintf("the domain is private to space %q", domain.Spec.OwnerSpace),
+			Paths:   []string{"spec.domain"},
 		})
 	}
 
@@ -83,11 +75,34 @@ func (r *RouteSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		errs = errs.Also(apis.ErrInvalidValue("hostname", r.Hostname))
 	}
 
-	// validate we only have one AppNames.
-	// TODO: We don't want to keep this:
-	// https://github.com/google/kf/issues/279
-	for i := 1; i < len(r.AppNames); i++ {
-		errs = errs.Also(apis.ErrInvalidArrayValue(r.AppNames[i], "appNames", i))
+	errs = errs.Also(r.validateAppWeights())
+
+	return errs
+}
+
+// validateAppWeights makes sure each weight is a percentage and the weights
+// of the bound Apps don't add up to more than 100%.
+func (r *RouteSpec) validateAppWeights() (errs *apis.FieldError) {
+	total := 0
+	for _, appName := range r.AppNames {
+		weight, ok := r.AppWeights[appName]
+		if !ok {
+			continue
+		}
+
+		if weight < 0 || weight > 100 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(weight, 0, 100, fmt.Sprintf("appWeights[%s]", appName)))
+			continue
+		}
+
+		total += weight
+	}
+
+	if total > 100 {
+		errs = errs.Also(&apis.FieldError{
+			Message: "app weights must not add up to more than 100",
+			Paths:   []string{"appWeights"},
+		})
 	}
 
 	return errs
@@ -17,20 +17,21 @@ package v1alpha1
 import (
 	"context"
 	"errors"
-	"fmt"
 	"testing"
 
 	"github.com/google/kf/pkg/kf/testutil"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	ktesting "k8s.io/client-go/testing"
 	"knative.dev/pkg/apis"
-	"knative.dev/pkg/apis/istio/v1alpha3"
-	"knative.dev/pkg/client/clientset/versioned/typed/istio/v1alpha3/fake"
 )
 
+type fakeDomainGetter func(name string) (*Domain, error)
+
+func (f fakeDomainGetter) Get(name string, options metav1.GetOptions) (*Domain, error) {
+	return f(name)
+}
+
 func TestRouteValidation(t *testing.T) {
 	goodObjMeta := metav1.ObjectMeta{
 		Name:      "valid",
@@ -45,7 +46,7 @@ func TestRouteValidation(t *testing.T) {
 	cases := map[string]struct {
 		route        *Route
 		want         *apis.FieldError
-		setup        func(t *testing.T, fake *fake.FakeNetworkingV1alpha3)
+		domains      fakeDomainGetter
 		setupContext func(ctx context.Context) context.Context
 	}{
 		"good": {
@@ -102,96 +103,127 @@ func TestRouteValidation(t *testing.T) {
 			route: &Route{
 				ObjectMeta: goodObjMeta,
 				Spec: RouteSpec{
-					AppNames: []string{"app-1", "app-2", "app-3"},
+					AppNames:   []string{"app-1", "app-2", "app-3"},
+					AppWeights: map[string]int{"app-1": 20, "app-2": 80},
+					RouteSpecFields: RouteSpecFields{
+						Domain: "example.com",
+					},
+				},
+			},
+		},
+		"app weight out of bounds": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AppNames:   []string{"app-1", "app-2"},
+					AppWeights: map[string]int{"app-1": -1, "app-2": 101},
+					RouteSpecFields: RouteSpecFields{
+						Domain: "example.com",
+					},
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(-1, 0, 100, "spec.appWeights[app-1]").
+				Also(apis.ErrOutOfBoundsValue(101, 0, 100, "spec.appWeights[app-2]")),
+		},
+		"app weights add up to more than 100": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AppNames:   []string{"app-1", "app-2"},
+					AppWeights: map[string]int{"app-1": 60, "app-2": 60},
 					RouteSpecFields: RouteSpecFields{
 						Domain: "example.com",
 					},
 				},
 			},
-			want: apis.ErrInvalidArrayValue("app-2", "spec.appNames", 1).Also(apis.ErrInvalidArrayValue("app-3", "spec.appNames", 2)),
+			want: &apis.FieldError{
+				Message: "app weights must not add up to more than 100",
+				Paths:   []string{"spec.appWeights"},
+			},
 		},
-		"fetching VirtualServices returns an error": {
-			setup: func(t *testing.T, fake *fake.FakeNetworkingV1alpha3) {
-				fake.AddReactor("get", "virtualservices", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
-					testutil.AssertEqual(t, "namespace", KfNamespace, action.GetNamespace())
-					return true, nil, errors.New("some-error")
-				})
+		"fetching the Domain returns an error": {
+			domains: func(name string) (*Domain, error) {
+				return nil, errors.New("some-error")
 			},
 			route: &Route{
 				ObjectMeta: goodObjMeta,
 				Spec:       goodRouteSpec,
 			},
 			want: &apis.FieldError{
-				Message: "failed to validate hostname + domain collisions",
-				Details: "failed to fetch VirtualServices: some-error",
+				Message: "failed to validate domain ownership",
+				Details: "failed to fetch Domain: some-error",
 			},
 		},
-		"fetching VirtualServices returns a not found error": {
-			setup: func(t *testing.T, fake *fake.FakeNetworkingV1alpha3) {
-				fake.AddReactor("get", "virtualservices", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
-					testutil.AssertEqual(t, "namespace", KfNamespace, action.GetNamespace())
-					return true, nil, apierrs.NewNotFound(schema.GroupResource{}, "some-name")
-				})
+		"Domain doesn't exist": {
+			domains: func(name string) (*Domain, error) {
+				return nil, apierrs.NewNotFound(schema.GroupResource{}, name)
 			},
 			route: &Route{
 				ObjectMeta: goodObjMeta,
 				Spec:       goodRouteSpec,
 			},
 		},
-		"existing VirtualService has different space annotation": {
-			setup: func(t *testing.T, fake *fake.FakeNetworkingV1alpha3) {
-				fake.AddReactor("get", "virtualservices", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
-					testutil.AssertEqual(t, "namespace", KfNamespace, action.GetNamespace())
-					return true, &v1alpha3.VirtualService{
-						ObjectMeta: metav1.ObjectMeta{
-							Annotations: map[string]string{
-								"space": "some-other-space",
-							},
-						},
-					}, nil
-				})
+		"Domain is shared": {
+			domains: func(name string) (*Domain, error) {
+				return &Domain{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+			},
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec:       goodRouteSpec,
+			},
+		},
+		"Domain is shared with the space": {
+			domains: func(name string) (*Domain, error) {
+				return &Domain{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Spec: DomainSpec{
+						OwnerSpace:    "some-other-space",
+						AllowedSpaces: []string{"valid"},
+					},
+				}, nil
+			},
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec:       goodRouteSpec,
+			},
+		},
+		"Domain is private to another space": {
+			domains: func(name string) (*Domain, error) {
+				testutil.AssertEqual(t, "domain", "example.com", name)
+				return &Domain{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Spec:       DomainSpec{OwnerSpace: "some-other-space"},
+				}, nil
 			},
 			route: &Route{
 				ObjectMeta: goodObjMeta,
 				Spec:       goodRouteSpec,
 			},
 			want: &apis.FieldError{
-				Message: "Immutable field changed",
-				Paths:   []string{"namespace"},
-				Details: fmt.Sprintf("The route is invalid: Routes for this host and domain have been reserved for another space."),
+				Message: `the domain is private to space "some-other-space"`,
+				Paths:   []string{"spec.domain"},
 			},
 		},
 	}
 
 	for tn, tc := range cases {
 		t.Run(tn, func(t *testing.T) {
-			f := &fake.FakeNetworkingV1alpha3{
-				Fake: &ktesting.Fake{},
-			}
-
-			if tc.setup == nil {
-				tc.setup = func(t *testing.T, fake *fake.FakeNetworkingV1alpha3) {
-					fake.AddReactor("get", "virtualservices", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
-						testutil.AssertEqual(t, "namespace", KfNamespace, action.GetNamespace())
-						return true, &v1alpha3.VirtualService{
-							ObjectMeta: metav1.ObjectMeta{
-								Annotations: map[string]string{
-									"space": "valid",
-								},
-							},
-						}, nil
-					})
+			if tc.domains == nil {
+				tc.domains = func(name string) (*Domain, error) {
+					return &Domain{
+						ObjectMeta: metav1.ObjectMeta{Name: name},
+						Spec:       DomainSpec{OwnerSpace: "valid"},
+					}, nil
 				}
 			}
 
 			ctx := context.Background()
 			if tc.setupContext == nil {
 				tc.setupContext = func(ctx context.Context) context.Context {
-					return SetupIstioClient(ctx, f)
+					return SetupDomainGetter(ctx, tc.domains)
 				}
 			}
 
-			tc.setup(t, f)
 			ctx = tc.setupContext(ctx)
 
 			got := tc.route.Validate(ctx)
@@ -16,6 +16,12 @@ package v1alpha1
 
 import "context"
 
+const (
+	// DefaultDockerfilePath is the path of the Dockerfile used when building
+	// the source if one isn't specified.
+	DefaultDockerfilePath = "Dockerfile"
+)
+
 // SetDefaults implements apis.Defaultable
 func (k *Source) SetDefaults(ctx context.Context) {
 	k.Spec.SetDefaults(ctx)
@@ -23,7 +29,16 @@ func (k *Source) SetDefaults(ctx context.Context) {
 
 // SetDefaults implements apis.Defaultable
 func (k *SourceSpec) SetDefaults(ctx context.Context) {
-	// XXX: currently no defaults to set
+	if k.IsDockerfileBuild() {
+		k.Dockerfile.SetDefaults(ctx)
+	}
+}
+
+// SetDefaults implements apis.Defaultable
+func (k *SourceSpecDockerfile) SetDefaults(ctx context.Context) {
+	if k.Path == "" {
+		k.Path = DefaultDockerfilePath
+	}
 }
 
 // SetSpaceDefaults sets the default values for the source based on the space's
@@ -35,6 +50,14 @@ func (k *Source) SetSpaceDefaults(space *Space) {
 // SetSpaceDefaults sets the default values for the SourceSpec based on the
 // space's settings.
 func (k *SourceSpec) SetSpaceDefaults(space *Space) {
+	if k.Backend == "" {
+		k.Backend = space.Spec.BuildpackBuild.Backend
+	}
+
+	if k.Timeout == nil {
+		k.Timeout = space.Spec.BuildpackBuild.Timeout
+	}
+
 	if k.IsBuildpackBuild() {
 		if k.BuildpackBuild.BuildpackBuilder == "" {
 			k.BuildpackBuild.BuildpackBuilder = space.Spec.BuildpackBuild.BuilderImage
@@ -44,7 +67,29 @@ func (k *SourceSpec) SetSpaceDefaults(space *Space) {
 			k.BuildpackBuild.Registry = space.Spec.BuildpackBuild.ContainerRegistry
 		}
 
+		if k.BuildpackBuild.Stack == "" {
+			k.BuildpackBuild.Stack = space.Spec.BuildpackBuild.Stack
+		}
+
 		// user defined values in buildpackbuild.env take priority from buildpackbuild.env
 		k.BuildpackBuild.Env = append(space.Spec.BuildpackBuild.Env, k.BuildpackBuild.Env...)
 	}
+
+	if k.IsDockerfileBuild() && k.Dockerfile.Registry == "" {
+		k.Dockerfile.Registry = space.Spec.BuildpackBuild.ContainerRegistry
+	}
+
+	if k.IsRebase() {
+		if k.Rebase.BuildpackBuilder == "" {
+			k.Rebase.BuildpackBuilder = space.Spec.BuildpackBuild.BuilderImage
+		}
+
+		if k.Rebase.Registry == "" {
+			k.Rebase.Registry = space.Spec.BuildpackBuild.ContainerRegistry
+		}
+
+		if k.Rebase.Stack == "" {
+			k.Rebase.Stack = space.Spec.BuildpackBuild.Stack
+		}
+	}
 }
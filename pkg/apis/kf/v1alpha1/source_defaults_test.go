@@ -0,0 +1,69 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import "fmt"
+
+func ExampleSourceSpec_SetSpaceDefaults() {
+	space := &Space{}
+	space.Spec.BuildpackBuild.BuilderImage = "gcr.io/my-registry/builder:latest"
+	space.Spec.BuildpackBuild.ContainerRegistry = "gcr.io/my-registry"
+	space.Spec.BuildpackBuild.Stack = "gcr.io/my-registry/run:cflinuxfs3"
+
+	spec := &SourceSpec{}
+	spec.BuildpackBuild.Source = "gcr.io/my-registry/src-my-app"
+	spec.SetSpaceDefaults(space)
+
+	fmt.Println("Builder:", spec.BuildpackBuild.BuildpackBuilder)
+	fmt.Println("Registry:", spec.BuildpackBuild.Registry)
+	fmt.Println("Stack:", spec.BuildpackBuild.Stack)
+
+	// Output: Builder: gcr.io/my-registry/builder:latest
+	// Registry: gcr.io/my-registry
+	// Stack: gcr.io/my-registry/run:cflinuxfs3
+}
+
+func ExampleSourceSpec_SetSpaceDefaults_stackOverride() {
+	space := &Space{}
+	space.Spec.BuildpackBuild.Stack = "gcr.io/my-registry/run:cflinuxfs3"
+
+	spec := &SourceSpec{}
+	spec.BuildpackBuild.Source = "gcr.io/my-registry/src-my-app"
+	spec.BuildpackBuild.Stack = "gcr.io/my-registry/run:bionic"
+	spec.SetSpaceDefaults(space)
+
+	fmt.Println("Stack:", spec.BuildpackBuild.Stack)
+
+	// Output: Stack: gcr.io/my-registry/run:bionic
+}
+
+func ExampleSourceSpec_SetSpaceDefaults_rebase() {
+	space := &Space{}
+	space.Spec.BuildpackBuild.BuilderImage = "gcr.io/my-registry/builder:latest"
+	space.Spec.BuildpackBuild.ContainerRegistry = "gcr.io/my-registry"
+	space.Spec.BuildpackBuild.Stack = "gcr.io/my-registry/run:cflinuxfs3"
+
+	spec := &SourceSpec{}
+	spec.Rebase.Image = "gcr.io/my-registry/app-my-space-my-app-abcd:1"
+	spec.SetSpaceDefaults(space)
+
+	fmt.Println("Builder:", spec.Rebase.BuildpackBuilder)
+	fmt.Println("Registry:", spec.Rebase.Registry)
+	fmt.Println("Stack:", spec.Rebase.Stack)
+
+	// Output: Builder: gcr.io/my-registry/builder:latest
+	// Registry: gcr.io/my-registry
+	// Stack: gcr.io/my-registry/run:cflinuxfs3
+}
@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strings"
 
 	build "github.com/knative/build/pkg/apis/build/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
@@ -38,6 +39,24 @@ const (
 	BuildArgImage            = "IMAGE"
 	BuildArgBuildpack        = "BUILDPACK"
 	BuildArgBuildpackBuilder = "BUILDER_IMAGE"
+	BuildArgRunImage         = "RUN_IMAGE"
+	BuildArgDockerfile       = "DOCKERFILE"
+	BuildArgBuildArgs        = "BUILD_ARGS"
+	BuildArgCache            = "CACHE"
+	BuildArgSourceImage      = "SOURCE_IMAGE"
+
+	// GitCommitMessagePrefix prefixes the termination message of the build
+	// step that clones a Git source. The rest of the message holds the
+	// commit that was checked out.
+	GitCommitMessagePrefix = "commit="
+
+	// BuildTimeoutReason is the reason a Source fails when its build runs
+	// longer than the Source's timeout.
+	BuildTimeoutReason = "BuildTimeout"
+
+	// BuildCancelledReason is the reason a Source fails when its build is
+	// cancelled.
+	BuildCancelledReason = "BuildCancelled"
 )
 
 func (status *SourceStatus) manage() apis.ConditionManager {
@@ -65,30 +84,128 @@ func (status *SourceStatus) MarkBuildNotOwned(name string) {
 		fmt.Sprintf("There is an existing Build %q that we do not own.", name))
 }
 
+// MarkBuildCancelled notes that the Source's build was cancelled.
+func (status *SourceStatus) MarkBuildCancelled() {
+	status.manage().MarkFalse(SourceConditionBuildSucceeded, BuildCancelledReason, "Build was cancelled")
+}
+
+// MarkBuildBackendError notes that the Source's build can't be run by its
+// build backend.
+func (status *SourceStatus) MarkBuildBackendError(err error) {
+	status.manage().MarkFalse(SourceConditionBuildSucceeded, "BuildBackendError", "%s", err)
+}
+
+// BuildResult is the state of a Source's build reported by a build backend.
+type BuildResult struct {
+	// Name is the name of the build.
+	Name string
+
+	// Backend is the build backend that runs the build.
+	Backend string
+
+	// Status is the status of the build's Succeeded condition. It's blank if
+	// the build hasn't reported one yet.
+	Status corev1.ConditionStatus
+
+	// Reason and Message explain the Status.
+	Reason  string
+	Message string
+
+	// Image is the image the build produced, it's only set once the build
+	// succeeds.
+	Image string
+
+	// GitCommit is the commit the build cloned if the source came from a Git
+	// repository.
+	GitCommit string
+}
+
 // PropagateBuildStatus copies fields from the Build status to Space
 // and updates the readiness based on the current phase.
 func (status *SourceStatus) PropagateBuildStatus(build *build.Build) {
+	status.PropagateBuildResult(KnativeBuildResult(build))
+}
 
+// KnativeBuildResult converts the status of a Knative Build into a
+// BuildResult.
+func KnativeBuildResult(build *build.Build) *BuildResult {
 	if build == nil {
-		return
+		return nil
 	}
 
-	status.BuildName = build.Name
+	result := &BuildResult{
+		Name:    build.Name,
+		Backend: BuildBackendKnativeBuild,
+	}
 
 	for _, condition := range build.Status.GetConditions() {
 		if condition.Type == "Succeeded" {
-			switch condition.Status {
-			case corev1.ConditionTrue:
-				status.Image = GetBuildArg(build, BuildArgImage)
-
-				status.manage().MarkTrue(SourceConditionBuildSucceeded)
-			case corev1.ConditionFalse:
-				status.manage().MarkFalse(SourceConditionBuildSucceeded, condition.Reason, "Build failed: %s", condition.Message)
-			case corev1.ConditionUnknown:
-				status.manage().MarkUnknown(SourceConditionBuildSucceeded, condition.Reason, "Build in progress")
+			result.Status = condition.Status
+			result.Reason = condition.Reason
+			result.Message = condition.Message
+
+			if condition.Status == corev1.ConditionTrue {
+				result.Image = GetBuildArg(build, BuildArgImage)
+				result.GitCommit = GetBuildGitCommit(build)
 			}
 		}
 	}
+
+	return result
+}
+
+// PropagateBuildResult copies the result of a build backend to the Source
+// and updates the readiness based on the current phase.
+func (status *SourceStatus) PropagateBuildResult(result *BuildResult) {
+
+	if result == nil {
+		return
+	}
+
+	sameBuild := status.BuildName == result.Name
+	status.BuildName = result.Name
+	if result.Backend != "" {
+		status.BuildBackend = result.Backend
+	}
+
+	switch result.Status {
+	case corev1.ConditionTrue:
+		status.Image = result.Image
+		status.GitCommit = result.GitCommit
+
+		status.manage().MarkTrue(SourceConditionBuildSucceeded)
+	case corev1.ConditionFalse:
+		switch result.Reason {
+		case BuildTimeoutReason:
+			status.manage().MarkFalse(SourceConditionBuildSucceeded, result.Reason, "Build timed out: %s", result.Message)
+		case BuildCancelledReason:
+			status.MarkBuildCancelled()
+		default:
+			status.manage().MarkFalse(SourceConditionBuildSucceeded, result.Reason, "Build failed: %s", result.Message)
+		}
+	case corev1.ConditionUnknown:
+		if sameBuild && status.buildCancelled() {
+			// The build keeps running until its backend notices it was
+			// cancelled, don't bring the Source back in the meantime.
+			return
+		}
+
+		status.manage().MarkUnknown(SourceConditionBuildSucceeded, result.Reason, "Build in progress")
+	}
+}
+
+// CancelRequested returns true if the user asked to cancel the Source's
+// current build and it hasn't been marked cancelled yet.
+func (source *Source) CancelRequested() bool {
+	return source.Status.BuildName != "" &&
+		source.Annotations[CancelledBuildAnnotation] == source.Status.BuildName &&
+		!source.Status.buildCancelled()
+}
+
+// buildCancelled returns true if the Source was marked cancelled.
+func (status *SourceStatus) buildCancelled() bool {
+	cond := status.GetCondition(SourceConditionBuildSucceeded)
+	return cond != nil && cond.IsFalse() && cond.Reason == BuildCancelledReason
 }
 
 func GetBuildArg(b *build.Build, key string) string {
@@ -100,6 +217,21 @@ func GetBuildArg(b *build.Build, key string) string {
 	return ""
 }
 
+// GetBuildGitCommit returns the commit a Build cloned its Git source at or
+// blank if the source didn't come from Git.
+func GetBuildGitCommit(b *build.Build) string {
+	for _, state := range b.Status.StepStates {
+		if state.Terminated == nil {
+			continue
+		}
+
+		if strings.HasPrefix(state.Terminated.Message, GitCommitMessagePrefix) {
+			return strings.TrimPrefix(state.Terminated.Message, GitCommitMessagePrefix)
+		}
+	}
+	return ""
+}
+
 func (status *SourceStatus) duck() *duckv1beta1.Status {
 	return &status.Status
 }
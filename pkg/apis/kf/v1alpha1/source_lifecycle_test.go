@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/kf/pkg/kf/testutil"
@@ -244,6 +245,128 @@ func TestSourceHappyPath(t *testing.T) {
 	testutil.AssertEqual(t, "Image", "some-container-image", status.Image)
 }
 
+func TestSourceStatus_PropagateBuildStatus_gitCommit(t *testing.T) {
+	status := initTestSourceStatus(t)
+
+	build := happyBuild()
+	build.Status.StepStates = []corev1.ContainerState{
+		{Terminated: &corev1.ContainerStateTerminated{}},
+		{Terminated: &corev1.ContainerStateTerminated{Message: GitCommitMessagePrefix + "abc123"}},
+		{Running: &corev1.ContainerStateRunning{}},
+	}
+	status.PropagateBuildStatus(build)
+	testutil.AssertEqual(t, "GitCommit", "abc123", status.GitCommit)
+
+	// A later build from a container image clears the commit.
+	status.PropagateBuildStatus(happyBuild())
+	testutil.AssertEqual(t, "GitCommit", "", status.GitCommit)
+}
+
+func TestSourceStatus_PropagateBuildResult(t *testing.T) {
+	status := initTestSourceStatus(t)
+
+	status.PropagateBuildResult(&BuildResult{
+		Name:    "some-taskrun",
+		Backend: BuildBackendTekton,
+		Status:  corev1.ConditionUnknown,
+		Reason:  "Running",
+	})
+
+	apitesting.CheckConditionOngoing(status.duck(), SourceConditionBuildSucceeded, t)
+	testutil.AssertEqual(t, "BuildName", "some-taskrun", status.BuildName)
+	testutil.AssertEqual(t, "BuildBackend", BuildBackendTekton, status.BuildBackend)
+
+	status.PropagateBuildResult(&BuildResult{
+		Name:    "some-taskrun",
+		Backend: BuildBackendTekton,
+		Status:  corev1.ConditionFalse,
+		Reason:  "Failed",
+		Message: "step build exited with code 1",
+		Image:   "some-image",
+	})
+
+	apitesting.CheckConditionFailed(status.duck(), SourceConditionBuildSucceeded, t)
+	testutil.AssertEqual(t, "Image", "", status.Image) // Image is only set when the build succeeds
+	testutil.AssertEqual(t, "Message", "Build failed: step build exited with code 1", status.GetCondition(SourceConditionBuildSucceeded).Message)
+}
+
+func TestSourceStatus_PropagateBuildResult_timeout(t *testing.T) {
+	status := initTestSourceStatus(t)
+
+	status.PropagateBuildResult(&BuildResult{
+		Name:    "some-build",
+		Status:  corev1.ConditionFalse,
+		Reason:  BuildTimeoutReason,
+		Message: "Build \"some-build\" failed to finish within \"10m0s\"",
+	})
+
+	apitesting.CheckConditionFailed(status.duck(), SourceConditionBuildSucceeded, t)
+	cond := status.GetCondition(SourceConditionBuildSucceeded)
+	testutil.AssertEqual(t, "Reason", BuildTimeoutReason, cond.Reason)
+	testutil.AssertEqual(t, "Message", `Build timed out: Build "some-build" failed to finish within "10m0s"`, cond.Message)
+}
+
+func TestSourceStatus_MarkBuildCancelled(t *testing.T) {
+	status := initTestSourceStatus(t)
+
+	status.PropagateBuildResult(&BuildResult{
+		Name:   "some-build",
+		Status: corev1.ConditionUnknown,
+		Reason: "Running",
+	})
+	status.MarkBuildCancelled()
+
+	apitesting.CheckConditionFailed(status.duck(), SourceConditionBuildSucceeded, t)
+	testutil.AssertEqual(t, "Reason", BuildCancelledReason, status.GetCondition(SourceConditionBuildSucceeded).Reason)
+
+	// The build still runs until the backend notices the cancellation.
+	status.PropagateBuildResult(&BuildResult{
+		Name:   "some-build",
+		Status: corev1.ConditionUnknown,
+		Reason: "Running",
+	})
+	apitesting.CheckConditionFailed(status.duck(), SourceConditionBuildSucceeded, t)
+
+	// A new build isn't affected by the old cancellation.
+	status.PropagateBuildResult(&BuildResult{
+		Name:   "other-build",
+		Status: corev1.ConditionUnknown,
+		Reason: "Running",
+	})
+	apitesting.CheckConditionOngoing(status.duck(), SourceConditionBuildSucceeded, t)
+}
+
+func TestSource_CancelRequested(t *testing.T) {
+	source := &Source{}
+	source.Status = *initTestSourceStatus(t)
+	testutil.AssertEqual(t, "not started", false, source.CancelRequested())
+
+	source.Status.PropagateBuildResult(&BuildResult{
+		Name:   "some-build",
+		Status: corev1.ConditionUnknown,
+		Reason: "Running",
+	})
+	testutil.AssertEqual(t, "no annotation", false, source.CancelRequested())
+
+	source.Annotations = map[string]string{CancelledBuildAnnotation: "old-build"}
+	testutil.AssertEqual(t, "other build", false, source.CancelRequested())
+
+	source.Annotations[CancelledBuildAnnotation] = "some-build"
+	testutil.AssertEqual(t, "requested", true, source.CancelRequested())
+
+	source.Status.MarkBuildCancelled()
+	testutil.AssertEqual(t, "already cancelled", false, source.CancelRequested())
+}
+
+func TestSourceStatus_MarkBuildBackendError(t *testing.T) {
+	status := initTestSourceStatus(t)
+
+	status.MarkBuildBackendError(errors.New(`the build backend "tekton" isn't enabled`))
+
+	apitesting.CheckConditionFailed(status.duck(), SourceConditionBuildSucceeded, t)
+	testutil.AssertEqual(t, "Reason", "BuildBackendError", status.GetCondition(SourceConditionBuildSucceeded).Reason)
+}
+
 func TestSourceStatus_lifecycle(t *testing.T) {
 	cases := map[string]struct {
 		Init func(*SourceStatus)
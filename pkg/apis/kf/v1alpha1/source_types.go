@@ -38,7 +38,8 @@ type Source struct {
 }
 
 // SourceSpec defines the source code for an App.
-// The fields ContainerImage and BuildpackBuild are mutually exclusive.
+// The fields ContainerImage, BuildpackBuild, Dockerfile and Rebase are
+// mutually exclusive.
 type SourceSpec struct {
 
 	// UpdateRequests is a unique identifier for an SourceSpec.
@@ -58,6 +59,41 @@ type SourceSpec struct {
 	// BuildpackBuild defines buildpack information for source.
 	// +optional
 	BuildpackBuild SourceSpecBuildpackBuild `json:"buildpackBuild,omitempty"`
+
+	// Dockerfile defines building the source using a Dockerfile.
+	// +optional
+	Dockerfile SourceSpecDockerfile `json:"dockerfile,omitempty"`
+
+	// Rebase defines moving the layers of an App image built by buildpacks
+	// onto the latest run image of its stack.
+	// +optional
+	Rebase SourceSpecRebase `json:"rebase,omitempty"`
+
+	// Backend is the build system that runs the Source's build. The
+	// cluster's default is used if it's blank.
+	// +optional
+	Backend string `json:"backend,omitempty"`
+
+	// Timeout is the longest the build can run before it's stopped and the
+	// Source fails. The build backend's default is used if it's not set.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+const (
+	// BuildBackendKnativeBuild runs builds as Knative Builds from
+	// ClusterBuildTemplates.
+	BuildBackendKnativeBuild = "knative-build"
+
+	// BuildBackendTekton runs builds as Tekton Pipelines TaskRuns from
+	// ClusterTasks.
+	BuildBackendTekton = "tekton"
+)
+
+// BuildBackends contains all the valid build backends.
+var BuildBackends = []string{
+	BuildBackendKnativeBuild,
+	BuildBackendTekton,
 }
 
 // SourceSpecContainerImage defines a container image for an App.
@@ -65,13 +101,26 @@ type SourceSpecContainerImage struct {
 
 	// Image is the container image URI for the App.
 	Image string `json:"image"`
+
+	// BuildpackBuild is the build that produced Image when Kf built it. It's
+	// restored when the App is restaged or used when it's rebased so Apps
+	// redeployed from their previous image can still be rebuilt.
+	// +optional
+	BuildpackBuild *SourceSpecBuildpackBuild `json:"buildpackBuild,omitempty"`
 }
 
 // SourceSpecBuildpackBuild defines building an App using Buildpacks.
 type SourceSpecBuildpackBuild struct {
 
 	// Source is the Container Image which contains the App's source code.
-	Source string `json:"source"`
+	// It can't be used with Git.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Git is the Git repository which contains the App's source code.
+	// It can't be used with Source.
+	// +optional
+	Git *SourceSpecGit `json:"git,omitempty"`
 
 	// Stack is the base layer to use for the App.
 	// +optional
@@ -89,6 +138,82 @@ type SourceSpecBuildpackBuild struct {
 
 	// Env represents the environment variables to apply when building the App.
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// CacheClaimName is the PersistentVolumeClaim the buildpack layers are
+	// cached in between builds. The layers aren't cached if it's blank.
+	// +optional
+	CacheClaimName string `json:"cacheClaimName,omitempty"`
+}
+
+// SourceSpecDockerfile defines building an App using a Dockerfile.
+type SourceSpecDockerfile struct {
+
+	// Source is the Container Image which contains the App's source code.
+	// It can't be used with Git.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Git is the Git repository which contains the App's source code.
+	// It can't be used with Source.
+	// +optional
+	Git *SourceSpecGit `json:"git,omitempty"`
+
+	// Path is the path of the Dockerfile relative to the root of the source.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// BuildArgs holds the values passed to the build as --build-arg flags.
+	// +optional
+	BuildArgs []corev1.EnvVar `json:"buildArgs,omitempty"`
+
+	// Registry is the container registry which will store the built image.
+	Registry string `json:"registry"`
+}
+
+// SourceSpecRebase defines rebasing an App image built by buildpacks onto a
+// new run image without rebuilding it.
+type SourceSpecRebase struct {
+	// Image is the App image built by buildpacks that's rebased. It isn't
+	// modified, the rebased image is stored in Registry.
+	Image string `json:"image"`
+
+	// Stack is the run image the App's layers are moved onto.
+	// +optional
+	Stack string `json:"stack,omitempty"`
+
+	// BuildpackBuilder is the container image which holds the buildpacks
+	// lifecycle used to rebase the image.
+	BuildpackBuilder string `json:"buildpackBuilder"`
+
+	// Registry is the container registry which will store the rebased image.
+	Registry string `json:"registry"`
+
+	// BuildpackBuild is the build that originally produced Image. It's
+	// restored when the App is restaged so the App can be rebuilt from its
+	// source code.
+	// +optional
+	BuildpackBuild *SourceSpecBuildpackBuild `json:"buildpackBuild,omitempty"`
+}
+
+// SourceSpecGit defines source code stored in a Git repository.
+type SourceSpecGit struct {
+
+	// URL is the URL of the repository to clone.
+	URL string `json:"url"`
+
+	// Revision is the branch, tag or commit to build. The repository's default
+	// branch is used if it's blank.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Subdirectory is the path of the source code within the repository.
+	// +optional
+	Subdirectory string `json:"subdirectory,omitempty"`
+
+	// SecretName is the name of a basic-auth or ssh-auth Secret holding the
+	// credentials used to clone the repository.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
 }
 
 // SourceStatus is the current configuration and running state for an App's Source.
@@ -110,6 +235,16 @@ type SourceStatusFields struct {
 	// BuildName is the name of the build that produced the image.
 	// +optional
 	BuildName string `json:"buildName,omitempty"`
+
+	// BuildBackend is the build system that runs the build named by
+	// BuildName.
+	// +optional
+	BuildBackend string `json:"buildBackend,omitempty"`
+
+	// GitCommit is the commit the image was built from if the source was
+	// cloned from a Git repository.
+	// +optional
+	GitCommit string `json:"gitCommit,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -129,5 +264,32 @@ func (spec *SourceSpec) IsContainerBuild() bool {
 
 // IsBuildpackBuild returns true if the build is for a buildpack
 func (spec *SourceSpec) IsBuildpackBuild() bool {
-	return spec.BuildpackBuild.Source != ""
+	return spec.BuildpackBuild.Source != "" || spec.BuildpackBuild.Git != nil
+}
+
+// IsDockerfileBuild returns true if the build is for a Dockerfile
+func (spec *SourceSpec) IsDockerfileBuild() bool {
+	return spec.Dockerfile.Source != "" || spec.Dockerfile.Git != nil
+}
+
+// IsRebase returns true if the build rebases an existing image
+func (spec *SourceSpec) IsRebase() bool {
+	return spec.Rebase.Image != ""
+}
+
+// OriginalBuildpackBuild returns the buildpack build that produced the
+// Source's image, either because the Source is that build or because it was
+// recorded when the image was rebased or redeployed. It returns nil if the
+// image wasn't built with buildpacks.
+func (spec *SourceSpec) OriginalBuildpackBuild() *SourceSpecBuildpackBuild {
+	switch {
+	case spec.IsBuildpackBuild():
+		return spec.BuildpackBuild.DeepCopy()
+	case spec.IsRebase():
+		return spec.Rebase.BuildpackBuild.DeepCopy()
+	case spec.IsContainerBuild():
+		return spec.ContainerImage.BuildpackBuild.DeepCopy()
+	default:
+		return nil
+	}
 }
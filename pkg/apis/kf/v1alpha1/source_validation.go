@@ -16,7 +16,10 @@ package v1alpha1
 
 import (
 	"context"
+	"path"
+	"strings"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -34,14 +37,40 @@ func (source *Source) Validate(ctx context.Context) (errs *apis.FieldError) {
 // Validate makes sure that a SourceSpec is properly configured.
 func (spec *SourceSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 
-	if spec.IsContainerBuild() && spec.IsBuildpackBuild() {
-		errs = errs.Also(apis.ErrMultipleOneOf("buildpackBuild", "containerImage"))
-	} else if spec.IsContainerBuild() {
+	var buildTypes []string
+	if spec.IsBuildpackBuild() {
+		buildTypes = append(buildTypes, "buildpackBuild")
+	}
+	if spec.IsContainerBuild() {
+		buildTypes = append(buildTypes, "containerImage")
+	}
+	if spec.IsDockerfileBuild() {
+		buildTypes = append(buildTypes, "dockerfile")
+	}
+	if spec.IsRebase() {
+		buildTypes = append(buildTypes, "rebase")
+	}
+
+	switch {
+	case len(buildTypes) > 1:
+		errs = errs.Also(apis.ErrMultipleOneOf(buildTypes...))
+	case spec.IsContainerBuild():
 		errs = errs.Also(spec.ContainerImage.Validate(ctx))
-	} else if spec.IsBuildpackBuild() {
+	case spec.IsBuildpackBuild():
 		errs = errs.Also(spec.BuildpackBuild.Validate(ctx))
-	} else {
-		errs = errs.Also(apis.ErrMissingOneOf("buildpackBuild", "containerImage"))
+	case spec.IsDockerfileBuild():
+		errs = errs.Also(spec.Dockerfile.Validate(ctx))
+	case spec.IsRebase():
+		errs = errs.Also(spec.Rebase.Validate(ctx))
+	default:
+		errs = errs.Also(apis.ErrMissingOneOf("buildpackBuild", "containerImage", "dockerfile", "rebase"))
+	}
+
+	errs = errs.Also(validateBuildBackend(spec.Backend))
+	errs = errs.Also(validateBuildTimeout(spec.Timeout))
+
+	if spec.Backend == BuildBackendTekton {
+		errs = errs.Also(spec.validateTektonBuild())
 	}
 
 	return errs
@@ -60,9 +89,7 @@ func (containerImage *SourceSpecContainerImage) Validate(ctx context.Context) (e
 // Validate makes sure that a SourceSpecBuildpackBuild is properly configured.
 func (buildpackBuild *SourceSpecBuildpackBuild) Validate(ctx context.Context) (errs *apis.FieldError) {
 
-	if buildpackBuild.Source == "" {
-		errs = errs.Also(apis.ErrMissingField("source"))
-	}
+	errs = errs.Also(validateBuildSource(ctx, buildpackBuild.Source, buildpackBuild.Git))
 
 	if buildpackBuild.Stack == "" {
 		errs = errs.Also(apis.ErrMissingField("stack"))
@@ -78,3 +105,126 @@ func (buildpackBuild *SourceSpecBuildpackBuild) Validate(ctx context.Context) (e
 
 	return errs
 }
+
+// Validate makes sure that a SourceSpecDockerfile is properly configured.
+func (dockerfile *SourceSpecDockerfile) Validate(ctx context.Context) (errs *apis.FieldError) {
+
+	errs = errs.Also(validateBuildSource(ctx, dockerfile.Source, dockerfile.Git))
+
+	if dockerfile.Path == "" {
+		errs = errs.Also(apis.ErrMissingField("path"))
+	}
+
+	if dockerfile.Registry == "" {
+		errs = errs.Also(apis.ErrMissingField("registry"))
+	}
+
+	for i, arg := range dockerfile.BuildArgs {
+		if arg.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("buildArgs", i))
+		}
+	}
+
+	return errs
+}
+
+// Validate makes sure that a SourceSpecRebase is properly configured.
+func (rebase *SourceSpecRebase) Validate(ctx context.Context) (errs *apis.FieldError) {
+
+	if rebase.Image == "" {
+		errs = errs.Also(apis.ErrMissingField("image"))
+	}
+
+	if rebase.BuildpackBuilder == "" {
+		errs = errs.Also(apis.ErrMissingField("buildpackBuilder"))
+	}
+
+	if rebase.Registry == "" {
+		errs = errs.Also(apis.ErrMissingField("registry"))
+	}
+
+	return errs
+}
+
+// validateTektonBuild rejects the builds the Tekton ClusterTasks can't run.
+func (spec *SourceSpec) validateTektonBuild() (errs *apis.FieldError) {
+	var unsupported []string
+	switch {
+	case spec.IsDockerfileBuild():
+		unsupported = append(unsupported, "dockerfile")
+	case spec.IsRebase():
+		unsupported = append(unsupported, "rebase")
+	case spec.BuildpackBuild.Git != nil:
+		unsupported = append(unsupported, "buildpackBuild.git")
+	}
+
+	if spec.BuildpackBuild.CacheClaimName != "" {
+		unsupported = append(unsupported, "buildpackBuild.cacheClaimName")
+	}
+
+	if len(unsupported) == 0 {
+		return nil
+	}
+
+	return &apis.FieldError{
+		Message: "not supported by the tekton build backend",
+		Paths:   unsupported,
+	}
+}
+
+// validateBuildBackend makes sure the backend is blank or one of the known
+// build backends.
+func validateBuildBackend(backend string) (errs *apis.FieldError) {
+	if backend == "" {
+		return nil
+	}
+
+	for _, known := range BuildBackends {
+		if backend == known {
+			return nil
+		}
+	}
+
+	return apis.ErrInvalidValue(backend, "backend")
+}
+
+// validateBuildTimeout makes sure the timeout is unset or positive.
+func validateBuildTimeout(timeout *metav1.Duration) *apis.FieldError {
+	if timeout != nil && timeout.Duration <= 0 {
+		return apis.ErrInvalidValue(timeout.Duration.String(), "timeout")
+	}
+
+	return nil
+}
+
+// validateBuildSource makes sure the source code of a build comes from exactly
+// one of a container image or a Git repository.
+func validateBuildSource(ctx context.Context, source string, git *SourceSpecGit) (errs *apis.FieldError) {
+	switch {
+	case source != "" && git != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("git", "source"))
+	case git != nil:
+		errs = errs.Also(git.Validate(ctx).ViaField("git"))
+	case source == "":
+		errs = errs.Also(apis.ErrMissingOneOf("git", "source"))
+	}
+
+	return errs
+}
+
+// Validate makes sure that a SourceSpecGit is properly configured.
+func (git *SourceSpecGit) Validate(ctx context.Context) (errs *apis.FieldError) {
+
+	if git.URL == "" {
+		errs = errs.Also(apis.ErrMissingField("url"))
+	}
+
+	// The subdirectory must stay within the repository.
+	if subdirectory := path.Clean(git.Subdirectory); path.IsAbs(subdirectory) ||
+		subdirectory == ".." ||
+		strings.HasPrefix(subdirectory, "../") {
+		errs = errs.Also(apis.ErrInvalidValue(git.Subdirectory, "subdirectory"))
+	}
+
+	return errs
+}
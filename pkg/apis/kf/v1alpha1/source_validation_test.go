@@ -17,8 +17,10 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -39,6 +41,17 @@ func TestSource_Validate(t *testing.T) {
 	goodContainerImage := SourceSpecContainerImage{
 		Image: "some-container-image",
 	}
+	goodDockerfile := SourceSpecDockerfile{
+		Source:   "some-source-image",
+		Path:     "Dockerfile",
+		Registry: "some-container-registry",
+	}
+	goodRebase := SourceSpecRebase{
+		Image:            "some-app-image",
+		Stack:            "some-stack",
+		BuildpackBuilder: "some-buildpack-builder",
+		Registry:         "some-container-registry",
+	}
 
 	cases := map[string]struct {
 		spec Source
@@ -64,6 +77,28 @@ func TestSource_Validate(t *testing.T) {
 				},
 			},
 		},
+		"valid dockerfile": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					Dockerfile: goodDockerfile,
+				},
+			},
+		},
+		"invalid container and dockerfile": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					ContainerImage: goodContainerImage,
+					Dockerfile:     goodDockerfile,
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.containerImage", "spec.dockerfile"),
+		},
 		"invalid both": {
 			spec: Source{
 				ObjectMeta: metav1.ObjectMeta{
@@ -83,7 +118,123 @@ func TestSource_Validate(t *testing.T) {
 				},
 				Spec: SourceSpec{},
 			},
-			want: apis.ErrMissingOneOf("spec.buildpackBuild", "spec.containerImage"),
+			want: apis.ErrMissingOneOf("spec.buildpackBuild", "spec.containerImage", "spec.dockerfile", "spec.rebase"),
+		},
+		"valid rebase": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					Rebase: goodRebase,
+				},
+			},
+		},
+		"invalid rebase and buildpackBuild": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					BuildpackBuild: goodBuildpackBuild,
+					Rebase:         goodRebase,
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.buildpackBuild", "spec.rebase"),
+		},
+		"valid backend": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					BuildpackBuild: goodBuildpackBuild,
+					Backend:        BuildBackendTekton,
+				},
+			},
+		},
+		"tekton backend unsupported builds": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					Dockerfile: goodDockerfile,
+					Backend:    BuildBackendTekton,
+				},
+			},
+			want: &apis.FieldError{
+				Message: "not supported by the tekton build backend",
+				Paths:   []string{"spec.dockerfile"},
+			},
+		},
+		"tekton backend cache": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					BuildpackBuild: func() SourceSpecBuildpackBuild {
+						b := goodBuildpackBuild
+						b.CacheClaimName = "my-app-cache"
+						return b
+					}(),
+					Backend: BuildBackendTekton,
+				},
+			},
+			want: &apis.FieldError{
+				Message: "not supported by the tekton build backend",
+				Paths:   []string{"spec.buildpackBuild.cacheClaimName"},
+			},
+		},
+		"invalid backend": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					BuildpackBuild: goodBuildpackBuild,
+					Backend:        "jenkins",
+				},
+			},
+			want: apis.ErrInvalidValue("jenkins", "spec.backend"),
+		},
+		"valid timeout": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					BuildpackBuild: goodBuildpackBuild,
+					Timeout:        &metav1.Duration{Duration: 30 * time.Minute},
+				},
+			},
+		},
+		"invalid timeout": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					BuildpackBuild: goodBuildpackBuild,
+					Timeout:        &metav1.Duration{Duration: -1 * time.Minute},
+				},
+			},
+			want: apis.ErrInvalidValue("-1m0s", "spec.timeout"),
+		},
+		"invalid rebase": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					Rebase: SourceSpecRebase{
+						Image:            "some-app-image",
+						BuildpackBuilder: "some-buildpack-builder",
+					},
+				},
+			},
+			want: apis.ErrMissingField("spec.registry"),
 		},
 		"invalid buildpackBuild": {
 			spec: Source{
@@ -129,7 +280,34 @@ func TestSourceSpecBuildpackBuild_Validate(t *testing.T) {
 				BuildpackBuilder: "buildpackBuilder",
 				Registry:         "some-registry",
 			},
-			want: apis.ErrMissingField("source"),
+			want: apis.ErrMissingOneOf("git", "source"),
+		},
+		"valid git": {
+			spec: SourceSpecBuildpackBuild{
+				Git:              &SourceSpecGit{URL: "https://github.com/google/kf"},
+				Stack:            "some-stack",
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+		},
+		"image and git": {
+			spec: SourceSpecBuildpackBuild{
+				Source:           "some-image",
+				Git:              &SourceSpecGit{URL: "https://github.com/google/kf"},
+				Stack:            "some-stack",
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+			want: apis.ErrMultipleOneOf("git", "source"),
+		},
+		"invalid git": {
+			spec: SourceSpecBuildpackBuild{
+				Git:              &SourceSpecGit{},
+				Stack:            "some-stack",
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+			want: apis.ErrMissingField("git.url"),
 		},
 		"missing stack": {
 			spec: SourceSpecBuildpackBuild{
@@ -168,3 +346,99 @@ func TestSourceSpecBuildpackBuild_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestSourceSpecDockerfile_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec SourceSpecDockerfile
+		want *apis.FieldError
+	}{
+		"valid": {
+			spec: SourceSpecDockerfile{
+				Source:    "some-image",
+				Path:      "docker/Dockerfile",
+				Registry:  "some-registry",
+				BuildArgs: []corev1.EnvVar{{Name: "VERSION", Value: "1.0"}},
+			},
+		},
+		"missing image": {
+			spec: SourceSpecDockerfile{
+				Path:     "Dockerfile",
+				Registry: "some-registry",
+			},
+			want: apis.ErrMissingOneOf("git", "source"),
+		},
+		"missing path": {
+			spec: SourceSpecDockerfile{
+				Source:   "some-image",
+				Registry: "some-registry",
+			},
+			want: apis.ErrMissingField("path"),
+		},
+		"missing registry": {
+			spec: SourceSpecDockerfile{
+				Source: "some-image",
+				Path:   "Dockerfile",
+			},
+			want: apis.ErrMissingField("registry"),
+		},
+		"build arg missing name": {
+			spec: SourceSpecDockerfile{
+				Source:    "some-image",
+				Path:      "Dockerfile",
+				Registry:  "some-registry",
+				BuildArgs: []corev1.EnvVar{{Value: "1.0"}},
+			},
+			want: apis.ErrMissingField("buildArgs[0].name"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.spec.Validate(context.Background())
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestSourceSpecGit_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec SourceSpecGit
+		want *apis.FieldError
+	}{
+		"valid": {
+			spec: SourceSpecGit{
+				URL:          "https://github.com/google/kf",
+				Revision:     "abc123",
+				Subdirectory: "samples/apps/helloworld",
+				SecretName:   "git-credentials",
+			},
+		},
+		"missing url": {
+			spec: SourceSpecGit{},
+			want: apis.ErrMissingField("url"),
+		},
+		"absolute subdirectory": {
+			spec: SourceSpecGit{
+				URL:          "https://github.com/google/kf",
+				Subdirectory: "/etc",
+			},
+			want: apis.ErrInvalidValue("/etc", "subdirectory"),
+		},
+		"subdirectory outside repository": {
+			spec: SourceSpecGit{
+				URL:          "https://github.com/google/kf",
+				Subdirectory: "samples/../../other",
+			},
+			want: apis.ErrInvalidValue("samples/../../other", "subdirectory"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.spec.Validate(context.Background())
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
@@ -29,6 +29,14 @@ const (
 	// DefaultDomainTemplate contains the default domain template. It should
 	// be used with `fmt.Sprintf(DefaultDomainTemplate, namespace)`
 	DefaultDomainTemplate = "%s.kf.cluster.local"
+
+	// DefaultRetainedSucceededSources is the number of successfully built
+	// Sources kept for each App.
+	DefaultRetainedSucceededSources = 5
+
+	// DefaultRetainedFailedSources is the number of failed Sources kept for
+	// each App.
+	DefaultRetainedFailedSources = 2
 )
 
 // SetDefaults implements apis.Defaultable
@@ -54,6 +62,21 @@ func (k *SpaceSpecBuildpackBuild) SetDefaults(ctx context.Context) {
 	if k.BuilderImage == "" {
 		k.BuilderImage = DefaultBuilderImage
 	}
+
+	k.Retention.SetDefaults(ctx)
+}
+
+// SetDefaults implements apis.Defaultable
+func (k *SpaceSpecBuildRetention) SetDefaults(ctx context.Context) {
+	if k.Succeeded == nil {
+		succeeded := int32(DefaultRetainedSucceededSources)
+		k.Succeeded = &succeeded
+	}
+
+	if k.Failed == nil {
+		failed := int32(DefaultRetainedFailedSources)
+		k.Failed = &failed
+	}
 }
 
 // SetDefaults implements apis.Defaultable
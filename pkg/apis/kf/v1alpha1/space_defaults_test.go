@@ -66,3 +66,15 @@ func ExampleSpaceSpecExecution_SetDefaults_dedupe() {
 
 	// Output: *example.com, other-example.com
 }
+
+func ExampleSpaceSpecBuildRetention_SetDefaults() {
+	failed := int32(0)
+	retention := SpaceSpecBuildRetention{Failed: &failed}
+	retention.SetDefaults(context.Background())
+
+	fmt.Println("Succeeded:", *retention.Succeeded)
+	fmt.Println("Failed:", *retention.Failed)
+
+	// Output: Succeeded: 5
+	// Failed: 0
+}
@@ -17,7 +17,9 @@ package v1alpha1
 import (
 	"fmt"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
@@ -48,6 +50,15 @@ const (
 	// SpaceConditionLimitRangeReady is set when the limit range is
 	// ready.
 	SpaceConditionLimitRangeReady apis.ConditionType = "LimitRangeReady"
+	// SpaceConditionManagerRoleReady is set when the manager RBAC roles are
+	// ready.
+	SpaceConditionManagerRoleReady apis.ConditionType = "ManagerRoleReady"
+	// SpaceConditionRoleBindingsReady is set when the RBAC bindings for the
+	// space's role assignments are ready.
+	SpaceConditionRoleBindingsReady apis.ConditionType = "RoleBindingsReady"
+	// SpaceConditionNetworkPoliciesReady is set when the NetworkPolicies for
+	// the space's network policies are ready.
+	SpaceConditionNetworkPoliciesReady apis.ConditionType = "NetworkPoliciesReady"
 )
 
 func (status *SpaceStatus) manage() apis.ConditionManager {
@@ -57,6 +68,9 @@ func (status *SpaceStatus) manage() apis.ConditionManager {
 		SpaceConditionAuditorRoleReady,
 		SpaceConditionResourceQuotaReady,
 		SpaceConditionLimitRangeReady,
+		SpaceConditionManagerRoleReady,
+		SpaceConditionRoleBindingsReady,
+		SpaceConditionNetworkPoliciesReady,
 	).Manage(status)
 }
 
@@ -105,6 +119,25 @@ func (status *SpaceStatus) MarkLimitRangeNotOwned(name string) {
 		fmt.Sprintf("There is an existing limitrange %q that we do not own.", name))
 }
 
+// MarkManagerRoleNotOwned marks the manager role as not being owned by the Space.
+func (status *SpaceStatus) MarkManagerRoleNotOwned(name string) {
+	status.manage().MarkFalse(SpaceConditionManagerRoleReady, "NotOwned",
+		fmt.Sprintf("There is an existing manager role %q that we do not own.", name))
+}
+
+// MarkRoleBindingNotOwned marks a role binding as not being owned by the Space.
+func (status *SpaceStatus) MarkRoleBindingNotOwned(name string) {
+	status.manage().MarkFalse(SpaceConditionRoleBindingsReady, "NotOwned",
+		fmt.Sprintf("There is an existing rolebinding %q that we do not own.", name))
+}
+
+// MarkNetworkPolicyNotOwned marks a NetworkPolicy as not being owned by the
+// Space.
+func (status *SpaceStatus) MarkNetworkPolicyNotOwned(name string) {
+	status.manage().MarkFalse(SpaceConditionNetworkPoliciesReady, "NotOwned",
+		fmt.Sprintf("There is an existing networkpolicy %q that we do not own.", name))
+}
+
 // PropagateNamespaceStatus copies fields from the Namespace status to Space
 // and updates the readiness based on the current phase.
 func (status *SpaceStatus) PropagateNamespaceStatus(ns *v1.Namespace) {
@@ -134,6 +167,28 @@ func (status *SpaceStatus) PropagateAuditorRoleStatus(*rv1.Role) {
 	status.manage().MarkTrue(SpaceConditionAuditorRoleReady)
 }
 
+// PropagateManagerRoleStatus updates the readiness of the space based on if
+// the manager Role and ClusterRole exist.
+func (status *SpaceStatus) PropagateManagerRoleStatus(*rv1.Role, *rv1.ClusterRole) {
+	// Roles don't have a status field so they just need to exist to be ready.
+	status.manage().MarkTrue(SpaceConditionManagerRoleReady)
+}
+
+// PropagateRoleBindingsStatus updates the readiness of the space based on if
+// the RoleBindings and ClusterRoleBinding for its role assignments exist.
+func (status *SpaceStatus) PropagateRoleBindingsStatus([]*rv1.RoleBinding, *rv1.ClusterRoleBinding) {
+	// Bindings don't have a status field so they just need to exist to be ready.
+	status.manage().MarkTrue(SpaceConditionRoleBindingsReady)
+}
+
+// PropagateNetworkPoliciesStatus updates the readiness of the space based on
+// if the NetworkPolicies for its network policies exist.
+func (status *SpaceStatus) PropagateNetworkPoliciesStatus([]*networkingv1.NetworkPolicy) {
+	// NetworkPolicies don't have a status field so they just need to exist to
+	// be ready.
+	status.manage().MarkTrue(SpaceConditionNetworkPoliciesReady)
+}
+
 // PropagateResourceQuotaStatus copies the ResourceQuota Used and Hard amounts
 // to the Space and updates the readiness based on if a quota exists.
 func (status *SpaceStatus) PropagateResourceQuotaStatus(quota *v1.ResourceQuota) {
@@ -147,6 +202,151 @@ func (status *SpaceStatus) PropagateLimitRangeStatus(limitRange *v1.LimitRange)
 	status.manage().MarkTrue(SpaceConditionLimitRangeReady)
 }
 
+// IsValid returns true if the role is one of the known SpaceRoles.
+func (role SpaceRole) IsValid() bool {
+	for _, r := range SpaceRoles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RoleAssignmentsFor returns the assignments bound to the given role.
+func (s *SpaceSpecSecurity) RoleAssignmentsFor(role SpaceRole) []SpaceRoleAssignment {
+	var out []SpaceRoleAssignment
+	for _, a := range s.RoleAssignments {
+		if a.Role == role {
+			out = append(out, a)
+		}
+	}
+
+	return out
+}
+
+// AddRoleAssignment adds the assignment if it doesn't already exist and
+// returns true if it was added.
+func (s *SpaceSpecSecurity) AddRoleAssignment(assignment SpaceRoleAssignment) bool {
+	for _, a := range s.RoleAssignments {
+		if a == assignment {
+			return false
+		}
+	}
+
+	s.RoleAssignments = append(s.RoleAssignments, assignment)
+	return true
+}
+
+// RemoveRoleAssignment removes the assignment and returns true if it existed.
+func (s *SpaceSpecSecurity) RemoveRoleAssignment(assignment SpaceRoleAssignment) bool {
+	var out []SpaceRoleAssignment
+	for _, a := range s.RoleAssignments {
+		if a != assignment {
+			out = append(out, a)
+		}
+	}
+
+	removed := len(out) != len(s.RoleAssignments)
+	s.RoleAssignments = out
+	return removed
+}
+
+// IsManager returns true if the user is assigned the manager role on the
+// Space directly, through one of their groups, or as a ServiceAccount in the
+// Space's namespace.
+func (space *Space) IsManager(userInfo *authenticationv1.UserInfo) bool {
+	if userInfo == nil {
+		return false
+	}
+
+	for _, a := range space.Spec.Security.RoleAssignmentsFor(SpaceRoleManager) {
+		switch a.Kind {
+		case rv1.UserKind:
+			if a.Name == userInfo.Username {
+				return true
+			}
+		case rv1.GroupKind:
+			for _, group := range userInfo.Groups {
+				if a.Name == group {
+					return true
+				}
+			}
+		case rv1.ServiceAccountKind:
+			// The Space's namespace shares its name.
+			if userInfo.Username == fmt.Sprintf("system:serviceaccount:%s:%s", space.Name, a.Name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// NetworkPoliciesFor returns the policies that allow connections to the given
+// App.
+func (s *SpaceSpecSecurity) NetworkPoliciesFor(destinationApp string) []SpaceNetworkPolicy {
+	var out []SpaceNetworkPolicy
+	for _, p := range s.NetworkPolicies {
+		if p.DestinationApp == destinationApp {
+			out = append(out, p)
+		}
+	}
+
+	return out
+}
+
+// AddNetworkPolicy adds the policy if it doesn't already exist and returns
+// true if it was added.
+func (s *SpaceSpecSecurity) AddNetworkPolicy(policy SpaceNetworkPolicy) bool {
+	for _, p := range s.NetworkPolicies {
+		if p == policy {
+			return false
+		}
+	}
+
+	s.NetworkPolicies = append(s.NetworkPolicies, policy)
+	return true
+}
+
+// RemoveNetworkPolicy removes the policy and returns true if it existed.
+func (s *SpaceSpecSecurity) RemoveNetworkPolicy(policy SpaceNetworkPolicy) bool {
+	var out []SpaceNetworkPolicy
+	for _, p := range s.NetworkPolicies {
+		if p != policy {
+			out = append(out, p)
+		}
+	}
+
+	removed := len(out) != len(s.NetworkPolicies)
+	s.NetworkPolicies = out
+	return removed
+}
+
+// IsInternalDomain returns true if the domain is one of the space's internal
+// domains.
+func (s *SpaceSpecExecution) IsInternalDomain(domain string) bool {
+	for _, d := range s.Domains {
+		if d.Domain == domain {
+			return d.Internal
+		}
+	}
+
+	return false
+}
+
+// DomainTLS returns the TLS configuration the space has for the domain or nil
+// if it has none.
+func (s *SpaceSpecExecution) DomainTLS(domain string) *DomainTLS {
+	for _, d := range s.Domains {
+		if d.Domain == domain {
+			return d.TLS
+		}
+	}
+
+	return nil
+}
+
 func (status *SpaceStatus) duck() *duckv1beta1.Status {
 	return &status.Status
 }
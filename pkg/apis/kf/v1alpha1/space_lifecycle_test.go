@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/google/kf/pkg/kf/testutil"
@@ -170,6 +171,9 @@ func initTestStatus(t *testing.T) *SpaceStatus {
 	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionDeveloperRoleReady, t)
 	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionResourceQuotaReady, t)
 	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionLimitRangeReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionManagerRoleReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionRoleBindingsReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionNetworkPoliciesReady, t)
 
 	return status
 }
@@ -184,6 +188,9 @@ func TestSpaceHappyPath(t *testing.T) {
 		Status: corev1.ResourceQuotaStatus{},
 	})
 	status.PropagateLimitRangeStatus(nil)
+	status.PropagateManagerRoleStatus(nil, nil)
+	status.PropagateRoleBindingsStatus(nil, nil)
+	status.PropagateNetworkPoliciesStatus(nil)
 
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionReady, t)
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionNamespaceReady, t)
@@ -191,6 +198,9 @@ func TestSpaceHappyPath(t *testing.T) {
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionDeveloperRoleReady, t)
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionResourceQuotaReady, t)
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionLimitRangeReady, t)
+	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionManagerRoleReady, t)
+	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionRoleBindingsReady, t)
+	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionNetworkPoliciesReady, t)
 }
 
 func TestPropagateNamespaceStatus_terminating(t *testing.T) {
@@ -248,6 +258,9 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 					Status: corev1.ResourceQuotaStatus{},
 				})
 				status.PropagateLimitRangeStatus(nil)
+				status.PropagateManagerRoleStatus(nil, nil)
+				status.PropagateRoleBindingsStatus(nil, nil)
+				status.PropagateNetworkPoliciesStatus(nil)
 			},
 			ExpectSucceeded: []apis.ConditionType{
 				SpaceConditionReady,
@@ -256,6 +269,9 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 				SpaceConditionDeveloperRoleReady,
 				SpaceConditionResourceQuotaReady,
 				SpaceConditionLimitRangeReady,
+				SpaceConditionManagerRoleReady,
+				SpaceConditionRoleBindingsReady,
+				SpaceConditionNetworkPoliciesReady,
 			},
 		},
 		"terminating namespace": {
@@ -345,6 +361,42 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 				SpaceConditionLimitRangeReady,
 			},
 		},
+		"manager role not owned": {
+			Init: func(status *SpaceStatus) {
+				status.MarkManagerRoleNotOwned("space-manager")
+			},
+			ExpectOngoing: []apis.ConditionType{
+				SpaceConditionNamespaceReady,
+			},
+			ExpectFailed: []apis.ConditionType{
+				SpaceConditionReady,
+				SpaceConditionManagerRoleReady,
+			},
+		},
+		"role binding not owned": {
+			Init: func(status *SpaceStatus) {
+				status.MarkRoleBindingNotOwned("space-developer")
+			},
+			ExpectOngoing: []apis.ConditionType{
+				SpaceConditionNamespaceReady,
+			},
+			ExpectFailed: []apis.ConditionType{
+				SpaceConditionReady,
+				SpaceConditionRoleBindingsReady,
+			},
+		},
+		"network policy not owned": {
+			Init: func(status *SpaceStatus) {
+				status.MarkNetworkPolicyNotOwned("kf-allow-backend")
+			},
+			ExpectOngoing: []apis.ConditionType{
+				SpaceConditionNamespaceReady,
+			},
+			ExpectFailed: []apis.ConditionType{
+				SpaceConditionReady,
+				SpaceConditionNetworkPoliciesReady,
+			},
+		},
 	}
 
 	// XXX: if we start copying state from subresources back to the parent,
@@ -370,3 +422,81 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 		})
 	}
 }
+
+func TestSpaceSpecSecurity_roleAssignments(t *testing.T) {
+	t.Parallel()
+
+	dev := SpaceRoleAssignment{Role: SpaceRoleDeveloper, Kind: "User", Name: "dev@example.com"}
+	auditor := SpaceRoleAssignment{Role: SpaceRoleAuditor, Kind: "User", Name: "dev@example.com"}
+
+	security := SpaceSpecSecurity{}
+	testutil.AssertEqual(t, "add new", true, security.AddRoleAssignment(dev))
+	testutil.AssertEqual(t, "add duplicate", false, security.AddRoleAssignment(dev))
+	testutil.AssertEqual(t, "add other role", true, security.AddRoleAssignment(auditor))
+	testutil.AssertEqual(t, "assignments", []SpaceRoleAssignment{dev, auditor}, security.RoleAssignments)
+	testutil.AssertEqual(t, "developers", []SpaceRoleAssignment{dev}, security.RoleAssignmentsFor(SpaceRoleDeveloper))
+
+	testutil.AssertEqual(t, "remove existing", true, security.RemoveRoleAssignment(dev))
+	testutil.AssertEqual(t, "remove missing", false, security.RemoveRoleAssignment(dev))
+	testutil.AssertEqual(t, "assignments after remove", []SpaceRoleAssignment{auditor}, security.RoleAssignments)
+}
+
+func TestSpaceSpecSecurity_networkPolicies(t *testing.T) {
+	t.Parallel()
+
+	toBackend := SpaceNetworkPolicy{SourceApp: "frontend", DestinationApp: "backend", Port: 8080, Protocol: "TCP"}
+	toDatabase := SpaceNetworkPolicy{SourceApp: "backend", DestinationApp: "database", Protocol: "TCP"}
+
+	security := SpaceSpecSecurity{}
+	testutil.AssertEqual(t, "add new", true, security.AddNetworkPolicy(toBackend))
+	testutil.AssertEqual(t, "add duplicate", false, security.AddNetworkPolicy(toBackend))
+	testutil.AssertEqual(t, "add other", true, security.AddNetworkPolicy(toDatabase))
+	testutil.AssertEqual(t, "policies", []SpaceNetworkPolicy{toBackend, toDatabase}, security.NetworkPolicies)
+	testutil.AssertEqual(t, "backend policies", []SpaceNetworkPolicy{toBackend}, security.NetworkPoliciesFor("backend"))
+
+	testutil.AssertEqual(t, "remove existing", true, security.RemoveNetworkPolicy(toBackend))
+	testutil.AssertEqual(t, "remove missing", false, security.RemoveNetworkPolicy(toBackend))
+	testutil.AssertEqual(t, "policies after remove", []SpaceNetworkPolicy{toDatabase}, security.NetworkPolicies)
+}
+
+func ExampleSpaceSpecExecution_IsInternalDomain() {
+	execution := SpaceSpecExecution{
+		Domains: []SpaceDomain{
+			{Domain: "example.com", Default: true},
+			{Domain: "apps.internal", Internal: true},
+		},
+	}
+
+	fmt.Println("example.com:", execution.IsInternalDomain("example.com"))
+	fmt.Println("apps.internal:", execution.IsInternalDomain("apps.internal"))
+	fmt.Println("unknown.com:", execution.IsInternalDomain("unknown.com"))
+
+	// Output: example.com: false
+	// apps.internal: true
+	// unknown.com: false
+}
+
+func ExampleSpaceSpecExecution_DomainTLS() {
+	execution := SpaceSpecExecution{
+		Domains: []SpaceDomain{
+			{Domain: "example.com", Default: true, TLS: &DomainTLS{SecretName: "example-com-tls"}},
+			{Domain: "apps.internal", Internal: true},
+		},
+	}
+
+	fmt.Println("example.com:", execution.DomainTLS("example.com").SecretName)
+	fmt.Println("apps.internal:", execution.DomainTLS("apps.internal") == nil)
+
+	// Output: example.com: example-com-tls
+	// apps.internal: true
+}
+
+func TestSpaceRole_IsValid(t *testing.T) {
+	t.Parallel()
+
+	for _, role := range SpaceRoles {
+		testutil.AssertEqual(t, string(role), true, role.IsValid())
+	}
+
+	testutil.AssertEqual(t, "unknown role", false, SpaceRole("owner").IsValid())
+}
@@ -66,6 +66,70 @@ type SpaceSpecSecurity struct {
 	// EnableDeveloperLogsAccess allows developers to access pod logging endpoints.
 	// +optional
 	EnableDeveloperLogsAccess bool `json:"enableDeveloperLogsAccess,omitempty"`
+
+	// RoleAssignments holds the users, groups and service accounts that are
+	// bound to the space's roles.
+	// +optional
+	RoleAssignments []SpaceRoleAssignment `json:"roleAssignments,omitempty"`
+
+	// NetworkPolicies holds the Apps that are allowed to connect to other
+	// Apps in the space. Apps that aren't the destination of a policy accept
+	// traffic from any App.
+	// +optional
+	NetworkPolicies []SpaceNetworkPolicy `json:"networkPolicies,omitempty"`
+}
+
+// SpaceRole is the name of a role users can be assigned to within a space.
+type SpaceRole string
+
+const (
+	// SpaceRoleDeveloper allows pushing and managing apps and services.
+	SpaceRoleDeveloper SpaceRole = "developer"
+	// SpaceRoleAuditor allows read-only access to the space.
+	SpaceRoleAuditor SpaceRole = "auditor"
+	// SpaceRoleManager has developer access and can modify the space itself.
+	SpaceRoleManager SpaceRole = "manager"
+)
+
+// SpaceRoles contains all the valid space roles.
+var SpaceRoles = []SpaceRole{
+	SpaceRoleDeveloper,
+	SpaceRoleAuditor,
+	SpaceRoleManager,
+}
+
+// SpaceRoleAssignment binds a subject to a role in the space.
+type SpaceRoleAssignment struct {
+	// Role is the space role the subject is bound to.
+	Role SpaceRole `json:"role"`
+
+	// Kind is the type of subject: User, Group or ServiceAccount.
+	Kind string `json:"kind"`
+
+	// Name is the name of the subject. ServiceAccounts are looked up in the
+	// space's namespace.
+	Name string `json:"name"`
+}
+
+// SpaceNetworkPolicy allows connections between two Apps in the space.
+type SpaceNetworkPolicy struct {
+	// SourceApp is the name of the App that connects to DestinationApp.
+	SourceApp string `json:"sourceApp"`
+
+	// DestinationApp is the name of the App that accepts the connections.
+	DestinationApp string `json:"destinationApp"`
+
+	// Port limits the connections to the port DestinationApp listens on, all
+	// ports are allowed if it's 0. Knative forwards connections to the App
+	// through its queue-proxy, so the queue-proxy ports are allowed in its
+	// place.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Protocol is the protocol of the connections, TCP or UDP. Defaults to
+	// TCP.
+	// +optional
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
 }
 
 // SpaceSpecBuildpackBuild holds fields for managing building via buildpacks.
@@ -75,6 +139,11 @@ type SpaceSpecBuildpackBuild struct {
 	// BuilderImage is a buildpacks.io builder image.
 	BuilderImage string `json:"builderImage,omitempty"`
 
+	// Stack is the default stack used by buildpack builds. The builder's
+	// default is used if it's blank.
+	// +optional
+	Stack string `json:"stack,omitempty"`
+
 	// ContainerRegistry holds the container registry that buildpack builds are
 	// stored in.
 	ContainerRegistry string `json:"containerRegistry,omitempty"`
@@ -85,6 +154,39 @@ type SpaceSpecBuildpackBuild struct {
 	// +patchMergeKey=name
 	// +patchStrategy=merge
 	Env []corev1.EnvVar `json:"env,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// Backend is the build system that runs the space's builds, either
+	// knative-build or tekton. The cluster's default is used if it's blank.
+	// +optional
+	Backend string `json:"backend,omitempty"`
+
+	// Timeout is the default longest time builds in the space can run.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retention controls how many old Sources, and the Builds and images
+	// made from them, are kept for each App.
+	// +optional
+	Retention SpaceSpecBuildRetention `json:"retention,omitempty"`
+}
+
+// SpaceSpecBuildRetention holds the policy for garbage collecting an App's
+// old Sources. The latest Source of an App is never collected.
+type SpaceSpecBuildRetention struct {
+	// Succeeded is the number of successfully built Sources kept for each
+	// App.
+	// +optional
+	Succeeded *int32 `json:"succeeded,omitempty"`
+
+	// Failed is the number of failed Sources kept for each App.
+	// +optional
+	Failed *int32 `json:"failed,omitempty"`
+
+	// DeleteImages removes the source and App images of collected Sources
+	// from the container registry. Images that are still used by the App,
+	// its other Sources or its deployment history are kept.
+	// +optional
+	DeleteImages bool `json:"deleteImages,omitempty"`
 }
 
 // SpaceSpecExecution contains settings for the execution environment.
@@ -127,6 +229,16 @@ type SpaceDomain struct {
 	// specified. There can only be a single default set to true per space.
 	// NOTE: This may change in the future.
 	Default bool
+
+	// Internal implies that routes on this domain are only reachable by Apps
+	// in the cluster's service mesh and aren't exposed by the ingress
+	// gateway.
+	Internal bool
+
+	// TLS configures HTTPS for routes on this domain, it takes precedence
+	// over the TLS configuration of a cluster-wide Domain with the same
+	// name.
+	TLS *DomainTLS
 }
 
 // SpaceStatus represents information about the status of a Space.
@@ -17,6 +17,9 @@ package v1alpha1
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"knative.dev/pkg/apis"
 )
 
@@ -38,10 +41,40 @@ func (space *Space) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(space.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+	errs = errs.Also(space.validateManagerUpdate(ctx))
 
 	return errs
 }
 
+// validateManagerUpdate prevents space managers from using their access to the
+// Space to change anything other than its role assignments. RBAC can only
+// grant update on the whole object so the restriction is enforced here.
+func (space *Space) validateManagerUpdate(ctx context.Context) *apis.FieldError {
+	if !apis.IsInUpdate(ctx) {
+		return nil
+	}
+
+	original, ok := apis.GetBaseline(ctx).(*Space)
+	if !ok || original == nil {
+		return nil
+	}
+
+	if !original.IsManager(apis.GetUserInfo(ctx)) {
+		return nil
+	}
+
+	allowed := original.Spec.DeepCopy()
+	allowed.Security.RoleAssignments = space.Spec.Security.RoleAssignments
+	if equality.Semantic.DeepEqual(*allowed, space.Spec) {
+		return nil
+	}
+
+	return &apis.FieldError{
+		Message: "space managers can only change role assignments",
+		Paths:   []string{"spec"},
+	}
+}
+
 // Validate makes sure that SpaceSpec is properly configured.
 func (s *SpaceSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(s.Security.Validate(ctx).ViaField("security"))
@@ -54,7 +87,60 @@ func (s *SpaceSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 // Validate makes sure that SpaceSpecSecurity is properly configured.
 func (s *SpaceSpecSecurity) Validate(ctx context.Context) (errs *apis.FieldError) {
-	// XXX: no validation
+	for i, assignment := range s.RoleAssignments {
+		errs = errs.Also(assignment.Validate(ctx).ViaFieldIndex("roleAssignments", i))
+	}
+
+	for i, policy := range s.NetworkPolicies {
+		errs = errs.Also(policy.Validate(ctx).ViaFieldIndex("networkPolicies", i))
+	}
+
+	return errs
+}
+
+// Validate makes sure that SpaceNetworkPolicy is properly configured.
+func (p *SpaceNetworkPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if p.SourceApp == "" {
+		errs = errs.Also(apis.ErrMissingField("sourceApp"))
+	}
+
+	if p.DestinationApp == "" {
+		errs = errs.Also(apis.ErrMissingField("destinationApp"))
+	}
+
+	if p.Port < 0 || p.Port > 65535 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(p.Port, 0, 65535, "port"))
+	}
+
+	switch p.Protocol {
+	case "", corev1.ProtocolTCP, corev1.ProtocolUDP:
+		// valid
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(p.Protocol, "protocol"))
+	}
+
+	return errs
+}
+
+// Validate makes sure that SpaceRoleAssignment is properly configured.
+func (a *SpaceRoleAssignment) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if !a.Role.IsValid() {
+		errs = errs.Also(apis.ErrInvalidValue(a.Role, "role"))
+	}
+
+	switch a.Kind {
+	case rbacv1.UserKind, rbacv1.GroupKind, rbacv1.ServiceAccountKind:
+		// valid
+	case "":
+		errs = errs.Also(apis.ErrMissingField("kind"))
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(a.Kind, "kind"))
+	}
+
+	if a.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+
 	return errs
 }
 
@@ -68,6 +154,23 @@ func (s *SpaceSpecBuildpackBuild) Validate(ctx context.Context) (errs *apis.Fiel
 		errs = errs.Also(apis.ErrMissingField("containerRegistry"))
 	}
 
+	errs = errs.Also(validateBuildBackend(s.Backend))
+	errs = errs.Also(validateBuildTimeout(s.Timeout))
+	errs = errs.Also(s.Retention.Validate(ctx).ViaField("retention"))
+
+	return errs
+}
+
+// Validate makes sure that SpaceSpecBuildRetention is properly configured.
+func (r *SpaceSpecBuildRetention) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if r.Succeeded != nil && *r.Succeeded < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*r.Succeeded, "succeeded"))
+	}
+
+	if r.Failed != nil && *r.Failed < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*r.Failed, "failed"))
+	}
+
 	return errs
 }
 
@@ -79,6 +182,10 @@ func (s *SpaceSpecExecution) Validate(ctx context.Context) (errs *apis.FieldErro
 
 	lastDefault := -1
 	for i, d := range s.Domains {
+		if d.TLS != nil {
+			errs = errs.Also(d.TLS.Validate(ctx).ViaField("tls").ViaFieldIndex("domains", i))
+		}
+
 		if !d.Default {
 			continue
 		}
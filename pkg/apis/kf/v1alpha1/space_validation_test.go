@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/google/kf/pkg/kf/testutil"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -92,6 +93,50 @@ func TestSpaceValidation(t *testing.T) {
 			},
 			want: apis.ErrMissingField("spec.buildpackBuild.builderImage"),
 		},
+		"unknown build backend": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: goodExecuton,
+					BuildpackBuild: SpaceSpecBuildpackBuild{
+						BuilderImage:      DefaultBuilderImage,
+						ContainerRegistry: "gcr.io/test",
+						Backend:           "jenkins",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("jenkins", "spec.buildpackBuild.backend"),
+		},
+		"zero build timeout": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: goodExecuton,
+					BuildpackBuild: SpaceSpecBuildpackBuild{
+						BuilderImage:      DefaultBuilderImage,
+						ContainerRegistry: "gcr.io/test",
+						Timeout:           &metav1.Duration{},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("0s", "spec.buildpackBuild.timeout"),
+		},
+		"negative retention": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: goodExecuton,
+					BuildpackBuild: SpaceSpecBuildpackBuild{
+						BuilderImage:      DefaultBuilderImage,
+						ContainerRegistry: "gcr.io/test",
+						Retention: SpaceSpecBuildRetention{
+							Succeeded: func() *int32 { i := int32(-1); return &i }(),
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue(-1, "spec.buildpackBuild.retention.succeeded"),
+		},
 		"no domains": {
 			space: &Space{
 				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
@@ -147,6 +192,86 @@ func TestSpaceValidation(t *testing.T) {
 				Details: "one domain must be set to default",
 			},
 		},
+		"domain TLS missing secret": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: []SpaceDomain{
+							{Domain: "example.com", Default: true, TLS: &DomainTLS{}},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: apis.ErrMissingField("spec.execution.domains[0].tls.secretName"),
+		},
+		"valid role assignments": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Security: SpaceSpecSecurity{
+						RoleAssignments: []SpaceRoleAssignment{
+							{Role: SpaceRoleDeveloper, Kind: "User", Name: "dev@example.com"},
+							{Role: SpaceRoleAuditor, Kind: "Group", Name: "auditors"},
+							{Role: SpaceRoleManager, Kind: "ServiceAccount", Name: "ci"},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+					Execution:      goodExecuton,
+				},
+			},
+		},
+		"invalid role assignment": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Security: SpaceSpecSecurity{
+						RoleAssignments: []SpaceRoleAssignment{
+							{Role: "owner", Kind: "Robot"},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+					Execution:      goodExecuton,
+				},
+			},
+			want: apis.ErrInvalidValue("owner", "spec.security.roleAssignments[0].role").
+				Also(apis.ErrInvalidValue("Robot", "spec.security.roleAssignments[0].kind")).
+				Also(apis.ErrMissingField("spec.security.roleAssignments[0].name")),
+		},
+		"valid network policies": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Security: SpaceSpecSecurity{
+						NetworkPolicies: []SpaceNetworkPolicy{
+							{SourceApp: "frontend", DestinationApp: "backend"},
+							{SourceApp: "frontend", DestinationApp: "backend", Port: 8080, Protocol: "UDP"},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+					Execution:      goodExecuton,
+				},
+			},
+		},
+		"invalid network policy": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Security: SpaceSpecSecurity{
+						NetworkPolicies: []SpaceNetworkPolicy{
+							{Port: 70000, Protocol: "ICMP"},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+					Execution:      goodExecuton,
+				},
+			},
+			want: apis.ErrMissingField("spec.security.networkPolicies[0].sourceApp").
+				Also(apis.ErrMissingField("spec.security.networkPolicies[0].destinationApp")).
+				Also(apis.ErrOutOfBoundsValue(int32(70000), 0, 65535, "spec.security.networkPolicies[0].port")).
+				Also(apis.ErrInvalidValue("ICMP", "spec.security.networkPolicies[0].protocol")),
+		},
 	}
 
 	for tn, tc := range cases {
@@ -157,3 +282,92 @@ func TestSpaceValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestSpaceValidation_managerUpdate(t *testing.T) {
+	original := &Space{
+		ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+		Spec: SpaceSpec{
+			Security: SpaceSpecSecurity{
+				RoleAssignments: []SpaceRoleAssignment{
+					{Role: SpaceRoleManager, Kind: "User", Name: "manager@example.com"},
+					{Role: SpaceRoleManager, Kind: "Group", Name: "managers"},
+					{Role: SpaceRoleManager, Kind: "ServiceAccount", Name: "ci"},
+				},
+			},
+			BuildpackBuild: SpaceSpecBuildpackBuild{
+				BuilderImage:      DefaultBuilderImage,
+				ContainerRegistry: "gcr.io/test",
+			},
+			Execution: SpaceSpecExecution{
+				Domains: []SpaceDomain{{Domain: "example.com", Default: true}},
+			},
+		},
+	}
+
+	changeRoles := func(s *Space) {
+		s.Spec.Security.AddRoleAssignment(SpaceRoleAssignment{
+			Role: SpaceRoleDeveloper,
+			Kind: "User",
+			Name: "dev@example.com",
+		})
+	}
+
+	changeRegistry := func(s *Space) {
+		s.Spec.BuildpackBuild.ContainerRegistry = "gcr.io/other"
+	}
+
+	managerErr := &apis.FieldError{
+		Message: "space managers can only change role assignments",
+		Paths:   []string{"spec"},
+	}
+
+	cases := map[string]struct {
+		user   authenticationv1.UserInfo
+		update func(*Space)
+		want   *apis.FieldError
+	}{
+		"manager changes roles": {
+			user:   authenticationv1.UserInfo{Username: "manager@example.com"},
+			update: changeRoles,
+		},
+		"manager changes registry": {
+			user:   authenticationv1.UserInfo{Username: "manager@example.com"},
+			update: changeRegistry,
+			want:   managerErr,
+		},
+		"manager group changes registry": {
+			user: authenticationv1.UserInfo{
+				Username: "someone@example.com",
+				Groups:   []string{"system:authenticated", "managers"},
+			},
+			update: changeRegistry,
+			want:   managerErr,
+		},
+		"manager service account changes registry": {
+			user:   authenticationv1.UserInfo{Username: "system:serviceaccount:valid:ci"},
+			update: changeRegistry,
+			want:   managerErr,
+		},
+		"service account in another namespace changes registry": {
+			user:   authenticationv1.UserInfo{Username: "system:serviceaccount:other:ci"},
+			update: changeRegistry,
+		},
+		"admin changes registry": {
+			user:   authenticationv1.UserInfo{Username: "admin@example.com"},
+			update: changeRegistry,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			updated := original.DeepCopy()
+			tc.update(updated)
+
+			ctx := apis.WithinUpdate(context.Background(), original)
+			ctx = apis.WithUserInfo(ctx, &tc.user)
+			got := updated.Validate(ctx)
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+// GetGroupVersionKind returns the GroupVersionKind.
+func (r *Task) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("Task")
+}
+
+const (
+	// TaskConditionSucceeded is set when the Task has run to completion.
+	TaskConditionSucceeded = apis.ConditionSucceeded
+	// TaskConditionAppReady is set when the App the Task runs has an image.
+	TaskConditionAppReady apis.ConditionType = "AppReady"
+	// TaskConditionJobSucceeded is set when the Job backing the Task has
+	// completed.
+	TaskConditionJobSucceeded apis.ConditionType = "JobSucceeded"
+)
+
+func (status *TaskStatus) manage() apis.ConditionManager {
+	return apis.NewBatchConditionSet(
+		TaskConditionAppReady,
+		TaskConditionJobSucceeded,
+	).Manage(status)
+}
+
+// Succeeded returns if the Task has run to completion successfully.
+func (status *TaskStatus) Succeeded() bool {
+	return status.manage().IsHappy()
+}
+
+// GetCondition returns the condition by name.
+func (status *TaskStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return status.manage().GetCondition(t)
+}
+
+// InitializeConditions sets the initial values to the conditions.
+func (status *TaskStatus) InitializeConditions() {
+	status.manage().InitializeConditions()
+}
+
+// JobCondition gets a manager for the state of the Job.
+func (status *TaskStatus) JobCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), TaskConditionJobSucceeded, "Job")
+}
+
+// MarkAppNotFound notes that the App the Task runs doesn't exist.
+func (status *TaskStatus) MarkAppNotFound(name string) {
+	status.manage().MarkFalse(TaskConditionAppReady, "AppNotFound",
+		fmt.Sprintf("The App %q does not exist.", name))
+}
+
+// PropagateAppStatus copies the image of the App to the Task and marks the
+// App as ready once it has one.
+func (status *TaskStatus) PropagateAppStatus(app *App) {
+	if app.Status.Image == "" {
+		status.manage().MarkUnknown(TaskConditionAppReady, "AppNotReady",
+			fmt.Sprintf("Waiting for App %q to have an image.", app.Name))
+		return
+	}
+
+	// Once a Task is started it keeps running the image it started with even
+	// if the App is pushed again.
+	if status.Image == "" {
+		status.Image = app.Status.Image
+	}
+
+	status.manage().MarkTrue(TaskConditionAppReady)
+}
+
+// PropagateJobStatus copies fields from the Job status to the Task and
+// updates the readiness based on the Job's conditions.
+func (status *TaskStatus) PropagateJobStatus(job *batchv1.Job) {
+	if job == nil {
+		return
+	}
+
+	status.JobName = job.Name
+	status.StartTime = job.Status.StartTime
+	status.CompletionTime = job.Status.CompletionTime
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			status.manage().MarkTrue(TaskConditionJobSucceeded)
+			return
+		case batchv1.JobFailed:
+			status.manage().MarkFalse(TaskConditionJobSucceeded, condition.Reason, "Task failed: %s", condition.Message)
+			return
+		}
+	}
+
+	status.manage().MarkUnknown(TaskConditionJobSucceeded, "Running", "Task is running")
+}
+
+// PropagateTerminatedState records the exit code of the Task's process.
+func (status *TaskStatus) PropagateTerminatedState(state *corev1.ContainerStateTerminated) {
+	if state == nil {
+		return
+	}
+
+	exitCode := state.ExitCode
+	status.ExitCode = &exitCode
+}
+
+// MarkTerminated notes that the Task was stopped by the user.
+func (status *TaskStatus) MarkTerminated() {
+	status.manage().MarkFalse(TaskConditionJobSucceeded, "Terminated", "Task was terminated")
+}
+
+// IsDone returns true if the Task has either succeeded or failed.
+func (status *TaskStatus) IsDone() bool {
+	cond := status.GetCondition(TaskConditionSucceeded)
+	return cond != nil && cond.Status != corev1.ConditionUnknown
+}
+
+func (status *TaskStatus) duck() *duckv1beta1.Status {
+	return &status.Status
+}
@@ -0,0 +1,212 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/kf/pkg/kf/testutil"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	apitesting "knative.dev/pkg/apis/testing"
+)
+
+func TestTaskDuckTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		t    duck.Implementable
+	}{
+		{
+			name: "conditions",
+			t:    &duckv1beta1.Conditions{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := duck.VerifyType(&Task{}, test.t)
+			if err != nil {
+				t.Errorf("VerifyType(Task, %T) = %v", test.t, err)
+			}
+		})
+	}
+}
+
+func initTestTaskStatus(t *testing.T) *TaskStatus {
+	t.Helper()
+	status := &TaskStatus{}
+	status.InitializeConditions()
+
+	// sanity check
+	apitesting.CheckConditionOngoing(status.duck(), TaskConditionSucceeded, t)
+	apitesting.CheckConditionOngoing(status.duck(), TaskConditionAppReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), TaskConditionJobSucceeded, t)
+
+	return status
+}
+
+func readyTaskApp() *App {
+	app := &App{}
+	app.Name = "my-app"
+	app.Status.Image = "some-image"
+	return app
+}
+
+func taskJob(conditionType batchv1.JobConditionType) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-job-name",
+		},
+	}
+
+	if conditionType != "" {
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: conditionType, Status: corev1.ConditionTrue, Reason: "SomeReason"},
+		}
+	}
+
+	return job
+}
+
+func TestTaskHappyPath(t *testing.T) {
+	status := initTestTaskStatus(t)
+
+	status.PropagateAppStatus(readyTaskApp())
+	status.PropagateJobStatus(taskJob(""))
+
+	apitesting.CheckConditionOngoing(status.duck(), TaskConditionSucceeded, t)
+	apitesting.CheckConditionSucceeded(status.duck(), TaskConditionAppReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), TaskConditionJobSucceeded, t)
+	testutil.AssertEqual(t, "JobName", "some-job-name", status.JobName)
+	testutil.AssertEqual(t, "Image", "some-image", status.Image)
+	testutil.AssertEqual(t, "IsDone", false, status.IsDone())
+
+	status.PropagateJobStatus(taskJob(batchv1.JobComplete))
+	status.PropagateTerminatedState(&corev1.ContainerStateTerminated{ExitCode: 0})
+
+	apitesting.CheckConditionSucceeded(status.duck(), TaskConditionSucceeded, t)
+	apitesting.CheckConditionSucceeded(status.duck(), TaskConditionJobSucceeded, t)
+	testutil.AssertEqual(t, "IsDone", true, status.IsDone())
+	testutil.AssertEqual(t, "Succeeded", true, status.Succeeded())
+	testutil.AssertEqual(t, "ExitCode", int32(0), *status.ExitCode)
+}
+
+func TestTaskStatus_PropagateAppStatus_keepsImage(t *testing.T) {
+	status := initTestTaskStatus(t)
+	status.PropagateAppStatus(readyTaskApp())
+
+	updated := readyTaskApp()
+	updated.Status.Image = "newer-image"
+	status.PropagateAppStatus(updated)
+
+	testutil.AssertEqual(t, "Image", "some-image", status.Image)
+}
+
+func TestTaskStatus_lifecycle(t *testing.T) {
+	cases := map[string]struct {
+		Init func(*TaskStatus)
+
+		ExpectSucceeded []apis.ConditionType
+		ExpectFailed    []apis.ConditionType
+		ExpectOngoing   []apis.ConditionType
+	}{
+		"happy path": {
+			Init: func(status *TaskStatus) {
+				status.PropagateAppStatus(readyTaskApp())
+				status.PropagateJobStatus(taskJob(batchv1.JobComplete))
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionAppReady,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"app not ready": {
+			Init: func(status *TaskStatus) {
+				status.PropagateAppStatus(&App{})
+			},
+			ExpectOngoing: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionAppReady,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"app not found": {
+			Init: func(status *TaskStatus) {
+				status.MarkAppNotFound("my-app")
+			},
+			ExpectFailed: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionAppReady,
+			},
+		},
+		"job failed": {
+			Init: func(status *TaskStatus) {
+				status.PropagateAppStatus(readyTaskApp())
+				status.PropagateJobStatus(taskJob(batchv1.JobFailed))
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				TaskConditionAppReady,
+			},
+			ExpectFailed: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"terminated": {
+			Init: func(status *TaskStatus) {
+				status.PropagateAppStatus(readyTaskApp())
+				status.MarkTerminated()
+			},
+			ExpectFailed: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"job not owned": {
+			Init: func(status *TaskStatus) {
+				status.JobCondition().MarkChildNotOwned("my-job")
+			},
+			ExpectFailed: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := initTestTaskStatus(t)
+
+			tc.Init(status)
+
+			for _, exp := range tc.ExpectFailed {
+				apitesting.CheckConditionFailed(status.duck(), exp, t)
+			}
+
+			for _, exp := range tc.ExpectOngoing {
+				apitesting.CheckConditionOngoing(status.duck(), exp, t)
+			}
+
+			for _, exp := range tc.ExpectSucceeded {
+				apitesting.CheckConditionSucceeded(status.duck(), exp, t)
+			}
+		})
+	}
+}
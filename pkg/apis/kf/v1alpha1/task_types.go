@@ -0,0 +1,105 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+const (
+	// TaskNameLabel holds the label key used to find the resources
+	// belonging to a Task.
+	TaskNameLabel = "kf.dev/task"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Task represents a one-off process run using an App's image and
+// configuration.
+type Task struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec TaskSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status TaskStatus `json:"status,omitempty"`
+}
+
+// TaskSpec defines the process a Task runs.
+type TaskSpec struct {
+
+	// AppName is the name of the App in the same namespace the Task
+	// inherits its image, pod template and environment from.
+	AppName string `json:"appName"`
+
+	// Command overrides the entrypoint of the App's container.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args overrides the arguments of the App's container.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env holds environment variables added to the App's when the Task runs.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Terminated is set to stop a running Task.
+	// +optional
+	Terminated bool `json:"terminated,omitempty"`
+}
+
+// TaskStatus is the current state of a Task.
+type TaskStatus struct {
+	// Pull in the fields from Knative's duckv1beta1 status field.
+	duckv1beta1.Status `json:",inline"`
+
+	// Image is the App image the Task was run with.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// JobName is the name of the Job running the Task.
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// StartTime is the time the Task started running.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the Task finished running.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// ExitCode is the exit code of the Task's process once it has terminated.
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TaskList is a list of Task resources.
+type TaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Task `json:"items"`
+}
@@ -24,8 +24,8 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
-	cv1alpha3 "knative.dev/pkg/client/clientset/versioned/typed/istio/v1alpha3"
 )
 
 // PropagateCondition copies the condition of a sub-resource (source) to a
@@ -158,12 +158,21 @@ func GenerateName(parts ...string) string {
 	return fmt.Sprintf("%s-%s", prefix, checksum)
 }
 
-type istioClientKey struct{}
+// DomainGetter fetches cluster-wide Domains. It's implemented by the
+// generated Domains client.
+type DomainGetter interface {
+	Get(name string, options metav1.GetOptions) (*Domain, error)
+}
+
+type domainGetterKey struct{}
 
-func SetupIstioClient(ctx context.Context, istioClient cv1alpha3.VirtualServicesGetter) context.Context {
-	return context.WithValue(ctx, istioClientKey{}, istioClient)
+// SetupDomainGetter attaches the DomainGetter used to validate Routes to the
+// context.
+func SetupDomainGetter(ctx context.Context, domains DomainGetter) context.Context {
+	return context.WithValue(ctx, domainGetterKey{}, domains)
 }
 
-func IstioClientFromContext(ctx context.Context) cv1alpha3.VirtualServicesGetter {
-	return ctx.Value(istioClientKey{}).(cv1alpha3.VirtualServicesGetter)
+// DomainGetterFromContext returns the DomainGetter attached to the context.
+func DomainGetterFromContext(ctx context.Context) DomainGetter {
+	return ctx.Value(domainGetterKey{}).(DomainGetter)
 }
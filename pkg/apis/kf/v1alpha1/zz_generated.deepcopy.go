@@ -20,6 +20,7 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -84,6 +85,23 @@ func (in *AppList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppRevision) DeepCopyInto(out *AppRevision) {
+	*out = *in
+	in.DeployedAt.DeepCopyInto(&out.DeployedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppRevision.
+func (in *AppRevision) DeepCopy() *AppRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(AppRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppSpec) DeepCopyInto(out *AppSpec) {
 	*out = *in
@@ -95,6 +113,11 @@ func (in *AppSpec) DeepCopyInto(out *AppSpec) {
 		*out = make([]RouteSpecFields, len(*in))
 		copy(*out, *in)
 	}
+	if in.BuildCache != nil {
+		in, out := &in.BuildCache, &out.BuildCache
+		*out = new(AppSpecBuildCache)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -108,6 +131,23 @@ func (in *AppSpec) DeepCopy() *AppSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppSpecBuildCache) DeepCopyInto(out *AppSpecBuildCache) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppSpecBuildCache.
+func (in *AppSpecBuildCache) DeepCopy() *AppSpecBuildCache {
+	if in == nil {
+		return nil
+	}
+	out := new(AppSpecBuildCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppSpecInstances) DeepCopyInto(out *AppSpecInstances) {
 	*out = *in
@@ -163,6 +203,13 @@ func (in *AppStatus) DeepCopyInto(out *AppStatus) {
 	out.SourceStatusFields = in.SourceStatusFields
 	out.ConfigurationStatusFields = in.ConfigurationStatusFields
 	in.RouteStatusFields.DeepCopyInto(&out.RouteStatusFields)
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]AppRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -220,12 +267,131 @@ func (in OwnerReferences) DeepCopy() OwnerReferences {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildResult) DeepCopyInto(out *BuildResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildResult.
+func (in *BuildResult) DeepCopy() *BuildResult {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Domain) DeepCopyInto(out *Domain) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Domain.
+func (in *Domain) DeepCopy() *Domain {
+	if in == nil {
+		return nil
+	}
+	out := new(Domain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Domain) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainList) DeepCopyInto(out *DomainList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Domain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainList.
+func (in *DomainList) DeepCopy() *DomainList {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DomainList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
+	*out = *in
+	if in.AllowedSpaces != nil {
+		in, out := &in.AllowedSpaces, &out.AllowedSpaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DomainTLS)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainSpec.
+func (in *DomainSpec) DeepCopy() *DomainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainTLS) DeepCopyInto(out *DomainTLS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainTLS.
+func (in *DomainTLS) DeepCopy() *DomainTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -288,6 +454,13 @@ func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AppWeights != nil {
+		in, out := &in.AppWeights, &out.AppWeights
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	out.RouteSpecFields = in.RouteSpecFields
 	return
 }
@@ -318,6 +491,23 @@ func (in *RouteSpecFields) DeepCopy() *RouteSpecFields {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteStatus) DeepCopyInto(out *RouteStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteStatus.
+func (in *RouteStatus) DeepCopy() *RouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Source) DeepCopyInto(out *Source) {
 	*out = *in
@@ -382,8 +572,15 @@ func (in *SourceList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceSpec) DeepCopyInto(out *SourceSpec) {
 	*out = *in
-	out.ContainerImage = in.ContainerImage
+	in.ContainerImage.DeepCopyInto(&out.ContainerImage)
 	in.BuildpackBuild.DeepCopyInto(&out.BuildpackBuild)
+	in.Dockerfile.DeepCopyInto(&out.Dockerfile)
+	in.Rebase.DeepCopyInto(&out.Rebase)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -400,6 +597,11 @@ func (in *SourceSpec) DeepCopy() *SourceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceSpecBuildpackBuild) DeepCopyInto(out *SourceSpecBuildpackBuild) {
 	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(SourceSpecGit)
+		**out = **in
+	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]v1.EnvVar, len(*in))
@@ -423,6 +625,11 @@ func (in *SourceSpecBuildpackBuild) DeepCopy() *SourceSpecBuildpackBuild {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceSpecContainerImage) DeepCopyInto(out *SourceSpecContainerImage) {
 	*out = *in
+	if in.BuildpackBuild != nil {
+		in, out := &in.BuildpackBuild, &out.BuildpackBuild
+		*out = new(SourceSpecBuildpackBuild)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -436,6 +643,71 @@ func (in *SourceSpecContainerImage) DeepCopy() *SourceSpecContainerImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceSpecDockerfile) DeepCopyInto(out *SourceSpecDockerfile) {
+	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(SourceSpecGit)
+		**out = **in
+	}
+	if in.BuildArgs != nil {
+		in, out := &in.BuildArgs, &out.BuildArgs
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceSpecDockerfile.
+func (in *SourceSpecDockerfile) DeepCopy() *SourceSpecDockerfile {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceSpecDockerfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceSpecGit) DeepCopyInto(out *SourceSpecGit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceSpecGit.
+func (in *SourceSpecGit) DeepCopy() *SourceSpecGit {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceSpecGit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceSpecRebase) DeepCopyInto(out *SourceSpecRebase) {
+	*out = *in
+	if in.BuildpackBuild != nil {
+		in, out := &in.BuildpackBuild, &out.BuildpackBuild
+		*out = new(SourceSpecBuildpackBuild)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceSpecRebase.
+func (in *SourceSpecRebase) DeepCopy() *SourceSpecRebase {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceSpecRebase)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
 	*out = *in
@@ -501,6 +773,11 @@ func (in *Space) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceDomain) DeepCopyInto(out *SpaceDomain) {
 	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DomainTLS)
+		**out = **in
+	}
 	return
 }
 
@@ -519,7 +796,9 @@ func (in SpaceDomains) DeepCopyInto(out *SpaceDomains) {
 	{
 		in := &in
 		*out = make(SpaceDomains, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 		return
 	}
 }
@@ -567,10 +846,42 @@ func (in *SpaceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceNetworkPolicy) DeepCopyInto(out *SpaceNetworkPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceNetworkPolicy.
+func (in *SpaceNetworkPolicy) DeepCopy() *SpaceNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceRoleAssignment) DeepCopyInto(out *SpaceRoleAssignment) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceRoleAssignment.
+func (in *SpaceRoleAssignment) DeepCopy() *SpaceRoleAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceRoleAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceSpec) DeepCopyInto(out *SpaceSpec) {
 	*out = *in
-	out.Security = in.Security
+	in.Security.DeepCopyInto(&out.Security)
 	in.BuildpackBuild.DeepCopyInto(&out.BuildpackBuild)
 	in.Execution.DeepCopyInto(&out.Execution)
 	in.ResourceLimits.DeepCopyInto(&out.ResourceLimits)
@@ -587,6 +898,32 @@ func (in *SpaceSpec) DeepCopy() *SpaceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceSpecBuildRetention) DeepCopyInto(out *SpaceSpecBuildRetention) {
+	*out = *in
+	if in.Succeeded != nil {
+		in, out := &in.Succeeded, &out.Succeeded
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceSpecBuildRetention.
+func (in *SpaceSpecBuildRetention) DeepCopy() *SpaceSpecBuildRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceSpecBuildRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceSpecBuildpackBuild) DeepCopyInto(out *SpaceSpecBuildpackBuild) {
 	*out = *in
@@ -597,6 +934,12 @@ func (in *SpaceSpecBuildpackBuild) DeepCopyInto(out *SpaceSpecBuildpackBuild) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	in.Retention.DeepCopyInto(&out.Retention)
 	return
 }
 
@@ -623,7 +966,9 @@ func (in *SpaceSpecExecution) DeepCopyInto(out *SpaceSpecExecution) {
 	if in.Domains != nil {
 		in, out := &in.Domains, &out.Domains
 		*out = make([]SpaceDomain, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -671,6 +1016,16 @@ func (in *SpaceSpecResourceLimits) DeepCopy() *SpaceSpecResourceLimits {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceSpecSecurity) DeepCopyInto(out *SpaceSpecSecurity) {
 	*out = *in
+	if in.RoleAssignments != nil {
+		in, out := &in.RoleAssignments, &out.RoleAssignments
+		*out = make([]SpaceRoleAssignment, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = make([]SpaceNetworkPolicy, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -701,3 +1056,127 @@ func (in *SpaceStatus) DeepCopy() *SpaceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Task) DeepCopyInto(out *Task) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Task.
+func (in *Task) DeepCopy() *Task {
+	if in == nil {
+		return nil
+	}
+	out := new(Task)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Task) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskList) DeepCopyInto(out *TaskList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Task, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskList.
+func (in *TaskList) DeepCopy() *TaskList {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskSpec.
+func (in *TaskSpec) DeepCopy() *TaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskStatus) DeepCopyInto(out *TaskStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskStatus.
+func (in *TaskStatus) DeepCopy() *TaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
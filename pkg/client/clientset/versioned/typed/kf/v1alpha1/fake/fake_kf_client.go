@@ -30,6 +30,10 @@ func (c *FakeKfV1alpha1) Apps(namespace string) v1alpha1.AppInterface {
 	return &FakeApps{c, namespace}
 }
 
+func (c *FakeKfV1alpha1) Domains() v1alpha1.DomainInterface {
+	return &FakeDomains{c}
+}
+
 func (c *FakeKfV1alpha1) Routes(namespace string) v1alpha1.RouteInterface {
 	return &FakeRoutes{c, namespace}
 }
@@ -42,6 +46,10 @@ func (c *FakeKfV1alpha1) Spaces() v1alpha1.SpaceInterface {
 	return &FakeSpaces{c}
 }
 
+func (c *FakeKfV1alpha1) Tasks(namespace string) v1alpha1.TaskInterface {
+	return &FakeTasks{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKfV1alpha1) RESTClient() rest.Interface {
@@ -18,8 +18,12 @@ package v1alpha1
 
 type AppExpansion interface{}
 
+type DomainExpansion interface{}
+
 type RouteExpansion interface{}
 
 type SourceExpansion interface{}
 
 type SpaceExpansion interface{}
+
+type TaskExpansion interface{}
@@ -53,12 +53,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=kf.dev, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("apps"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Apps().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("domains"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Domains().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("routes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Routes().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("sources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Sources().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("spaces"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Spaces().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("tasks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Tasks().Informer()}, nil
 
 	}
 
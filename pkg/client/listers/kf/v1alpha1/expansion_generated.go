@@ -24,6 +24,10 @@ type AppListerExpansion interface{}
 // AppNamespaceLister.
 type AppNamespaceListerExpansion interface{}
 
+// DomainListerExpansion allows custom methods to be added to
+// DomainLister.
+type DomainListerExpansion interface{}
+
 // RouteListerExpansion allows custom methods to be added to
 // RouteLister.
 type RouteListerExpansion interface{}
@@ -43,3 +47,11 @@ type SourceNamespaceListerExpansion interface{}
 // SpaceListerExpansion allows custom methods to be added to
 // SpaceLister.
 type SpaceListerExpansion interface{}
+
+// TaskListerExpansion allows custom methods to be added to
+// TaskLister.
+type TaskListerExpansion interface{}
+
+// TaskNamespaceListerExpansion allows custom methods to be added to
+// TaskNamespaceLister.
+type TaskNamespaceListerExpansion interface{}
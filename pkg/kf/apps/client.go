@@ -15,6 +15,8 @@
 package apps
 
 import (
+	"errors"
+	"fmt"
 	"io"
 
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -32,6 +34,17 @@ type ClientExtension interface {
 	DeployLogs(out io.Writer, appName, resourceVersion, namespace string, noStart bool) error
 	Restart(namespace, name string) error
 	Restage(namespace, name string) error
+
+	// Rebase moves the layers of the App's current image onto the latest run
+	// image of its stack without rebuilding it. Only Apps built with
+	// buildpacks can be rebased.
+	Rebase(namespace, name string) error
+
+	// Rollback re-deploys the image of a previous successful deployment of
+	// the App without rebuilding it. If number is 0 the deployment before the
+	// latest one is used. The updated App and the revision that was rolled
+	// back to are returned.
+	Rollback(namespace, name string, number int) (*v1alpha1.App, *v1alpha1.AppRevision, error)
 }
 
 type appsClient struct {
@@ -75,7 +88,129 @@ func (ac *appsClient) Restart(namespace, name string) error {
 // resulting container.
 func (ac *appsClient) Restage(namespace, name string) error {
 	return ac.coreClient.Transform(namespace, name, func(a *v1alpha1.App) error {
-		a.Spec.Source.UpdateRequests++
+		a.Spec.Source = *RestageSource(a)
+		return nil
+	})
+}
+
+// RestageSource creates the Source spec that rebuilds the App. Apps that were
+// rebased or redeployed from an image are rebuilt from the buildpack build
+// that produced their original image.
+func RestageSource(app *v1alpha1.App) *v1alpha1.SourceSpec {
+	source := app.Spec.Source.DeepCopy()
+	source.UpdateRequests++
+
+	if original := source.OriginalBuildpackBuild(); original != nil && !source.IsBuildpackBuild() {
+		source.BuildpackBuild = *original
+		source.Rebase = v1alpha1.SourceSpecRebase{}
+		source.ContainerImage = v1alpha1.SourceSpecContainerImage{}
+	}
+
+	return source
+}
+
+// Rebase causes the controller to create a new image from the App's current
+// one on top of the latest run image and then deploy it.
+func (ac *appsClient) Rebase(namespace, name string) error {
+	return ac.coreClient.Transform(namespace, name, func(a *v1alpha1.App) error {
+		source, err := RebaseSource(a)
+		if err != nil {
+			return err
+		}
+
+		a.Spec.Source = *source
 		return nil
 	})
 }
+
+// RebaseSource creates the Source spec that rebases the App's current image.
+// The builder, registry and stack of the App's buildpack build are kept and
+// the build is recorded so the App can be restaged from source later.
+func RebaseSource(app *v1alpha1.App) (*v1alpha1.SourceSpec, error) {
+	current := app.Spec.Source
+
+	var rebase v1alpha1.SourceSpecRebase
+	if current.IsRebase() {
+		rebase = *current.Rebase.DeepCopy()
+	} else if original := current.OriginalBuildpackBuild(); original != nil {
+		rebase.Stack = original.Stack
+		rebase.BuildpackBuilder = original.BuildpackBuilder
+		rebase.Registry = original.Registry
+		rebase.BuildpackBuild = original
+	} else {
+		return nil, fmt.Errorf("app %s wasn't built with buildpacks", app.Name)
+	}
+
+	if app.Status.Image == "" {
+		return nil, fmt.Errorf("app %s doesn't have an image to rebase yet", app.Name)
+	}
+	rebase.Image = app.Status.Image
+
+	return &v1alpha1.SourceSpec{
+		UpdateRequests: current.UpdateRequests + 1,
+		ServiceAccount: current.ServiceAccount,
+		Rebase:         rebase,
+	}, nil
+}
+
+// deployImageSource creates the Source spec that deploys an image without
+// rebuilding it. The buildpack build that produced the image is kept so the
+// App can still be restaged or rebased.
+func deployImageSource(current v1alpha1.SourceSpec, image string) v1alpha1.SourceSpec {
+	src := sources.NewKfSource()
+	src.SetContainerImageSource(image)
+	src.Spec.ContainerImage.BuildpackBuild = current.OriginalBuildpackBuild()
+
+	return src.Spec
+}
+
+// Rollback re-deploys the image of a previous successful deployment of the
+// App. The App's current template and environment are kept.
+func (ac *appsClient) Rollback(namespace, name string, number int) (*v1alpha1.App, *v1alpha1.AppRevision, error) {
+	app, err := ac.coreClient.Get(namespace, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revision, err := RollbackTarget(&app.Status, number)
+	if err != nil {
+		return nil, nil, err
+	}
+	target := revision.DeepCopy()
+	app.Spec.Source = *RollbackSource(app, target)
+
+	updated, err := ac.coreClient.Update(namespace, app)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return updated, target, nil
+}
+
+// RollbackSource creates the Source spec that deploys the revision's image.
+// The App's buildpack build is kept so it can still be restaged or rebased.
+func RollbackSource(app *v1alpha1.App, revision *v1alpha1.AppRevision) *v1alpha1.SourceSpec {
+	source := deployImageSource(app.Spec.Source, revision.Image)
+	return &source
+}
+
+// RollbackTarget finds the deployment to roll back to. If number is 0 the
+// deployment before the latest one is used.
+func RollbackTarget(status *v1alpha1.AppStatus, number int) (*v1alpha1.AppRevision, error) {
+	var revision *v1alpha1.AppRevision
+	if number == 0 {
+		if len(status.Revisions) < 2 {
+			return nil, errors.New("no previous revision to roll back to")
+		}
+
+		revision = &status.Revisions[len(status.Revisions)-2]
+	} else if revision = status.FindRevision(number); revision == nil {
+		return nil, fmt.Errorf("revision %d not found, it may have been removed from the history", number)
+	}
+
+	if revision.Image == "" {
+		return nil, fmt.Errorf("revision %d doesn't have an image to deploy", revision.Number)
+	}
+
+	return revision, nil
+}
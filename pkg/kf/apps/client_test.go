@@ -0,0 +1,260 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps_test
+
+import (
+	"errors"
+	"testing"
+
+	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestRollbackTarget(t *testing.T) {
+	t.Parallel()
+
+	history := v1alpha1.AppStatus{
+		Revisions: []v1alpha1.AppRevision{
+			{Number: 1, Image: "image-1"},
+			{Number: 2},
+			{Number: 3, Image: "image-3"},
+		},
+	}
+
+	cases := map[string]struct {
+		status v1alpha1.AppStatus
+		number int
+
+		expectImage string
+		expectErr   error
+	}{
+		"previous revision": {
+			status:    history,
+			expectErr: errors.New("revision 2 doesn't have an image to deploy"),
+		},
+		"explicit revision": {
+			status:      history,
+			number:      1,
+			expectImage: "image-1",
+		},
+		"missing revision": {
+			status:    history,
+			number:    7,
+			expectErr: errors.New("revision 7 not found, it may have been removed from the history"),
+		},
+		"no history": {
+			expectErr: errors.New("no previous revision to roll back to"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			revision, err := apps.RollbackTarget(&tc.status, tc.number)
+			testutil.AssertErrorsEqual(t, tc.expectErr, err)
+			if err != nil {
+				return
+			}
+
+			testutil.AssertEqual(t, "image", tc.expectImage, revision.Image)
+		})
+	}
+}
+
+func TestRebaseSource(t *testing.T) {
+	t.Parallel()
+
+	buildpackApp := v1alpha1.App{}
+	buildpackApp.Name = "my-app"
+	buildpackApp.Spec.Source.UpdateRequests = 2
+	buildpackApp.Spec.Source.BuildpackBuild = v1alpha1.SourceSpecBuildpackBuild{
+		Source:           "gcr.io/my-registry/src-my-app",
+		Stack:            "gcr.io/my-registry/run:cflinuxfs3",
+		BuildpackBuilder: "gcr.io/my-registry/builder",
+		Registry:         "gcr.io/my-registry",
+	}
+	buildpackApp.Status.Image = "gcr.io/my-registry/app-my-app:1"
+
+	rebasedApp := v1alpha1.App{}
+	rebasedApp.Name = "my-app"
+	rebasedApp.Spec.Source.Rebase = v1alpha1.SourceSpecRebase{
+		Image:            "gcr.io/my-registry/app-my-app:1",
+		Stack:            "gcr.io/my-registry/run:cflinuxfs3",
+		BuildpackBuilder: "gcr.io/my-registry/builder",
+		Registry:         "gcr.io/my-registry",
+	}
+	rebasedApp.Status.Image = "gcr.io/my-registry/app-my-app:2"
+
+	redeployedApp := v1alpha1.App{}
+	redeployedApp.Name = "my-app"
+	redeployedApp.Spec.Source.ContainerImage = v1alpha1.SourceSpecContainerImage{
+		Image:          "gcr.io/my-registry/app-my-app@sha256:abc",
+		BuildpackBuild: buildpackApp.Spec.Source.BuildpackBuild.DeepCopy(),
+	}
+	redeployedApp.Status.Image = "gcr.io/my-registry/app-my-app@sha256:abc"
+
+	containerApp := v1alpha1.App{}
+	containerApp.Name = "my-app"
+	containerApp.Spec.Source.ContainerImage.Image = "nginx"
+	containerApp.Status.Image = "nginx"
+
+	buildingApp := *buildpackApp.DeepCopy()
+	buildingApp.Status.Image = ""
+
+	cases := map[string]struct {
+		app v1alpha1.App
+
+		expectRebase   v1alpha1.SourceSpecRebase
+		expectRequests int
+		expectErr      error
+	}{
+		"buildpack build": {
+			app: buildpackApp,
+			expectRebase: v1alpha1.SourceSpecRebase{
+				Image:            "gcr.io/my-registry/app-my-app:1",
+				Stack:            "gcr.io/my-registry/run:cflinuxfs3",
+				BuildpackBuilder: "gcr.io/my-registry/builder",
+				Registry:         "gcr.io/my-registry",
+				BuildpackBuild:   &buildpackApp.Spec.Source.BuildpackBuild,
+			},
+			expectRequests: 3,
+		},
+		"rebased image": {
+			app: rebasedApp,
+			expectRebase: v1alpha1.SourceSpecRebase{
+				Image:            "gcr.io/my-registry/app-my-app:2",
+				Stack:            "gcr.io/my-registry/run:cflinuxfs3",
+				BuildpackBuilder: "gcr.io/my-registry/builder",
+				Registry:         "gcr.io/my-registry",
+			},
+			expectRequests: 1,
+		},
+		"redeployed image": {
+			app: redeployedApp,
+			expectRebase: v1alpha1.SourceSpecRebase{
+				Image:            "gcr.io/my-registry/app-my-app@sha256:abc",
+				Stack:            "gcr.io/my-registry/run:cflinuxfs3",
+				BuildpackBuilder: "gcr.io/my-registry/builder",
+				Registry:         "gcr.io/my-registry",
+				BuildpackBuild:   &buildpackApp.Spec.Source.BuildpackBuild,
+			},
+			expectRequests: 1,
+		},
+		"container image": {
+			app:       containerApp,
+			expectErr: errors.New("app my-app wasn't built with buildpacks"),
+		},
+		"no image yet": {
+			app:       buildingApp,
+			expectErr: errors.New("app my-app doesn't have an image to rebase yet"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			source, err := apps.RebaseSource(&tc.app)
+			testutil.AssertErrorsEqual(t, tc.expectErr, err)
+			if err != nil {
+				return
+			}
+
+			testutil.AssertEqual(t, "rebase", tc.expectRebase, source.Rebase)
+			testutil.AssertEqual(t, "update requests", tc.expectRequests, source.UpdateRequests)
+			testutil.AssertEqual(t, "is rebase", true, source.IsRebase())
+			testutil.AssertEqual(t, "is buildpack build", false, source.IsBuildpackBuild())
+		})
+	}
+}
+
+func TestRestageSource(t *testing.T) {
+	t.Parallel()
+
+	buildpackBuild := v1alpha1.SourceSpecBuildpackBuild{
+		Source:           "gcr.io/my-registry/src-my-app",
+		Stack:            "gcr.io/my-registry/run:cflinuxfs3",
+		BuildpackBuilder: "gcr.io/my-registry/builder",
+		Registry:         "gcr.io/my-registry",
+	}
+
+	app := v1alpha1.App{}
+	app.Name = "my-app"
+	app.Spec.Source.BuildpackBuild = buildpackBuild
+	app.Status.Image = "gcr.io/my-registry/app-my-app:1"
+
+	t.Run("buildpack build", func(t *testing.T) {
+		source := apps.RestageSource(&app)
+		testutil.AssertEqual(t, "buildpack build", buildpackBuild, source.BuildpackBuild)
+		testutil.AssertEqual(t, "update requests", 1, source.UpdateRequests)
+	})
+
+	t.Run("rebased twice then restaged", func(t *testing.T) {
+		rebased := *app.DeepCopy()
+		for i := 0; i < 2; i++ {
+			source, err := apps.RebaseSource(&rebased)
+			testutil.AssertNil(t, "rebase error", err)
+			rebased.Spec.Source = *source
+		}
+
+		source := apps.RestageSource(&rebased)
+		testutil.AssertEqual(t, "buildpack build", buildpackBuild, source.BuildpackBuild)
+		testutil.AssertEqual(t, "update requests", 3, source.UpdateRequests)
+		testutil.AssertEqual(t, "is rebase", false, source.IsRebase())
+		testutil.AssertEqual(t, "is buildpack build", true, source.IsBuildpackBuild())
+	})
+
+	t.Run("redeployed image", func(t *testing.T) {
+		redeployed := v1alpha1.App{}
+		redeployed.Spec.Source.ContainerImage = v1alpha1.SourceSpecContainerImage{
+			Image:          "gcr.io/my-registry/app-my-app@sha256:abc",
+			BuildpackBuild: buildpackBuild.DeepCopy(),
+		}
+
+		source := apps.RestageSource(&redeployed)
+		testutil.AssertEqual(t, "buildpack build", buildpackBuild, source.BuildpackBuild)
+		testutil.AssertEqual(t, "is container build", false, source.IsContainerBuild())
+	})
+
+	t.Run("container image", func(t *testing.T) {
+		container := v1alpha1.App{}
+		container.Spec.Source.ContainerImage.Image = "nginx"
+
+		source := apps.RestageSource(&container)
+		testutil.AssertEqual(t, "container image", "nginx", source.ContainerImage.Image)
+	})
+}
+
+func TestRollbackSource(t *testing.T) {
+	t.Parallel()
+
+	buildpackBuild := v1alpha1.SourceSpecBuildpackBuild{
+		Source:           "gcr.io/my-registry/src-my-app",
+		Stack:            "gcr.io/my-registry/run:cflinuxfs3",
+		BuildpackBuilder: "gcr.io/my-registry/builder",
+		Registry:         "gcr.io/my-registry",
+	}
+
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.Spec.Source.BuildpackBuild = buildpackBuild
+	revision := &v1alpha1.AppRevision{Image: "gcr.io/my-registry/app-my-app@sha256:abc"}
+
+	source := apps.RollbackSource(app, revision)
+	testutil.AssertEqual(t, "image", revision.Image, source.ContainerImage.Image)
+	testutil.AssertEqual(t, "original build", &buildpackBuild, source.ContainerImage.BuildpackBuild)
+
+	app.Spec.Source = *source
+	restaged := apps.RestageSource(app)
+	testutil.AssertEqual(t, "restaged build", buildpackBuild, restaged.BuildpackBuild)
+}
@@ -157,6 +157,20 @@ func (mr *FakeClientMockRecorder) List(arg0 interface{}, arg1 ...interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*FakeClient)(nil).List), varargs...)
 }
 
+// Rebase mocks base method
+func (m *FakeClient) Rebase(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebase", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rebase indicates an expected call of Rebase
+func (mr *FakeClientMockRecorder) Rebase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebase", reflect.TypeOf((*FakeClient)(nil).Rebase), arg0, arg1)
+}
+
 // Restage mocks base method
 func (m *FakeClient) Restage(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -185,6 +199,22 @@ func (mr *FakeClientMockRecorder) Restart(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restart", reflect.TypeOf((*FakeClient)(nil).Restart), arg0, arg1)
 }
 
+// Rollback mocks base method
+func (m *FakeClient) Rollback(arg0, arg1 string, arg2 int) (*v1alpha1.App, *v1alpha1.AppRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha1.App)
+	ret1, _ := ret[1].(*v1alpha1.AppRevision)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Rollback indicates an expected call of Rollback
+func (mr *FakeClientMockRecorder) Rollback(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*FakeClient)(nil).Rollback), arg0, arg1, arg2)
+}
+
 // Transform mocks base method
 func (m *FakeClient) Transform(arg0, arg1 string, arg2 apps.Mutator) error {
 	m.ctrl.T.Helper()
@@ -18,6 +18,8 @@ import "github.com/google/kf/pkg/kf/apps"
 
 //go:generate mockgen --package=fake --copyright_file ../../internal/tools/option-builder/LICENSE_HEADER --destination=fake_client.go --mock_names=Client=FakeClient github.com/google/kf/pkg/kf/apps/fake Client
 //go:generate mockgen --package=fake --copyright_file ../../internal/tools/option-builder/LICENSE_HEADER --destination=fake_pusher.go --mock_names=Pusher=FakePusher github.com/google/kf/pkg/kf/apps/fake Pusher
+//go:generate mockgen --package=fake --copyright_file ../../internal/tools/option-builder/LICENSE_HEADER --destination=fake_stager.go --mock_names=Stager=FakeStager github.com/google/kf/pkg/kf/apps/fake Stager
+//go:generate mockgen --package=fake --copyright_file ../../internal/tools/option-builder/LICENSE_HEADER --destination=fake_promoter.go --mock_names=Promoter=FakePromoter github.com/google/kf/pkg/kf/apps/fake Promoter
 
 // Client is the client for spaces.
 type Client interface {
@@ -28,3 +30,13 @@ type Client interface {
 type Pusher interface {
 	apps.Pusher
 }
+
+// Stager is implemented by kf.Stager.
+type Stager interface {
+	apps.Stager
+}
+
+// Promoter is implemented by kf.Promoter.
+type Promoter interface {
+	apps.Promoter
+}
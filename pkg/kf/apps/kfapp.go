@@ -108,6 +108,48 @@ func (k *KfApp) GetContainerPorts() []corev1.ContainerPort {
 	return nil
 }
 
+// SetCommand sets the entrypoint of the container.
+func (k *KfApp) SetCommand(command []string) {
+	k.getOrCreateContainer().Command = command
+}
+
+// GetCommand gets the entrypoint of the container.
+func (k *KfApp) GetCommand() []string {
+	if container := k.getContainerOrNil(); container != nil {
+		return container.Command
+	}
+
+	return nil
+}
+
+// SetArgs sets the arguments passed to the container's entrypoint.
+func (k *KfApp) SetArgs(args []string) {
+	k.getOrCreateContainer().Args = args
+}
+
+// GetArgs gets the arguments passed to the container's entrypoint.
+func (k *KfApp) GetArgs() []string {
+	if container := k.getContainerOrNil(); container != nil {
+		return container.Args
+	}
+
+	return nil
+}
+
+// SetResources sets the resource requests and limits of the container.
+func (k *KfApp) SetResources(resources corev1.ResourceRequirements) {
+	k.getOrCreateContainer().Resources = resources
+}
+
+// GetResources gets the resource requests and limits of the container.
+func (k *KfApp) GetResources() corev1.ResourceRequirements {
+	if container := k.getContainerOrNil(); container != nil {
+		return container.Resources
+	}
+
+	return corev1.ResourceRequirements{}
+}
+
 // SetServiceAccount sets the account the application will run as.
 func (k *KfApp) SetServiceAccount(sa string) {
 	k.getOrCreateRevisionTemplateSpec().Spec.ServiceAccountName = sa
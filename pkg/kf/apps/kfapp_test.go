@@ -23,6 +23,7 @@ import (
 	"github.com/google/kf/pkg/kf/describe"
 	"github.com/google/kf/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -193,3 +194,42 @@ func ExampleKfApp_GetHealthCheck() {
 	//   Type:      http
 	//   Endpoint:  /healthz
 }
+
+func ExampleKfApp_GetCommand() {
+	myApp := NewKfApp()
+	fmt.Printf("Default: %q\n", myApp.GetCommand())
+
+	myApp.SetCommand([]string{"/bin/sh", "-c"})
+	fmt.Printf("After set: %q\n", myApp.GetCommand())
+
+	// Output: Default: []
+	// After set: ["/bin/sh" "-c"]
+}
+
+func ExampleKfApp_GetArgs() {
+	myApp := NewKfApp()
+	fmt.Printf("Default: %q\n", myApp.GetArgs())
+
+	myApp.SetArgs([]string{"bundle exec rackup"})
+	fmt.Printf("After set: %q\n", myApp.GetArgs())
+
+	// Output: Default: []
+	// After set: ["bundle exec rackup"]
+}
+
+func ExampleKfApp_GetResources() {
+	myApp := NewKfApp()
+	fmt.Printf("Default: %d\n", len(myApp.GetResources().Limits))
+
+	myApp.SetResources(corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	})
+
+	mem := myApp.GetResources().Limits[corev1.ResourceMemory]
+	fmt.Printf("After set: %s\n", mem.String())
+
+	// Output: Default: 0
+	// After set: 512Mi
+}
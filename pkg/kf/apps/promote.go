@@ -0,0 +1,167 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"errors"
+	"fmt"
+
+	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/internal/envutil"
+	"github.com/google/kf/pkg/kf/droplets"
+	"github.com/google/kf/pkg/kf/sources"
+)
+
+// Promoter deploys the images of applications to other spaces.
+type Promoter interface {
+	// Promote deploys the image of an application to the App with the same
+	// name in the given space without rebuilding it. The App is created if
+	// it doesn't exist. The image is referenced by digest so both Apps run
+	// the same image.
+	Promote(appName string, to *v1alpha1.Space, opts ...PromoteOption) (*v1alpha1.App, error)
+}
+
+// promoter copies images between spaces. It should be created via
+// NewPromoter.
+type promoter struct {
+	appsClient Client
+	copyImage  droplets.Copier
+}
+
+// NewPromoter creates a new Promoter.
+func NewPromoter(appsClient Client, copyImage droplets.Copier) Promoter {
+	return &promoter{
+		appsClient: appsClient,
+		copyImage:  copyImage,
+	}
+}
+
+// Promote implements Promoter.
+func (p *promoter) Promote(appName string, to *v1alpha1.Space, opts ...PromoteOption) (*v1alpha1.App, error) {
+	cfg := PromoteOptionDefaults().Extend(opts).toConfig()
+
+	if cfg.Namespace == to.Name {
+		return nil, errors.New("an app can't be promoted to the space it's in")
+	}
+
+	from, err := p.appsClient.Get(cfg.Namespace, appName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app: %s", err)
+	}
+
+	if from.Status.Image == "" {
+		return nil, fmt.Errorf("app %s doesn't have an image to promote yet", appName)
+	}
+
+	registry := cfg.ContainerRegistry
+	if registry == "" {
+		registry = to.Spec.BuildpackBuild.ContainerRegistry
+	}
+	if registry == "" {
+		return nil, fmt.Errorf("space %s doesn't have a container registry to promote the image to", to.Name)
+	}
+
+	// The image is always copied to a repository owned by the target space so
+	// garbage collection in the source space can't delete it. Layers already
+	// in the registry are mounted rather than uploaded again.
+	dst := JoinRepositoryImage(registry, AppImageName(to.Name, appName))
+	fmt.Fprintf(cfg.Output, "Copying image %s to %s\n", from.Status.Image, registry)
+
+	image, err := p.copyImage(from.Status.Image, dst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy image: %s", err)
+	}
+
+	promoted := NewPromotedApp(from, to.Name, image, cfg.CopyEnv, cfg.CopyScale)
+	resultingApp, err := p.appsClient.Upsert(to.Name, promoted, mergePromotedApps(cfg.CopyScale))
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote app: %s", err)
+	}
+
+	if err := p.appsClient.DeployLogs(
+		cfg.Output,
+		appName,
+		resultingApp.ResourceVersion,
+		to.Name,
+		resultingApp.Spec.Instances.Stopped,
+	); err != nil {
+		return nil, err
+	}
+
+	return resultingApp, nil
+}
+
+// NewPromotedApp creates an App in the namespace that deploys the image of
+// the given App. The runtime configuration of the App, such as its command,
+// ports and health check, is copied. Environment variables and scaling
+// bounds belong to the space so they're only copied if copyEnv and copyScale
+// are set. Routes are never copied.
+func NewPromotedApp(from *v1alpha1.App, namespace, image string, copyEnv, copyScale bool) *v1alpha1.App {
+	src := sources.NewKfSource()
+	src.SetContainerImageSource(image)
+
+	app := NewKfApp()
+	app.SetName(from.Name)
+	app.SetNamespace(namespace)
+	app.SetSource(src)
+	app.Spec.Template.Spec = *from.Spec.Template.Spec.DeepCopy()
+	app.Annotations = map[string]string{
+		v1alpha1.PromotedFromAnnotation:        fmt.Sprintf("%s/%s", from.Namespace, from.Name),
+		v1alpha1.PromotedImageAnnotation:       image,
+		v1alpha1.PromotedSourceImageAnnotation: from.Status.Image,
+	}
+
+	if !copyEnv && len(app.GetEnvVars()) > 0 {
+		app.SetEnvVars(nil)
+	}
+
+	if copyScale {
+		app.Spec.Instances = *from.Spec.Instances.DeepCopy()
+		app.Spec.Instances.Stopped = false
+	}
+
+	return app.ToApp()
+}
+
+// mergePromotedApps updates an existing App with a promoted one. The
+// existing App's routes and environment variables are kept, any environment
+// variables copied with the promoted App take precedence. Its scaling bounds
+// are only replaced if copyScale is set.
+func mergePromotedApps(copyScale bool) Merger {
+	return func(newapp, oldapp *v1alpha1.App) *v1alpha1.App {
+		merged := oldapp.DeepCopy()
+		merged.Spec.Source = newapp.Spec.Source
+		merged.Spec.Template.Spec = newapp.Spec.Template.Spec
+
+		env := envutil.DeduplicateEnvVars(append(envutil.GetAppEnvVars(oldapp), envutil.GetAppEnvVars(newapp)...))
+		if len(env) > 0 {
+			envutil.SetAppEnvVars(merged, env)
+		}
+
+		if copyScale {
+			merged.Spec.Instances = newapp.Spec.Instances
+			merged.Spec.Instances.Stopped = oldapp.Spec.Instances.Stopped
+		}
+
+		if merged.Annotations == nil {
+			merged.Annotations = make(map[string]string)
+		}
+		for k, v := range newapp.Annotations {
+			merged.Annotations[k] = v
+		}
+
+		return merged
+	}
+}
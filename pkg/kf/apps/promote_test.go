@@ -0,0 +1,225 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/internal/envutil"
+	"github.com/google/kf/pkg/kf/apps"
+	appsfake "github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPromoter_Promote(t *testing.T) {
+	t.Parallel()
+
+	three := 3
+	newFromApp := func() *v1alpha1.App {
+		app := apps.NewKfApp()
+		app.SetName("some-app")
+		app.SetNamespace("dev")
+		app.SetCommand([]string{"some-command"})
+		app.SetEnvVars([]corev1.EnvVar{{Name: "FOO", Value: "dev"}})
+		app.Spec.Instances.Exactly = &three
+		app.Spec.Routes = []v1alpha1.RouteSpecFields{{Hostname: "dev-host"}}
+		app.Status.Image = "dev-registry/app-dev-some-app:1"
+		return app.ToApp()
+	}
+
+	prod := &v1alpha1.Space{}
+	prod.Name = "prod"
+	prod.Spec.BuildpackBuild.ContainerRegistry = "prod-registry"
+
+	for tn, tc := range map[string]struct {
+		to        *v1alpha1.Space
+		opts      []apps.PromoteOption
+		fromApp   *v1alpha1.App
+		copyImage func(t *testing.T, src, dst string) (string, error)
+		setup     func(t *testing.T, fakeApps *appsfake.FakeClient)
+		wantErr   error
+	}{
+		"copies image to the target registry": {
+			to: prod,
+			copyImage: func(t *testing.T, src, dst string) (string, error) {
+				testutil.AssertEqual(t, "src", "dev-registry/app-dev-some-app:1", src)
+				testutil.AssertEqual(t, "dst", true, strings.HasPrefix(dst, "prod-registry/app-prod-some-app:"))
+				return "prod-registry/app-prod-some-app@sha256:abc", nil
+			},
+			setup: func(t *testing.T, fakeApps *appsfake.FakeClient) {
+				fakeApps.
+					EXPECT().
+					Upsert("prod", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ns string, app *v1alpha1.App, merge apps.Merger) (*v1alpha1.App, error) {
+						testutil.AssertEqual(t, "namespace", "prod", app.Namespace)
+						testutil.AssertEqual(t, "image", "prod-registry/app-prod-some-app@sha256:abc", app.Spec.Source.ContainerImage.Image)
+						testutil.AssertEqual(t, "promoted from", "dev/some-app", app.Annotations[v1alpha1.PromotedFromAnnotation])
+						testutil.AssertEqual(t, "promoted image", "prod-registry/app-prod-some-app@sha256:abc", app.Annotations[v1alpha1.PromotedImageAnnotation])
+						testutil.AssertEqual(t, "promoted source image", "dev-registry/app-dev-some-app:1", app.Annotations[v1alpha1.PromotedSourceImageAnnotation])
+						testutil.AssertEqual(t, "command", []string{"some-command"}, apps.NewFromApp(app).GetCommand())
+						testutil.AssertEqual(t, "env", 0, len(envutil.GetAppEnvVars(app)))
+						testutil.AssertEqual(t, "routes", 0, len(app.Spec.Routes))
+						testutil.AssertEqual(t, "instances", (*int)(nil), app.Spec.Instances.Exactly)
+						return app, nil
+					})
+
+				fakeApps.
+					EXPECT().
+					DeployLogs(gomock.Any(), "some-app", gomock.Any(), "prod", false)
+			},
+		},
+		"copies image within a shared registry": {
+			to:   prod,
+			opts: []apps.PromoteOption{apps.WithPromoteContainerRegistry("dev-registry")},
+			copyImage: func(t *testing.T, src, dst string) (string, error) {
+				testutil.AssertEqual(t, "dst", true, strings.HasPrefix(dst, "dev-registry/app-prod-some-app:"))
+				return "dev-registry/app-prod-some-app@sha256:abc", nil
+			},
+			setup: func(t *testing.T, fakeApps *appsfake.FakeClient) {
+				fakeApps.
+					EXPECT().
+					Upsert("prod", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ns string, app *v1alpha1.App, merge apps.Merger) (*v1alpha1.App, error) {
+						testutil.AssertEqual(t, "image", "dev-registry/app-prod-some-app@sha256:abc", app.Spec.Source.ContainerImage.Image)
+						return app, nil
+					})
+
+				fakeApps.EXPECT().DeployLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"copies env and scale": {
+			to:   prod,
+			opts: []apps.PromoteOption{apps.WithPromoteCopyEnv(true), apps.WithPromoteCopyScale(true)},
+			setup: func(t *testing.T, fakeApps *appsfake.FakeClient) {
+				fakeApps.
+					EXPECT().
+					Upsert("prod", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ns string, app *v1alpha1.App, merge apps.Merger) (*v1alpha1.App, error) {
+						testutil.AssertEqual(t, "env", []corev1.EnvVar{{Name: "FOO", Value: "dev"}}, envutil.GetAppEnvVars(app))
+						testutil.AssertEqual(t, "instances", &three, app.Spec.Instances.Exactly)
+						return app, nil
+					})
+
+				fakeApps.EXPECT().DeployLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"updates existing app": {
+			to:   prod,
+			opts: []apps.PromoteOption{apps.WithPromoteCopyEnv(true)},
+			setup: func(t *testing.T, fakeApps *appsfake.FakeClient) {
+				fakeApps.
+					EXPECT().
+					Upsert("prod", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ns string, app *v1alpha1.App, merge apps.Merger) (*v1alpha1.App, error) {
+						one := 1
+						existing := apps.NewKfApp()
+						existing.SetName("some-app")
+						existing.SetNamespace("prod")
+						existing.SetEnvVars([]corev1.EnvVar{{Name: "FOO", Value: "prod"}, {Name: "BAR", Value: "prod"}})
+						existing.Spec.Routes = []v1alpha1.RouteSpecFields{{Hostname: "prod-host"}}
+						existing.Spec.Instances.Exactly = &one
+						existing.Annotations = map[string]string{"some-annotation": "some-value"}
+
+						merged := merge(app, existing.ToApp())
+						testutil.AssertEqual(t, "image", "some-image@sha256:abc", merged.Spec.Source.ContainerImage.Image)
+						testutil.AssertEqual(t, "env", []corev1.EnvVar{{Name: "BAR", Value: "prod"}, {Name: "FOO", Value: "dev"}}, envutil.GetAppEnvVars(merged))
+						testutil.AssertEqual(t, "routes", existing.Spec.Routes, merged.Spec.Routes)
+						testutil.AssertEqual(t, "instances", &one, merged.Spec.Instances.Exactly)
+						testutil.AssertEqual(t, "annotation", "some-value", merged.Annotations["some-annotation"])
+						testutil.AssertEqual(t, "promoted from", "dev/some-app", merged.Annotations[v1alpha1.PromotedFromAnnotation])
+						return merged, nil
+					})
+
+				fakeApps.EXPECT().DeployLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"same space": {
+			to:      prod,
+			opts:    []apps.PromoteOption{apps.WithPromoteNamespace("prod")},
+			wantErr: errors.New("an app can't be promoted to the space it's in"),
+		},
+		"app without image": {
+			to: prod,
+			fromApp: func() *v1alpha1.App {
+				app := newFromApp()
+				app.Status.Image = ""
+				return app
+			}(),
+			wantErr: errors.New("app some-app doesn't have an image to promote yet"),
+		},
+		"space without registry": {
+			to: func() *v1alpha1.Space {
+				space := prod.DeepCopy()
+				space.Spec.BuildpackBuild.ContainerRegistry = ""
+				return space
+			}(),
+			wantErr: errors.New("space prod doesn't have a container registry to promote the image to"),
+		},
+		"copy fails": {
+			to: prod,
+			copyImage: func(t *testing.T, src, dst string) (string, error) {
+				return "", errors.New("some-error")
+			},
+			wantErr: errors.New("failed to copy image: some-error"),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fromApp := tc.fromApp
+			if fromApp == nil {
+				fromApp = newFromApp()
+			}
+
+			fakeApps := appsfake.NewFakeClient(ctrl)
+			fakeApps.
+				EXPECT().
+				Get("dev", "some-app").
+				Return(fromApp, nil).
+				AnyTimes()
+
+			if tc.setup != nil {
+				tc.setup(t, fakeApps)
+			}
+
+			copyImage := tc.copyImage
+			if copyImage == nil {
+				copyImage = func(t *testing.T, src, dst string) (string, error) {
+					return "some-image@sha256:abc", nil
+				}
+			}
+
+			buffer := &bytes.Buffer{}
+			p := apps.NewPromoter(fakeApps, func(src, dst string) (string, error) {
+				return copyImage(t, src, dst)
+			})
+
+			opts := append([]apps.PromoteOption{
+				apps.WithPromoteNamespace("dev"),
+				apps.WithPromoteOutput(buffer),
+			}, tc.opts...)
+			_, gotErr := p.Promote("some-app", tc.to, opts...)
+
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+		})
+	}
+}
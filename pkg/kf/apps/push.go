@@ -23,10 +23,23 @@ import (
 	"github.com/google/kf/pkg/kf/internal/kf"
 	"github.com/google/kf/pkg/kf/sources"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
 )
 
 //go:generate go run ../internal/tools/option-builder/option-builder.go push-options.yml push_options.go
 
+const (
+	// PushStrategyInPlace updates the running App directly.
+	PushStrategyInPlace = "in-place"
+
+	// PushStrategyBlueGreen keeps a copy of the running App serving traffic
+	// while the App is updated, and rolls back if the update fails.
+	PushStrategyBlueGreen = "blue-green"
+)
+
+// PushStrategies contains the valid values for the Strategy push option.
+var PushStrategies = []string{PushStrategyInPlace, PushStrategyBlueGreen}
+
 // pusher deploys source code to Knative. It should be created via NewPusher.
 type pusher struct {
 	appsClient Client
@@ -59,11 +72,21 @@ func newApp(appName string, opts ...PushOption) (*v1alpha1.App, error) {
 	}
 
 	src := sources.NewKfSource()
-	src.SetBuildpackBuildSource(cfg.SourceImage)
 	src.SetContainerImageSource(cfg.ContainerImage)
-	src.SetBuildpackBuildRegistry(cfg.ContainerRegistry)
-	src.SetBuildpackBuildEnv(envs)
-	src.SetBuildpackBuildBuildpack(cfg.Buildpack)
+	if cfg.Dockerfile != "" {
+		src.SetDockerfileSource(cfg.SourceImage)
+		src.SetDockerfileGit(cfg.Git)
+		src.SetDockerfilePath(cfg.Dockerfile)
+		src.SetDockerfileRegistry(cfg.ContainerRegistry)
+	} else {
+		src.SetBuildpackBuildSource(cfg.SourceImage)
+		src.SetBuildpackBuildGit(cfg.Git)
+		src.SetBuildpackBuildRegistry(cfg.ContainerRegistry)
+		src.SetBuildpackBuildEnv(envs)
+		src.SetBuildpackBuildBuildpack(cfg.Buildpack)
+		src.SetBuildpackBuildStack(cfg.Stack)
+	}
+	src.SetTimeout(cfg.BuildTimeout)
 
 	app := NewKfApp()
 	app.SetName(appName)
@@ -73,11 +96,30 @@ func newApp(appName string, opts ...PushOption) (*v1alpha1.App, error) {
 	app.Spec.Instances.Stopped = cfg.NoStart
 	app.SetHealthCheck(cfg.HealthCheck)
 	app.Spec.Routes = cfg.Routes
+	app.Spec.BuildCache = cfg.BuildCache
 
 	if cfg.Grpc {
 		app.SetContainerPorts([]corev1.ContainerPort{{Name: "h2c", ContainerPort: 8080}})
 	}
 
+	setInstances(&app.Spec.Instances, cfg.MinScale, cfg.MaxScale)
+
+	if cfg.Resources != nil {
+		app.SetResources(*cfg.Resources)
+	}
+
+	if cfg.Command != "" {
+		if cfg.ContainerImage != "" || cfg.Dockerfile != "" {
+			// Override the image's entrypoint so the command behaves the same
+			// way it does for buildpack apps.
+			app.SetCommand([]string{"/bin/sh", "-c", cfg.Command})
+		} else {
+			// The buildpack launcher runs a single argument through a shell
+			// after setting up the buildpack environment.
+			app.SetArgs([]string{cfg.Command})
+		}
+	}
+
 	if len(envs) > 0 {
 		app.SetEnvVars(envs)
 	}
@@ -85,6 +127,23 @@ func newApp(appName string, opts ...PushOption) (*v1alpha1.App, error) {
 	return app.ToApp(), nil
 }
 
+// setInstances sets the scaling bounds of the App. Equal bounds mean a static
+// number of instances and zero values are treated as unbound.
+func setInstances(instances *v1alpha1.AppSpecInstances, minScale, maxScale int) {
+	if minScale > 0 && minScale == maxScale {
+		instances.Exactly = &minScale
+		return
+	}
+
+	if minScale > 0 {
+		instances.Min = &minScale
+	}
+
+	if maxScale > 0 {
+		instances.Max = &maxScale
+	}
+}
+
 // Push deploys an application to Knative. It can be configured via
 // Optionapp.
 func (p *pusher) Push(appName string, opts ...PushOption) error {
@@ -95,31 +154,239 @@ func (p *pusher) Push(appName string, opts ...PushOption) error {
 		return fmt.Errorf("failed to create app: %s", err)
 	}
 
+	if cfg.RandomRoute && len(app.Spec.Routes) == 0 {
+		if app.Spec.Routes, err = p.randomRoutes(app); err != nil {
+			return err
+		}
+	}
+
+	if cfg.DryRun {
+		return p.dryRun(app, cfg)
+	}
+
+	switch cfg.Strategy {
+	case PushStrategyInPlace:
+		err = p.deploy(app, cfg)
+	case PushStrategyBlueGreen:
+		err = p.deployBlueGreen(app, cfg)
+	default:
+		return kf.ConfigErr{Reason: fmt.Sprintf("unknown push strategy %q", cfg.Strategy)}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	status := "deployed"
+	if cfg.NoStart {
+		status = "deployed without starting"
+	}
+
+	_, err = fmt.Fprintf(cfg.Output, "%q successfully %s\n", appName, status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// dryRun prints the differences between the live App and the App the push
+// would produce without changing anything.
+func (p *pusher) dryRun(app *v1alpha1.App, cfg pushConfig) error {
+	existing, err := p.getApp(app.Namespace, app.Name)
+	if err != nil {
+		return err
+	}
+
+	live := &v1alpha1.App{}
+	desired := app.DeepCopy()
+	if existing == nil {
+		fmt.Fprintf(cfg.Output, "App %q doesn't exist and would be created\n", app.Name)
+	} else {
+		live = existing
+		desired = mergeApps(desired, existing.DeepCopy())
+	}
+
+	FormatDiff(cfg.Output, "live", "desired", comparableApp(live), comparableApp(desired))
+	fmt.Fprintf(cfg.Output, "Dry run of %q finished, no changes were applied\n", app.Name)
+
+	return nil
+}
+
+// comparableApp strips the fields of an App that are managed by the server so
+// only the user's changes show up in diffs.
+func comparableApp(app *v1alpha1.App) *v1alpha1.App {
+	out := &v1alpha1.App{}
+	out.Name = app.Name
+	out.Namespace = app.Namespace
+	out.Spec = app.Spec
+	return out
+}
+
+// deploy updates the App in place and waits for it to become ready.
+func (p *pusher) deploy(app *v1alpha1.App, cfg pushConfig) error {
 	resultingApp, err := p.appsClient.Upsert(app.Namespace, app, mergeApps)
 	if err != nil {
 		return fmt.Errorf("failed to push app: %s", err)
 	}
 
-	if err := p.appsClient.DeployLogs(
+	return p.appsClient.DeployLogs(
 		cfg.Output,
-		appName,
+		app.Name,
 		resultingApp.ResourceVersion,
 		app.Namespace,
 		cfg.NoStart,
-	); err != nil {
+	)
+}
+
+// deployBlueGreen copies the running App to a venerable App bound to the same
+// routes, so traffic is still served by the old version while the App is
+// updated. If the new version fails to become ready the App is rolled back.
+// The venerable App is deleted once the rollout is finished.
+func (p *pusher) deployBlueGreen(app *v1alpha1.App, cfg pushConfig) error {
+	existing, err := p.getApp(app.Namespace, app.Name)
+	if err != nil {
 		return err
 	}
 
-	status := "deployed"
-	if cfg.NoStart {
-		status = "deployed without starting"
+	// There's no live version to protect.
+	if existing == nil {
+		return p.deploy(app, cfg)
 	}
 
-	_, err = fmt.Fprintf(cfg.Output, "%q successfully %s\n", appName, status)
+	venerable := newVenerableApp(existing)
+	fmt.Fprintf(cfg.Output, "Copying %q to %q to serve traffic during the push\n", existing.Name, venerable.Name)
+	venerableCfg := pushConfig{Output: cfg.Output, NoStart: venerable.Spec.Instances.Stopped}
+	if err := p.deploy(venerable, venerableCfg); err != nil {
+		return p.cleanupVenerable(venerable, fmt.Errorf("failed to copy app: %s", err))
+	}
+
+	if err := p.deploy(app, cfg); err != nil {
+		fmt.Fprintf(cfg.Output, "Push failed, rolling back %q\n", app.Name)
+		if rollbackErr := p.rollback(existing, cfg); rollbackErr != nil {
+			// Leave the venerable App serving traffic so the routes still
+			// work.
+			return fmt.Errorf("%s, failed to roll back: %s", err, rollbackErr)
+		}
+
+		return p.cleanupVenerable(venerable, fmt.Errorf("%s, rolled back to the previous version", err))
+	}
+
+	return p.cleanupVenerable(venerable, nil)
+}
+
+// randomRoutes keeps the routes of an existing App so re-pushing doesn't
+// generate a new hostname every time, otherwise it returns a route with a
+// random hostname on the space's default domain.
+func (p *pusher) randomRoutes(app *v1alpha1.App) ([]v1alpha1.RouteSpecFields, error) {
+	existing, err := p.getApp(app.Namespace, app.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil && len(existing.Spec.Routes) > 0 {
+		return existing.Spec.Routes, nil
+	}
+
+	return []v1alpha1.RouteSpecFields{{Hostname: RandomHostname(app.Name)}}, nil
+}
+
+// RandomHostname generates a hostname for an app that is unlikely to collide
+// with other hostnames.
+func RandomHostname(appName string) string {
+	return fmt.Sprintf("%s-%s", appName, rand.String(8))
+}
+
+// getApp returns the App with the given name or nil if it doesn't exist.
+func (p *pusher) getApp(namespace, name string) (*v1alpha1.App, error) {
+	existing, err := p.appsClient.List(
+		namespace,
+		WithListFieldSelector(map[string]string{"metadata.name": name}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app: %s", err)
+	}
+
+	// The field selector may be ignored so double check the name.
+	for _, app := range existing {
+		if app.Name == name {
+			return app.DeepCopy(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// rollback restores the spec of the previous version of the App. The image
+// it was running is deployed again rather than being rebuilt.
+func (p *pusher) rollback(previous *v1alpha1.App, cfg pushConfig) error {
+	current, err := p.appsClient.Get(previous.Namespace, previous.Name)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	toUpdate := current.DeepCopy()
+	toUpdate.Spec = runningSpec(previous)
+	updated, err := p.appsClient.Update(previous.Namespace, toUpdate)
+	if err != nil {
+		return err
+	}
+
+	return p.appsClient.DeployLogs(
+		cfg.Output,
+		previous.Name,
+		updated.ResourceVersion,
+		previous.Namespace,
+		previous.Spec.Instances.Stopped,
+	)
+}
+
+// cleanupVenerable deletes the venerable App, which unbinds it from its
+// routes, and returns the cause.
+func (p *pusher) cleanupVenerable(venerable *v1alpha1.App, cause error) error {
+	if err := p.appsClient.DeleteInForeground(venerable.Namespace, venerable.Name); err != nil {
+		if cause != nil {
+			return fmt.Errorf("%s, failed to delete %q: %s", cause, venerable.Name, err)
+		}
+
+		return fmt.Errorf("failed to delete %q: %s", venerable.Name, err)
+	}
+
+	return cause
+}
+
+// VenerableAppName gets the name of the App that holds the previous version
+// of an App during a blue-green push.
+func VenerableAppName(appName string) string {
+	return fmt.Sprintf("%s-venerable", appName)
+}
+
+// newVenerableApp creates a copy of the running App. The copy deploys the
+// image that was already built for the App so it doesn't need to be rebuilt,
+// and exposes the App's service bindings so it runs with the same services.
+func newVenerableApp(existing *v1alpha1.App) *v1alpha1.App {
+	venerable := NewKfApp()
+	venerable.SetName(VenerableAppName(existing.Name))
+	venerable.SetNamespace(existing.Namespace)
+	venerable.Annotations = map[string]string{
+		v1alpha1.ServiceBindingsAppAnnotation: existing.Name,
+	}
+
+	venerable.Spec = runningSpec(existing)
+
+	return venerable.ToApp()
+}
+
+// runningSpec copies the spec of the App with its source replaced by the
+// image the App is running, if it has one, so it can be deployed again
+// without being rebuilt. The build that produced the image is kept so the
+// App can still be restaged.
+func runningSpec(app *v1alpha1.App) v1alpha1.AppSpec {
+	spec := *app.Spec.DeepCopy()
+	if app.Status.Image != "" {
+		spec.Source = deployImageSource(app.Spec.Source, app.Status.Image)
+	}
+
+	return spec
 }
 
 func mergeApps(newapp, oldapp *v1alpha1.App) *v1alpha1.App {
@@ -21,17 +21,30 @@ import (
 	"io"
 	corev1 "k8s.io/api/core/v1"
 	"os"
+	"time"
 )
 
 type pushConfig struct {
+	// BuildCache is the persistent cache kept between buildpack builds
+	BuildCache *v1alpha1.AppSpecBuildCache
+	// BuildTimeout is the longest the build can run, the space's default is used if it's zero
+	BuildTimeout time.Duration
 	// Buildpack is skip the detect buildpack step and use the given name
 	Buildpack string
+	// Command is the command used to start the app, run in a shell
+	Command string
 	// ContainerImage is the container to deploy
 	ContainerImage string
 	// ContainerRegistry is the container registry's URL
 	ContainerRegistry string
+	// Dockerfile is the path of the Dockerfile used to build the source
+	Dockerfile string
+	// DryRun is print the changes to the app without applying them
+	DryRun bool
 	// EnvironmentVariables is set environment variables
 	EnvironmentVariables map[string]string
+	// Git is the Git repository to build the source from instead of SourceImage
+	Git *v1alpha1.SourceSpecGit
 	// Grpc is setup the ports for the container to allow gRPC to work
 	Grpc bool
 	// HealthCheck is the health check to use on the app
@@ -46,12 +59,20 @@ type pushConfig struct {
 	NoStart bool
 	// Output is the io.Writer to write output such as build logs
 	Output io.Writer
+	// RandomRoute is create a route with a random hostname if the app has no routes
+	RandomRoute bool
+	// Resources is the resource requests and limits of the app
+	Resources *corev1.ResourceRequirements
 	// Routes is routes for the app
 	Routes []v1alpha1.RouteSpecFields
 	// ServiceAccount is the service account to authenticate with
 	ServiceAccount string
 	// SourceImage is the source code as a container image
 	SourceImage string
+	// Stack is the base layer used for buildpack builds
+	Stack string
+	// Strategy is the strategy used to roll out the app, either in-place or blue-green
+	Strategy string
 }
 
 // PushOption is a single option for configuring a pushConfig
@@ -80,12 +101,30 @@ func (opts PushOptions) Extend(other PushOptions) PushOptions {
 	return out
 }
 
+// BuildCache returns the last set value for BuildCache or the empty value
+// if not set.
+func (opts PushOptions) BuildCache() *v1alpha1.AppSpecBuildCache {
+	return opts.toConfig().BuildCache
+}
+
+// BuildTimeout returns the last set value for BuildTimeout or the empty value
+// if not set.
+func (opts PushOptions) BuildTimeout() time.Duration {
+	return opts.toConfig().BuildTimeout
+}
+
 // Buildpack returns the last set value for Buildpack or the empty value
 // if not set.
 func (opts PushOptions) Buildpack() string {
 	return opts.toConfig().Buildpack
 }
 
+// Command returns the last set value for Command or the empty value
+// if not set.
+func (opts PushOptions) Command() string {
+	return opts.toConfig().Command
+}
+
 // ContainerImage returns the last set value for ContainerImage or the empty value
 // if not set.
 func (opts PushOptions) ContainerImage() string {
@@ -98,12 +137,30 @@ func (opts PushOptions) ContainerRegistry() string {
 	return opts.toConfig().ContainerRegistry
 }
 
+// Dockerfile returns the last set value for Dockerfile or the empty value
+// if not set.
+func (opts PushOptions) Dockerfile() string {
+	return opts.toConfig().Dockerfile
+}
+
+// DryRun returns the last set value for DryRun or the empty value
+// if not set.
+func (opts PushOptions) DryRun() bool {
+	return opts.toConfig().DryRun
+}
+
 // EnvironmentVariables returns the last set value for EnvironmentVariables or the empty value
 // if not set.
 func (opts PushOptions) EnvironmentVariables() map[string]string {
 	return opts.toConfig().EnvironmentVariables
 }
 
+// Git returns the last set value for Git or the empty value
+// if not set.
+func (opts PushOptions) Git() *v1alpha1.SourceSpecGit {
+	return opts.toConfig().Git
+}
+
 // Grpc returns the last set value for Grpc or the empty value
 // if not set.
 func (opts PushOptions) Grpc() bool {
@@ -146,6 +203,18 @@ func (opts PushOptions) Output() io.Writer {
 	return opts.toConfig().Output
 }
 
+// RandomRoute returns the last set value for RandomRoute or the empty value
+// if not set.
+func (opts PushOptions) RandomRoute() bool {
+	return opts.toConfig().RandomRoute
+}
+
+// Resources returns the last set value for Resources or the empty value
+// if not set.
+func (opts PushOptions) Resources() *corev1.ResourceRequirements {
+	return opts.toConfig().Resources
+}
+
 // Routes returns the last set value for Routes or the empty value
 // if not set.
 func (opts PushOptions) Routes() []v1alpha1.RouteSpecFields {
@@ -164,6 +233,32 @@ func (opts PushOptions) SourceImage() string {
 	return opts.toConfig().SourceImage
 }
 
+// Stack returns the last set value for Stack or the empty value
+// if not set.
+func (opts PushOptions) Stack() string {
+	return opts.toConfig().Stack
+}
+
+// Strategy returns the last set value for Strategy or the empty value
+// if not set.
+func (opts PushOptions) Strategy() string {
+	return opts.toConfig().Strategy
+}
+
+// WithPushBuildCache creates an Option that sets the persistent cache kept between buildpack builds
+func WithPushBuildCache(val *v1alpha1.AppSpecBuildCache) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.BuildCache = val
+	}
+}
+
+// WithPushBuildTimeout creates an Option that sets the longest the build can run, the space's default is used if it's zero
+func WithPushBuildTimeout(val time.Duration) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.BuildTimeout = val
+	}
+}
+
 // WithPushBuildpack creates an Option that sets skip the detect buildpack step and use the given name
 func WithPushBuildpack(val string) PushOption {
 	return func(cfg *pushConfig) {
@@ -171,6 +266,13 @@ func WithPushBuildpack(val string) PushOption {
 	}
 }
 
+// WithPushCommand creates an Option that sets the command used to start the app, run in a shell
+func WithPushCommand(val string) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Command = val
+	}
+}
+
 // WithPushContainerImage creates an Option that sets the container to deploy
 func WithPushContainerImage(val string) PushOption {
 	return func(cfg *pushConfig) {
@@ -185,6 +287,20 @@ func WithPushContainerRegistry(val string) PushOption {
 	}
 }
 
+// WithPushDockerfile creates an Option that sets the path of the Dockerfile used to build the source
+func WithPushDockerfile(val string) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Dockerfile = val
+	}
+}
+
+// WithPushDryRun creates an Option that sets print the changes to the app without applying them
+func WithPushDryRun(val bool) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.DryRun = val
+	}
+}
+
 // WithPushEnvironmentVariables creates an Option that sets set environment variables
 func WithPushEnvironmentVariables(val map[string]string) PushOption {
 	return func(cfg *pushConfig) {
@@ -192,6 +308,13 @@ func WithPushEnvironmentVariables(val map[string]string) PushOption {
 	}
 }
 
+// WithPushGit creates an Option that sets the Git repository to build the source from instead of SourceImage
+func WithPushGit(val *v1alpha1.SourceSpecGit) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Git = val
+	}
+}
+
 // WithPushGrpc creates an Option that sets setup the ports for the container to allow gRPC to work
 func WithPushGrpc(val bool) PushOption {
 	return func(cfg *pushConfig) {
@@ -241,6 +364,20 @@ func WithPushOutput(val io.Writer) PushOption {
 	}
 }
 
+// WithPushRandomRoute creates an Option that sets create a route with a random hostname if the app has no routes
+func WithPushRandomRoute(val bool) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.RandomRoute = val
+	}
+}
+
+// WithPushResources creates an Option that sets the resource requests and limits of the app
+func WithPushResources(val *corev1.ResourceRequirements) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Resources = val
+	}
+}
+
 // WithPushRoutes creates an Option that sets routes for the app
 func WithPushRoutes(val []v1alpha1.RouteSpecFields) PushOption {
 	return func(cfg *pushConfig) {
@@ -262,11 +399,26 @@ func WithPushSourceImage(val string) PushOption {
 	}
 }
 
+// WithPushStack creates an Option that sets the base layer used for buildpack builds
+func WithPushStack(val string) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Stack = val
+	}
+}
+
+// WithPushStrategy creates an Option that sets the strategy used to roll out the app, either in-place or blue-green
+func WithPushStrategy(val string) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Strategy = val
+	}
+}
+
 // PushOptionDefaults gets the default values for Push.
 func PushOptionDefaults() PushOptions {
 	return PushOptions{
 		WithPushNamespace("default"),
 		WithPushOutput(os.Stdout),
+		WithPushStrategy(PushStrategyInPlace),
 	}
 }
 
@@ -320,3 +472,115 @@ func DeployOptionDefaults() DeployOptions {
 		WithDeployNamespace("default"),
 	}
 }
+
+type promoteConfig struct {
+	// ContainerRegistry is the container registry to copy the image to, the target space's registry is used if it's empty
+	ContainerRegistry string
+	// CopyEnv is copy the environment variables of the app to the target app
+	CopyEnv bool
+	// CopyScale is copy the scaling bounds of the app to the target app
+	CopyScale bool
+	// Namespace is the Kubernetes namespace to use
+	Namespace string
+	// Output is the io.Writer to write output such as deploy logs
+	Output io.Writer
+}
+
+// PromoteOption is a single option for configuring a promoteConfig
+type PromoteOption func(*promoteConfig)
+
+// PromoteOptions is a configuration set defining a promoteConfig
+type PromoteOptions []PromoteOption
+
+// toConfig applies all the options to a new promoteConfig and returns it.
+func (opts PromoteOptions) toConfig() promoteConfig {
+	cfg := promoteConfig{}
+
+	for _, v := range opts {
+		v(&cfg)
+	}
+
+	return cfg
+}
+
+// Extend creates a new PromoteOptions with the contents of other overriding
+// the values set in this PromoteOptions.
+func (opts PromoteOptions) Extend(other PromoteOptions) PromoteOptions {
+	var out PromoteOptions
+	out = append(out, opts...)
+	out = append(out, other...)
+	return out
+}
+
+// ContainerRegistry returns the last set value for ContainerRegistry or the empty value
+// if not set.
+func (opts PromoteOptions) ContainerRegistry() string {
+	return opts.toConfig().ContainerRegistry
+}
+
+// CopyEnv returns the last set value for CopyEnv or the empty value
+// if not set.
+func (opts PromoteOptions) CopyEnv() bool {
+	return opts.toConfig().CopyEnv
+}
+
+// CopyScale returns the last set value for CopyScale or the empty value
+// if not set.
+func (opts PromoteOptions) CopyScale() bool {
+	return opts.toConfig().CopyScale
+}
+
+// Namespace returns the last set value for Namespace or the empty value
+// if not set.
+func (opts PromoteOptions) Namespace() string {
+	return opts.toConfig().Namespace
+}
+
+// Output returns the last set value for Output or the empty value
+// if not set.
+func (opts PromoteOptions) Output() io.Writer {
+	return opts.toConfig().Output
+}
+
+// WithPromoteContainerRegistry creates an Option that sets the container registry to copy the image to, the target space's registry is used if it's empty
+func WithPromoteContainerRegistry(val string) PromoteOption {
+	return func(cfg *promoteConfig) {
+		cfg.ContainerRegistry = val
+	}
+}
+
+// WithPromoteCopyEnv creates an Option that sets copy the environment variables of the app to the target app
+func WithPromoteCopyEnv(val bool) PromoteOption {
+	return func(cfg *promoteConfig) {
+		cfg.CopyEnv = val
+	}
+}
+
+// WithPromoteCopyScale creates an Option that sets copy the scaling bounds of the app to the target app
+func WithPromoteCopyScale(val bool) PromoteOption {
+	return func(cfg *promoteConfig) {
+		cfg.CopyScale = val
+	}
+}
+
+// WithPromoteNamespace creates an Option that sets the Kubernetes namespace to use
+func WithPromoteNamespace(val string) PromoteOption {
+	return func(cfg *promoteConfig) {
+		cfg.Namespace = val
+	}
+}
+
+// WithPromoteOutput creates an Option that sets the io.Writer to write output such as deploy logs
+func WithPromoteOutput(val io.Writer) PromoteOption {
+	return func(cfg *promoteConfig) {
+		cfg.Output = val
+	}
+}
+
+// PromoteOptionDefaults gets the default values for Promote.
+func PromoteOptionDefaults() PromoteOptions {
+	return PromoteOptions{
+		WithPromoteNamespace("default"),
+		WithPromoteOutput(os.Stdout),
+	}
+}
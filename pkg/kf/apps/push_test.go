@@ -15,16 +15,20 @@
 package apps_test
 
 import (
+	"bytes"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/internal/envutil"
 	"github.com/google/kf/pkg/kf/apps"
 	appsfake "github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/sources"
 	"github.com/google/kf/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -195,6 +199,41 @@ func TestPush(t *testing.T) {
 					}).Return(&v1alpha1.App{}, nil)
 			},
 		},
+		"properly configures dockerfile source": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushSourceImage("some-image"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushDockerfile("docker/Dockerfile"),
+				apps.WithPushCommand("./start.sh"),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "image", "some-image", newObj.Spec.Source.Dockerfile.Source)
+						testutil.AssertEqual(t, "dockerfile", "docker/Dockerfile", newObj.Spec.Source.Dockerfile.Path)
+						testutil.AssertEqual(t, "registry", "some-reg.io", newObj.Spec.Source.Dockerfile.Registry)
+						testutil.AssertEqual(t, "buildpack source", "", newObj.Spec.Source.BuildpackBuild.Source)
+						testutil.AssertEqual(t, "command", []string{"/bin/sh", "-c", "./start.sh"}, newObj.Spec.Template.Spec.Containers[0].Command)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"properly configures git source": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushGit(&v1alpha1.SourceSpecGit{URL: "https://github.com/google/kf", Revision: "abc123"}),
+				apps.WithPushContainerRegistry("some-reg.io"),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "git", &v1alpha1.SourceSpecGit{URL: "https://github.com/google/kf", Revision: "abc123"}, newObj.Spec.Source.BuildpackBuild.Git)
+						testutil.AssertEqual(t, "image", "", newObj.Spec.Source.BuildpackBuild.Source)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
 		"pushes app with environment variables": {
 			appName:   "some-app",
 			buildpack: "some-buildpack",
@@ -290,6 +329,125 @@ func TestPush(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"exact instances": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushMinScale(3),
+				apps.WithPushMaxScale(3),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) {
+						three := 3
+						testutil.AssertEqual(t, "instances", v1alpha1.AppSpecInstances{Exactly: &three}, newObj.Spec.Instances)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"autoscaling bounds": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushMinScale(0),
+				apps.WithPushMaxScale(5),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) {
+						five := 5
+						testutil.AssertEqual(t, "instances", v1alpha1.AppSpecInstances{Max: &five}, newObj.Spec.Instances)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"resources": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushResources(&corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				}),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) {
+						mem := apps.NewFromApp(newObj).GetResources().Limits[corev1.ResourceMemory]
+						testutil.AssertEqual(t, "memory limit", "1Gi", mem.String())
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"buildpack app command": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushSourceImage("some-image"),
+				apps.WithPushCommand("bundle exec rackup"),
+				apps.WithPushStack("cflinuxfs3"),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) {
+						ka := apps.NewFromApp(newObj)
+						testutil.AssertEqual(t, "command", []string(nil), ka.GetCommand())
+						testutil.AssertEqual(t, "args", []string{"bundle exec rackup"}, ka.GetArgs())
+						testutil.AssertEqual(t, "stack", "cflinuxfs3", newObj.Spec.Source.BuildpackBuild.Stack)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"build cache": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushSourceImage("some-image"),
+				apps.WithPushBuildCache(&v1alpha1.AppSpecBuildCache{
+					Size: resource.MustParse("2Gi"),
+					Skip: true,
+				}),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "build cache", &v1alpha1.AppSpecBuildCache{
+							Size: resource.MustParse("2Gi"),
+							Skip: true,
+						}, newObj.Spec.BuildCache)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"build timeout": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushSourceImage("some-image"),
+				apps.WithPushBuildTimeout(time.Hour),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "timeout", &metav1.Duration{Duration: time.Hour}, newObj.Spec.Source.Timeout)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"container image command": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushCommand("./start.sh"),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) {
+						ka := apps.NewFromApp(newObj)
+						testutil.AssertEqual(t, "command", []string{"/bin/sh", "-c", "./start.sh"}, ka.GetCommand())
+						testutil.AssertEqual(t, "args", []string(nil), ka.GetArgs())
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
 		"NoStart sets stopped": {
 			appName:   "some-app",
 			srcImage:  "some-image",
@@ -339,3 +497,278 @@ func TestPush(t *testing.T) {
 		})
 	}
 }
+
+func TestPush_BlueGreen(t *testing.T) {
+	t.Parallel()
+
+	existingApp := func() *v1alpha1.App {
+		app := &v1alpha1.App{}
+		app.Name = "some-app"
+		app.Namespace = "some-namespace"
+		app.Spec.Routes = []v1alpha1.RouteSpecFields{{Hostname: "some-host", Domain: "example.com"}}
+		app.Spec.Source.BuildpackBuild.Source = "some-source-image"
+		app.Status.Image = "some-built-image"
+		return app
+	}
+
+	for tn, tc := range map[string]struct {
+		setup   func(t *testing.T, appsClient *appsfake.FakeClient)
+		wantErr error
+	}{
+		"app doesn't exist yet": {
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().List("some-namespace", gomock.Any()).Return(nil, nil)
+				appsClient.EXPECT().
+					Upsert("some-namespace", gomock.Any(), gomock.Any()).
+					Return(&v1alpha1.App{}, nil)
+				appsClient.EXPECT().DeployLogs(gomock.Any(), "some-app", gomock.Any(), "some-namespace", false)
+			},
+		},
+		"listing apps fails": {
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("some-error"))
+			},
+			wantErr: errors.New("failed to get app: some-error"),
+		},
+		"venerable app serves traffic during the push": {
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().List("some-namespace", gomock.Any()).Return([]v1alpha1.App{*existingApp()}, nil)
+				gomock.InOrder(
+					appsClient.EXPECT().
+						Upsert("some-namespace", gomock.Any(), gomock.Any()).
+						DoAndReturn(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) (*v1alpha1.App, error) {
+							testutil.AssertEqual(t, "name", "some-app-venerable", newObj.Name)
+							testutil.AssertEqual(t, "routes", existingApp().Spec.Routes, newObj.Spec.Routes)
+							testutil.AssertEqual(t, "image", "some-built-image", newObj.Spec.Source.ContainerImage.Image)
+							testutil.AssertEqual(t, "original build", &existingApp().Spec.Source.BuildpackBuild, newObj.Spec.Source.ContainerImage.BuildpackBuild)
+							testutil.AssertEqual(t, "bindings app", "some-app", newObj.Annotations[v1alpha1.ServiceBindingsAppAnnotation])
+							return &v1alpha1.App{}, nil
+						}),
+					appsClient.EXPECT().DeployLogs(gomock.Any(), "some-app-venerable", gomock.Any(), "some-namespace", false),
+					appsClient.EXPECT().
+						Upsert("some-namespace", gomock.Any(), gomock.Any()).
+						DoAndReturn(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) (*v1alpha1.App, error) {
+							testutil.AssertEqual(t, "name", "some-app", newObj.Name)
+							return &v1alpha1.App{}, nil
+						}),
+					appsClient.EXPECT().DeployLogs(gomock.Any(), "some-app", gomock.Any(), "some-namespace", false),
+					appsClient.EXPECT().DeleteInForeground("some-namespace", "some-app-venerable"),
+				)
+			},
+		},
+		"stopped app stays stopped": {
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				stopped := existingApp()
+				stopped.Spec.Instances.Stopped = true
+				appsClient.EXPECT().List("some-namespace", gomock.Any()).Return([]v1alpha1.App{*stopped}, nil)
+				gomock.InOrder(
+					appsClient.EXPECT().
+						Upsert("some-namespace", gomock.Any(), gomock.Any()).
+						DoAndReturn(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) (*v1alpha1.App, error) {
+							testutil.AssertEqual(t, "stopped", true, newObj.Spec.Instances.Stopped)
+							return &v1alpha1.App{}, nil
+						}),
+					appsClient.EXPECT().DeployLogs(gomock.Any(), "some-app-venerable", gomock.Any(), "some-namespace", true),
+					appsClient.EXPECT().Upsert("some-namespace", gomock.Any(), gomock.Any()).Return(&v1alpha1.App{}, nil),
+					appsClient.EXPECT().DeployLogs(gomock.Any(), "some-app", gomock.Any(), "some-namespace", false),
+					appsClient.EXPECT().DeleteInForeground("some-namespace", "some-app-venerable"),
+				)
+			},
+		},
+		"venerable app fails to deploy": {
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().List("some-namespace", gomock.Any()).Return([]v1alpha1.App{*existingApp()}, nil)
+				appsClient.EXPECT().Upsert("some-namespace", gomock.Any(), gomock.Any()).Return(&v1alpha1.App{}, nil)
+				appsClient.EXPECT().
+					DeployLogs(gomock.Any(), "some-app-venerable", gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("deployment failed: some-error"))
+				appsClient.EXPECT().DeleteInForeground("some-namespace", "some-app-venerable")
+			},
+			wantErr: errors.New("failed to copy app: deployment failed: some-error"),
+		},
+		"new version fails and gets rolled back": {
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().List("some-namespace", gomock.Any()).Return([]v1alpha1.App{*existingApp()}, nil)
+				appsClient.EXPECT().Upsert("some-namespace", gomock.Any(), gomock.Any()).Return(&v1alpha1.App{}, nil).Times(2)
+				appsClient.EXPECT().DeployLogs(gomock.Any(), "some-app-venerable", gomock.Any(), gomock.Any(), gomock.Any())
+				gomock.InOrder(
+					appsClient.EXPECT().
+						DeployLogs(gomock.Any(), "some-app", gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(errors.New("deployment failed: some-error")),
+					appsClient.EXPECT().Get("some-namespace", "some-app").Return(&v1alpha1.App{}, nil),
+					appsClient.EXPECT().
+						Update("some-namespace", gomock.Any()).
+						DoAndReturn(func(namespace string, obj *v1alpha1.App, opts ...apps.UpdateOption) (*v1alpha1.App, error) {
+							testutil.AssertEqual(t, "routes", existingApp().Spec.Routes, obj.Spec.Routes)
+							testutil.AssertEqual(t, "image", "some-built-image", obj.Spec.Source.ContainerImage.Image)
+							testutil.AssertEqual(t, "original build", &existingApp().Spec.Source.BuildpackBuild, obj.Spec.Source.ContainerImage.BuildpackBuild)
+							obj.ResourceVersion = "rollback-version"
+							return obj, nil
+						}),
+					appsClient.EXPECT().DeployLogs(gomock.Any(), "some-app", "rollback-version", "some-namespace", false),
+					appsClient.EXPECT().DeleteInForeground("some-namespace", "some-app-venerable"),
+				)
+			},
+			wantErr: errors.New("deployment failed: some-error, rolled back to the previous version"),
+		},
+		"rollback fails": {
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().List("some-namespace", gomock.Any()).Return([]v1alpha1.App{*existingApp()}, nil)
+				appsClient.EXPECT().Upsert("some-namespace", gomock.Any(), gomock.Any()).Return(&v1alpha1.App{}, nil).Times(2)
+				appsClient.EXPECT().DeployLogs(gomock.Any(), "some-app-venerable", gomock.Any(), gomock.Any(), gomock.Any())
+				appsClient.EXPECT().
+					DeployLogs(gomock.Any(), "some-app", gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("deployment failed: some-error"))
+				appsClient.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("some-get-error"))
+			},
+			wantErr: errors.New("deployment failed: some-error, failed to roll back: some-get-error"),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeApps := appsfake.NewFakeClient(ctrl)
+			tc.setup(t, fakeApps)
+
+			p := apps.NewPusher(fakeApps)
+			gotErr := p.Push(
+				"some-app",
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("some-new-image"),
+				apps.WithPushStrategy(apps.PushStrategyBlueGreen),
+				apps.WithPushOutput(&bytes.Buffer{}),
+			)
+
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			ctrl.Finish()
+		})
+	}
+}
+
+func TestPush_RandomRoute(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		existing   []v1alpha1.App
+		opts       apps.PushOptions
+		wantRoutes func(t *testing.T, routes []v1alpha1.RouteSpecFields)
+	}{
+		"new app gets a random hostname": {
+			wantRoutes: func(t *testing.T, routes []v1alpha1.RouteSpecFields) {
+				testutil.AssertEqual(t, "routes count", 1, len(routes))
+				testutil.AssertEqual(t, "domain", "", routes[0].Domain)
+				testutil.AssertContainsAll(t, routes[0].Hostname, []string{"some-app-"})
+			},
+		},
+		"existing routes are kept": {
+			existing: []v1alpha1.App{{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-app"},
+				Spec: v1alpha1.AppSpec{
+					Routes: []v1alpha1.RouteSpecFields{{Hostname: "some-app-abc"}},
+				},
+			}},
+			wantRoutes: func(t *testing.T, routes []v1alpha1.RouteSpecFields) {
+				testutil.AssertEqual(t, "routes", []v1alpha1.RouteSpecFields{{Hostname: "some-app-abc"}}, routes)
+			},
+		},
+		"explicit routes take priority": {
+			opts: apps.PushOptions{
+				apps.WithPushRoutes([]v1alpha1.RouteSpecFields{{Hostname: "explicit"}}),
+			},
+			wantRoutes: func(t *testing.T, routes []v1alpha1.RouteSpecFields) {
+				testutil.AssertEqual(t, "routes", []v1alpha1.RouteSpecFields{{Hostname: "explicit"}}, routes)
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeApps := appsfake.NewFakeClient(ctrl)
+			fakeApps.EXPECT().List(gomock.Any(), gomock.Any()).Return(tc.existing, nil).AnyTimes()
+			fakeApps.EXPECT().
+				Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+				Do(func(namespace string, newObj *v1alpha1.App, merge apps.Merger) {
+					tc.wantRoutes(t, newObj.Spec.Routes)
+				}).
+				Return(&v1alpha1.App{}, nil)
+			fakeApps.EXPECT().DeployLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+			opts := append(apps.PushOptions{
+				apps.WithPushRandomRoute(true),
+				apps.WithPushOutput(&bytes.Buffer{}),
+			}, tc.opts...)
+
+			p := apps.NewPusher(fakeApps)
+			testutil.AssertNil(t, "push err", p.Push("some-app", opts...))
+		})
+	}
+}
+
+func TestPush_DryRun(t *testing.T) {
+	t.Parallel()
+
+	existing := apps.NewKfApp()
+	existing.SetName("some-app")
+	existing.SetNamespace("some-namespace")
+	existing.ResourceVersion = "some-version"
+	existingSource := sources.NewKfSource()
+	existingSource.SetContainerImageSource("gcr.io/old-image")
+	existing.SetSource(existingSource)
+
+	for tn, tc := range map[string]struct {
+		existing   []v1alpha1.App
+		wantOutput []string
+	}{
+		"new app": {
+			wantOutput: []string{
+				`App "some-app" doesn't exist and would be created`,
+				"App Diff (-live +desired):",
+				"gcr.io/new-image",
+				`Dry run of "some-app" finished, no changes were applied`,
+			},
+		},
+		"existing app": {
+			existing: []v1alpha1.App{*existing.ToApp()},
+			wantOutput: []string{
+				"App Diff (-live +desired):",
+				"gcr.io/old-image",
+				"gcr.io/new-image",
+				`Dry run of "some-app" finished, no changes were applied`,
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Only reads are expected, anything that mutates the App fails
+			// the test.
+			fakeApps := appsfake.NewFakeClient(ctrl)
+			fakeApps.EXPECT().List(gomock.Any(), gomock.Any()).Return(tc.existing, nil)
+
+			buffer := &bytes.Buffer{}
+			p := apps.NewPusher(fakeApps)
+			err := p.Push("some-app",
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/new-image"),
+				apps.WithPushDryRun(true),
+				apps.WithPushOutput(buffer),
+			)
+
+			testutil.AssertNil(t, "push err", err)
+			testutil.AssertContainsAll(t, buffer.String(), tc.wantOutput)
+		})
+	}
+}
+
+func TestPush_UnknownStrategy(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := apps.NewPusher(appsfake.NewFakeClient(ctrl))
+	gotErr := p.Push("some-app", apps.WithPushStrategy("some-strategy"))
+
+	testutil.AssertErrorsEqual(t, errors.New(`unknown push strategy "some-strategy"`), gotErr)
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+
+	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/sources"
+)
+
+// StageComponent is the component label of Sources created by a Stager.
+// They're kept apart from the Sources of the App so the App's controller
+// doesn't deploy them.
+const StageComponent = v1alpha1.StageComponent
+
+// Stager builds applications without deploying them.
+type Stager interface {
+	// Stage builds the source of an application as it would be built by
+	// Push and waits for the build to finish. The running App isn't changed.
+	// The space supplies the build defaults.
+	Stage(appName string, space *v1alpha1.Space, opts ...PushOption) (*v1alpha1.Source, error)
+}
+
+// stager creates standalone Sources. It should be created via NewStager.
+type stager struct {
+	sourcesClient sources.Client
+}
+
+// NewStager creates a new Stager.
+func NewStager(sourcesClient sources.Client) Stager {
+	return &stager{
+		sourcesClient: sourcesClient,
+	}
+}
+
+// Stage implements Stager.
+func (s *stager) Stage(appName string, space *v1alpha1.Space, opts ...PushOption) (*v1alpha1.Source, error) {
+	cfg := PushOptionDefaults().Extend(opts).toConfig()
+
+	app, err := newApp(appName, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create app: %s", err)
+	}
+
+	if app.Spec.Source.IsContainerBuild() {
+		return nil, fmt.Errorf("app %s is deployed from a container image and can't be staged", appName)
+	}
+
+	source, err := s.sourcesClient.Create(cfg.Namespace, NewStagedSource(app, space))
+	if err != nil {
+		return nil, fmt.Errorf("failed to stage app: %s", err)
+	}
+
+	fmt.Fprintf(cfg.Output, "Staging app %s as %s\n", appName, source.Name)
+
+	return s.sourcesClient.WaitForBuild(cfg.Output, source.Namespace, source.Name)
+}
+
+// NewStagedSource creates a Source that builds the App's source with the
+// space's defaults. Unlike the Sources created by the App's controller it
+// doesn't use the App's build cache. The App's controller adopts it once the
+// App exists so it's garbage collected with the App's other Sources and
+// deleted with the App.
+func NewStagedSource(app *v1alpha1.App, space *v1alpha1.Space) *v1alpha1.Source {
+	source := &v1alpha1.Source{}
+	source.GenerateName = fmt.Sprintf("%s-stage-", app.Name)
+	source.Namespace = app.Namespace
+	source.Labels = app.ComponentLabels(StageComponent)
+	source.Spec = *app.Spec.Source.DeepCopy()
+	source.Spec.SetSpaceDefaults(space)
+
+	return source
+}
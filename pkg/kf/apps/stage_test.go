@@ -0,0 +1,117 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/sources/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestStager_Stage(t *testing.T) {
+	t.Parallel()
+
+	space := &v1alpha1.Space{}
+	space.Spec.BuildpackBuild.BuilderImage = "some-builder"
+	space.Spec.BuildpackBuild.ContainerRegistry = "some-registry"
+
+	for tn, tc := range map[string]struct {
+		opts    []apps.PushOption
+		setup   func(t *testing.T, fakeSources *fake.FakeClient)
+		wantErr error
+	}{
+		"creates and waits for source": {
+			opts: []apps.PushOption{
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushSourceImage("some-source-image"),
+				apps.WithPushBuildpack("some-buildpack"),
+			},
+			setup: func(t *testing.T, fakeSources *fake.FakeClient) {
+				fakeSources.
+					EXPECT().
+					Create("some-namespace", gomock.Any()).
+					DoAndReturn(func(ns string, source *v1alpha1.Source) (*v1alpha1.Source, error) {
+						testutil.AssertEqual(t, "generate name", "some-app-stage-", source.GenerateName)
+						testutil.AssertEqual(t, "component", apps.StageComponent, source.Labels[v1alpha1.ComponentLabel])
+						testutil.AssertEqual(t, "app name", "some-app", source.Labels[v1alpha1.NameLabel])
+						testutil.AssertEqual(t, "owners", 0, len(source.OwnerReferences))
+						testutil.AssertEqual(t, "source image", "some-source-image", source.Spec.BuildpackBuild.Source)
+						testutil.AssertEqual(t, "buildpack", "some-buildpack", source.Spec.BuildpackBuild.Buildpack)
+						testutil.AssertEqual(t, "builder", "some-builder", source.Spec.BuildpackBuild.BuildpackBuilder)
+
+						created := source.DeepCopy()
+						created.Name = "some-app-stage-abc"
+						return created, nil
+					})
+
+				fakeSources.
+					EXPECT().
+					WaitForBuild(gomock.Any(), "some-namespace", "some-app-stage-abc").
+					DoAndReturn(func(out io.Writer, ns, name string) (*v1alpha1.Source, error) {
+						source := &v1alpha1.Source{}
+						source.Name = name
+						source.Status.Image = "some-image"
+						return source, nil
+					})
+			},
+		},
+		"build fails": {
+			opts: []apps.PushOption{
+				apps.WithPushSourceImage("some-source-image"),
+			},
+			setup: func(t *testing.T, fakeSources *fake.FakeClient) {
+				fakeSources.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&v1alpha1.Source{}, nil)
+
+				fakeSources.
+					EXPECT().
+					WaitForBuild(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("build failed"))
+			},
+			wantErr: errors.New("build failed"),
+		},
+		"container image": {
+			opts: []apps.PushOption{
+				apps.WithPushContainerImage("some-image"),
+			},
+			wantErr: errors.New("app some-app is deployed from a container image and can't be staged"),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeSources := fake.NewFakeClient(ctrl)
+			if tc.setup != nil {
+				tc.setup(t, fakeSources)
+			}
+
+			buffer := &bytes.Buffer{}
+			s := apps.NewStager(fakeSources)
+			_, gotErr := s.Stage("some-app", space, append(tc.opts, apps.WithPushOutput(buffer))...)
+
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+		})
+	}
+}
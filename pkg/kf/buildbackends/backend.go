@@ -0,0 +1,99 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildbackends
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+)
+
+// ErrNotOwned is returned when a build with the name a Source would use
+// exists but isn't controlled by the Source.
+var ErrNotOwned = errors.New("the build isn't owned by the Source")
+
+// TemplateError is returned when a backend can't make a build for the
+// Source, such as a build the backend doesn't support. Retrying won't help
+// until the Source changes.
+type TemplateError struct {
+	Err error
+}
+
+// Error implements error.
+func (e *TemplateError) Error() string {
+	return e.Err.Error()
+}
+
+// IsTemplateError returns true if the error is a TemplateError.
+func IsTemplateError(err error) bool {
+	_, ok := err.(*TemplateError)
+	return ok
+}
+
+// Backend runs the builds that turn Sources into container images.
+type Backend interface {
+	// Name returns the name Sources use to select the backend.
+	Name() string
+
+	// Create starts the build for the Source. The error is a *TemplateError
+	// if the backend can't make a build for the Source.
+	Create(source *v1alpha1.Source) (*v1alpha1.BuildResult, error)
+
+	// Status gets the state of the Source's build. The error satisfies
+	// errors.IsNotFound from k8s.io/apimachinery if the build doesn't exist
+	// and is ErrNotOwned if the Source doesn't control the build. Like Create
+	// it returns a *TemplateError if the backend can't make the build.
+	Status(source *v1alpha1.Source) (*v1alpha1.BuildResult, error)
+
+	// Tail writes the logs of a build to out until the build completes or
+	// the context is cancelled.
+	Tail(ctx context.Context, out io.Writer, buildName, namespace string) error
+
+	// Cancel stops a running build, the build fails with the reason
+	// v1alpha1.BuildCancelledReason.
+	Cancel(buildName, namespace string) error
+}
+
+// Registry holds Backends by their name.
+type Registry map[string]Backend
+
+// NewRegistry creates a Registry holding the given backends.
+func NewRegistry(backends ...Backend) Registry {
+	registry := make(Registry)
+	for _, backend := range backends {
+		registry[backend.Name()] = backend
+	}
+
+	return registry
+}
+
+// Get returns the Backend with the given name. A blank name gets the Knative
+// Build backend because Sources created before backends could be selected
+// don't record one.
+func (r Registry) Get(name string) (Backend, error) {
+	if name == "" {
+		name = v1alpha1.BuildBackendKnativeBuild
+	}
+
+	backend, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("the build backend %q isn't enabled", name)
+	}
+
+	return backend, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildbackends
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	build "github.com/knative/build/pkg/apis/build/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRegistry_Get(t *testing.T) {
+	knative := NewKnativeBuild(nil, nil, nil, nil)
+	tekton := NewTekton(nil, nil, nil, nil)
+
+	cases := map[string]struct {
+		registry    Registry
+		name        string
+		wantBackend Backend
+		wantErr     error
+	}{
+		"blank name gets knative-build": {
+			registry:    NewRegistry(tekton, knative),
+			name:        "",
+			wantBackend: knative,
+		},
+		"named backend": {
+			registry:    NewRegistry(tekton, knative),
+			name:        v1alpha1.BuildBackendTekton,
+			wantBackend: tekton,
+		},
+		"backend not enabled": {
+			registry: NewRegistry(knative),
+			name:     v1alpha1.BuildBackendTekton,
+			wantErr:  errors.New(`the build backend "tekton" isn't enabled`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			backend, err := tc.registry.Get(tc.name)
+
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			testutil.AssertEqual(t, "backend", tc.wantBackend, backend)
+		})
+	}
+}
+
+func TestBackend_templateError(t *testing.T) {
+	templateErr := errors.New("rebasing isn't supported by the tekton build backend")
+	makeTaskRun := func(*v1alpha1.Source) (*unstructured.Unstructured, error) {
+		return nil, templateErr
+	}
+	makeBuild := func(*v1alpha1.Source) (*build.Build, error) {
+		return nil, templateErr
+	}
+
+	backends := map[string]Backend{
+		"knative-build": NewKnativeBuild(nil, nil, makeBuild, nil),
+		"tekton":        NewTekton(nil, nil, makeTaskRun, nil),
+	}
+
+	for tn, backend := range backends {
+		t.Run(tn, func(t *testing.T) {
+			_, createErr := backend.Create(&v1alpha1.Source{})
+			testutil.AssertErrorsEqual(t, templateErr, createErr)
+			testutil.AssertEqual(t, "create is template error", true, IsTemplateError(createErr))
+
+			_, statusErr := backend.Status(&v1alpha1.Source{})
+			testutil.AssertErrorsEqual(t, templateErr, statusErr)
+			testutil.AssertEqual(t, "status is template error", true, IsTemplateError(statusErr))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildbackends
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	buildclient "github.com/google/kf/pkg/client/build/clientset/versioned/typed/build/v1alpha1"
+	buildlisters "github.com/google/kf/pkg/client/build/listers/build/v1alpha1"
+	build "github.com/knative/build/pkg/apis/build/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BuildTailer is implemented by github.com/knative/build/pkg/logs
+type BuildTailer interface {
+	Tail(ctx context.Context, out io.Writer, buildName, namespace string) error
+}
+
+// BuildTailerFunc converts a func into a BuildTailer.
+type BuildTailerFunc func(ctx context.Context, out io.Writer, buildName, namespace string) error
+
+// Tail implements BuildTailer.
+func (f BuildTailerFunc) Tail(ctx context.Context, out io.Writer, buildName, namespace string) error {
+	return f(ctx, out, buildName, namespace)
+}
+
+// BuildMaker creates the Knative Build for a Source.
+type BuildMaker func(source *v1alpha1.Source) (*build.Build, error)
+
+type knativeBuild struct {
+	client    buildclient.BuildsGetter
+	lister    buildlisters.BuildLister
+	makeBuild BuildMaker
+	tailer    BuildTailer
+}
+
+var _ Backend = (*knativeBuild)(nil)
+
+// NewKnativeBuild creates a Backend that runs builds with Knative Build. The
+// client, lister and makeBuild are used to create builds and get their
+// status, the client is also used to cancel them and the tailer is used to
+// read their logs. Clients that don't create builds can leave the lister and
+// makeBuild nil.
+func NewKnativeBuild(
+	client buildclient.BuildsGetter,
+	lister buildlisters.BuildLister,
+	makeBuild BuildMaker,
+	tailer BuildTailer,
+) Backend {
+	return &knativeBuild{
+		client:    client,
+		lister:    lister,
+		makeBuild: makeBuild,
+		tailer:    tailer,
+	}
+}
+
+// Name implements Backend.
+func (k *knativeBuild) Name() string {
+	return v1alpha1.BuildBackendKnativeBuild
+}
+
+// Create implements Backend.
+func (k *knativeBuild) Create(source *v1alpha1.Source) (*v1alpha1.BuildResult, error) {
+	desired, err := k.makeBuild(source)
+	if err != nil {
+		return nil, &TemplateError{Err: err}
+	}
+
+	actual, err := k.client.Builds(desired.Namespace).Create(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	return v1alpha1.KnativeBuildResult(actual), nil
+}
+
+// Status implements Backend.
+func (k *knativeBuild) Status(source *v1alpha1.Source) (*v1alpha1.BuildResult, error) {
+	desired, err := k.makeBuild(source)
+	if err != nil {
+		return nil, &TemplateError{Err: err}
+	}
+
+	actual, err := k.lister.Builds(desired.Namespace).Get(desired.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !metav1.IsControlledBy(actual, source) {
+		return nil, ErrNotOwned
+	}
+
+	return v1alpha1.KnativeBuildResult(actual), nil
+}
+
+// Cancel implements Backend.
+func (k *knativeBuild) Cancel(buildName, namespace string) error {
+	actual, err := k.client.Builds(namespace).Get(buildName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if actual.Spec.Status == build.BuildSpecStatusCancelled {
+		return nil
+	}
+
+	toUpdate := actual.DeepCopy()
+	toUpdate.Spec.Status = build.BuildSpecStatusCancelled
+	_, err = k.client.Builds(namespace).Update(toUpdate)
+	return err
+}
+
+// Tail implements Backend.
+func (k *knativeBuild) Tail(ctx context.Context, out io.Writer, buildName, namespace string) error {
+	return k.tailer.Tail(ctx, out, buildName, namespace)
+}
@@ -0,0 +1,297 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildbackends
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// taskRunLabel is added by Tekton to the pods of TaskRuns.
+	taskRunLabel = "tekton.dev/taskRun"
+
+	// taskRunCancelled is the spec status that cancels a TaskRun and the
+	// reason of TaskRuns that were cancelled.
+	taskRunCancelled = "TaskRunCancelled"
+
+	// taskRunTimeout is the reason of TaskRuns that ran longer than their
+	// timeout.
+	taskRunTimeout = "TaskRunTimeout"
+)
+
+// TaskRunResource is the resource of Tekton TaskRuns.
+var TaskRunResource = schema.GroupVersionResource{
+	Group:    "tekton.dev",
+	Version:  "v1alpha1",
+	Resource: "taskruns",
+}
+
+// TaskRunMaker creates the Tekton TaskRun for a Source.
+type TaskRunMaker func(source *v1alpha1.Source) (*unstructured.Unstructured, error)
+
+type tekton struct {
+	client      dynamic.Interface
+	lister      cache.GenericLister
+	makeTaskRun TaskRunMaker
+	k8sClient   v1.CoreV1Interface
+}
+
+var _ Backend = (*tekton)(nil)
+
+// NewTekton creates a Backend that runs builds as Tekton TaskRuns. The
+// lister and makeTaskRun are used to create TaskRuns and get their status,
+// the k8sClient is used to read logs from their pods. The client is needed
+// for all of them and to cancel TaskRuns.
+func NewTekton(
+	client dynamic.Interface,
+	lister cache.GenericLister,
+	makeTaskRun TaskRunMaker,
+	k8sClient v1.CoreV1Interface,
+) Backend {
+	return &tekton{
+		client:      client,
+		lister:      lister,
+		makeTaskRun: makeTaskRun,
+		k8sClient:   k8sClient,
+	}
+}
+
+// Name implements Backend.
+func (t *tekton) Name() string {
+	return v1alpha1.BuildBackendTekton
+}
+
+// Create implements Backend.
+func (t *tekton) Create(source *v1alpha1.Source) (*v1alpha1.BuildResult, error) {
+	desired, err := t.makeTaskRun(source)
+	if err != nil {
+		return nil, &TemplateError{Err: err}
+	}
+
+	actual, err := t.client.
+		Resource(TaskRunResource).
+		Namespace(desired.GetNamespace()).
+		Create(desired, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return taskRunResult(actual), nil
+}
+
+// Status implements Backend.
+func (t *tekton) Status(source *v1alpha1.Source) (*v1alpha1.BuildResult, error) {
+	desired, err := t.makeTaskRun(source)
+	if err != nil {
+		return nil, &TemplateError{Err: err}
+	}
+
+	obj, err := t.lister.ByNamespace(desired.GetNamespace()).Get(desired.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	actual, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("expected TaskRun %q to be unstructured, got %T", desired.GetName(), obj)
+	}
+
+	if !metav1.IsControlledBy(actual, source) {
+		return nil, ErrNotOwned
+	}
+
+	return taskRunResult(actual), nil
+}
+
+// Cancel implements Backend.
+func (t *tekton) Cancel(buildName, namespace string) error {
+	taskRuns := t.client.Resource(TaskRunResource).Namespace(namespace)
+
+	actual, err := taskRuns.Get(buildName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if status, _, _ := unstructured.NestedString(actual.Object, "spec", "status"); status == taskRunCancelled {
+		return nil
+	}
+
+	toUpdate := actual.DeepCopy()
+	if err := unstructured.SetNestedField(toUpdate.Object, taskRunCancelled, "spec", "status"); err != nil {
+		return err
+	}
+
+	_, err = taskRuns.Update(toUpdate, metav1.UpdateOptions{})
+	return err
+}
+
+// Tail implements Backend. The logs of each step are written in order as the
+// steps start.
+func (t *tekton) Tail(ctx context.Context, out io.Writer, buildName, namespace string) error {
+	if _, err := t.client.
+		Resource(TaskRunResource).
+		Namespace(namespace).
+		Get(buildName, metav1.GetOptions{}); err != nil {
+		return err
+	}
+
+	w, err := t.k8sClient.Pods(namespace).Watch(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", taskRunLabel, buildName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch pods: %s", err)
+	}
+	defer w.Stop()
+
+	// next is the index of the next step container to read logs from.
+	next := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case e, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+
+			if e.Type != watch.Added && e.Type != watch.Modified {
+				continue
+			}
+
+			pod, ok := e.Object.(*corev1.Pod)
+			if !ok {
+				continue
+			}
+
+			for ; next < len(pod.Spec.Containers); next++ {
+				container := pod.Spec.Containers[next].Name
+				if !containerStarted(pod, container) {
+					// Logs can't be read until the container has started.
+					break
+				}
+
+				fmt.Fprintf(out, "[%s]\n", container)
+				if err := t.readLogs(ctx, namespace, pod.Name, container, out); err != nil {
+					return err
+				}
+			}
+
+			if next == len(pod.Spec.Containers) {
+				return nil
+			}
+
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				// The remaining steps will never run.
+				return nil
+			}
+		}
+	}
+}
+
+func (t *tekton) readLogs(ctx context.Context, namespace, podName, container string, out io.Writer) error {
+	// XXX: This is not tested at a unit level and instead defers to
+	// integration tests.
+	stream, err := t.k8sClient.
+		Pods(namespace).
+		GetLogs(podName, &corev1.PodLogOptions{
+			Container: container,
+			Follow:    true,
+		}).
+		Context(ctx).
+		Stream()
+	if err != nil {
+		return fmt.Errorf("failed to read stream: %s", err)
+	}
+	defer stream.Close()
+
+	if _, err := io.Copy(out, stream); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// containerStarted returns true if the container in the pod is running or
+// has finished.
+func containerStarted(pod *corev1.Pod, container string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container {
+			return status.State.Running != nil || status.State.Terminated != nil
+		}
+	}
+
+	return false
+}
+
+// taskRunResult converts the status of a Tekton TaskRun into a BuildResult.
+func taskRunResult(taskRun *unstructured.Unstructured) *v1alpha1.BuildResult {
+	result := &v1alpha1.BuildResult{
+		Name:    taskRun.GetName(),
+		Backend: v1alpha1.BuildBackendTekton,
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(taskRun.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Succeeded" {
+			continue
+		}
+
+		result.Status = corev1.ConditionStatus(stringField(condition, "status"))
+		result.Reason = stringField(condition, "reason")
+		result.Message = stringField(condition, "message")
+	}
+
+	// Use the same reasons as Knative Build so Sources fail the same way on
+	// both backends.
+	switch result.Reason {
+	case taskRunTimeout:
+		result.Reason = v1alpha1.BuildTimeoutReason
+	case taskRunCancelled:
+		result.Reason = v1alpha1.BuildCancelledReason
+	}
+
+	if result.Status != corev1.ConditionTrue {
+		return result
+	}
+
+	params, _, _ := unstructured.NestedSlice(taskRun.Object, "spec", "inputs", "params")
+	for _, p := range params {
+		param, ok := p.(map[string]interface{})
+		if ok && param["name"] == v1alpha1.BuildArgImage {
+			result.Image = stringField(param, "value")
+		}
+	}
+
+	return result
+}
+
+func stringField(obj map[string]interface{}, field string) string {
+	value, _ := obj[field].(string)
+	return value
+}
@@ -0,0 +1,134 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildbackends
+
+import (
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestTaskRunResult(t *testing.T) {
+	newTaskRun := func(conditions ...interface{}) *unstructured.Unstructured {
+		taskRun := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"inputs": map[string]interface{}{
+						"params": []interface{}{
+							map[string]interface{}{"name": "SOURCE_IMAGE", "value": "some-source"},
+							map[string]interface{}{"name": "IMAGE", "value": "some-image"},
+						},
+					},
+				},
+				"status": map[string]interface{}{
+					"conditions": conditions,
+				},
+			},
+		}
+		taskRun.SetName("my-source-1")
+
+		return taskRun
+	}
+
+	cases := map[string]struct {
+		taskRun *unstructured.Unstructured
+		want    *v1alpha1.BuildResult
+	}{
+		"not started": {
+			taskRun: newTaskRun(),
+			want: &v1alpha1.BuildResult{
+				Name:    "my-source-1",
+				Backend: v1alpha1.BuildBackendTekton,
+			},
+		},
+		"running": {
+			taskRun: newTaskRun(map[string]interface{}{
+				"type":   "Succeeded",
+				"status": "Unknown",
+				"reason": "Running",
+			}),
+			want: &v1alpha1.BuildResult{
+				Name:    "my-source-1",
+				Backend: v1alpha1.BuildBackendTekton,
+				Status:  corev1.ConditionUnknown,
+				Reason:  "Running",
+			},
+		},
+		"failed": {
+			taskRun: newTaskRun(map[string]interface{}{
+				"type":    "Succeeded",
+				"status":  "False",
+				"reason":  "Failed",
+				"message": "build step failed",
+			}),
+			want: &v1alpha1.BuildResult{
+				Name:    "my-source-1",
+				Backend: v1alpha1.BuildBackendTekton,
+				Status:  corev1.ConditionFalse,
+				Reason:  "Failed",
+				Message: "build step failed",
+			},
+		},
+		"timed out": {
+			taskRun: newTaskRun(map[string]interface{}{
+				"type":    "Succeeded",
+				"status":  "False",
+				"reason":  "TaskRunTimeout",
+				"message": "TaskRun \"my-source-1\" failed to finish within \"10m0s\"",
+			}),
+			want: &v1alpha1.BuildResult{
+				Name:    "my-source-1",
+				Backend: v1alpha1.BuildBackendTekton,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1alpha1.BuildTimeoutReason,
+				Message: "TaskRun \"my-source-1\" failed to finish within \"10m0s\"",
+			},
+		},
+		"cancelled": {
+			taskRun: newTaskRun(map[string]interface{}{
+				"type":   "Succeeded",
+				"status": "False",
+				"reason": "TaskRunCancelled",
+			}),
+			want: &v1alpha1.BuildResult{
+				Name:    "my-source-1",
+				Backend: v1alpha1.BuildBackendTekton,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1alpha1.BuildCancelledReason,
+			},
+		},
+		"succeeded": {
+			taskRun: newTaskRun(map[string]interface{}{
+				"type":   "Succeeded",
+				"status": "True",
+			}),
+			want: &v1alpha1.BuildResult{
+				Name:    "my-source-1",
+				Backend: v1alpha1.BuildBackendTekton,
+				Status:  corev1.ConditionTrue,
+				Image:   "some-image",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "result", tc.want, taskRunResult(tc.taskRun))
+		})
+	}
+}
@@ -29,9 +29,18 @@ func BuildpackTemplate() build.TemplateInstantiationSpec {
 	}
 }
 
+// DockerfileTemplate gets the template spec for the Dockerfile template.
+func DockerfileTemplate() build.TemplateInstantiationSpec {
+	return build.TemplateInstantiationSpec{
+		Name: "dockerfile",
+		Kind: clusterBuildTemplate,
+	}
+}
+
 // clusterBuiltins returns a list of all ClusterBuildTemplates
 func clusterBuiltins() []build.TemplateInstantiationSpec {
 	return []build.TemplateInstantiationSpec{
 		BuildpackTemplate(),
+		DockerfileTemplate(),
 	}
 }
@@ -18,15 +18,17 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/apps"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/utils"
 	"github.com/google/kf/pkg/kf/describe"
+	"github.com/google/kf/pkg/kf/routes"
 	"github.com/spf13/cobra"
 )
 
 // NewGetAppCommand creates a command to get details about a single application.
-func NewGetAppCommand(p *config.KfParams, appsClient apps.Client) *cobra.Command {
+func NewGetAppCommand(p *config.KfParams, appsClient apps.Client, routesClient routes.Client) *cobra.Command {
 	var apps = &cobra.Command{
 		Use:     "app APP_NAME",
 		Short:   "Get a pushed app",
@@ -63,6 +65,9 @@ func NewGetAppCommand(p *config.KfParams, appsClient apps.Client) *cobra.Command
 				status := app.Status
 
 				fmt.Fprintf(w, "Image:\t%s\n", status.Image)
+				if status.GitCommit != "" {
+					fmt.Fprintf(w, "Git Commit:\t%s\n", status.GitCommit)
+				}
 				if url := status.URL; url != nil {
 					fmt.Fprintf(w, "Host:\t%s\n", url.Host)
 				}
@@ -73,6 +78,21 @@ func NewGetAppCommand(p *config.KfParams, appsClient apps.Client) *cobra.Command
 			})
 			fmt.Fprintln(w)
 
+			spaceRoutes, err := routesClient.List(p.Namespace)
+			if err != nil {
+				return err
+			}
+
+			var appRoutes []v1alpha1.Route
+			for _, route := range spaceRoutes {
+				if route.Spec.HasApp(app.Name) {
+					appRoutes = append(appRoutes, route)
+				}
+			}
+
+			describe.AppRoutes(w, appRoutes)
+			fmt.Fprintln(w)
+
 			return nil
 		},
 	}
@@ -0,0 +1,84 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// NewClearBuildCacheCommand creates a command that deletes the persistent
+// cache of an app's buildpack builds.
+func NewClearBuildCacheCommand(
+	p *config.KfParams,
+	client apps.Client,
+	claims corev1.PersistentVolumeClaimsGetter,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear-build-cache APP_NAME",
+		Short: "Delete the dependencies cached between an app's buildpack builds",
+		Long: `
+	Clear-build-cache deletes the persistent cache of an app's buildpack builds.
+	A new, empty cache is created for the app's next build.
+
+	The cache is removed once the builds using it finish.
+	`,
+		Example: `
+  kf clear-build-cache myapp
+  `,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			cmd.SilenceUsage = true
+
+			app, err := client.Get(p.Namespace, appName)
+			if err != nil {
+				return fmt.Errorf("failed to get app: %s", err)
+			}
+
+			if app.Spec.BuildCache == nil {
+				return fmt.Errorf("app %s doesn't have a build cache, push it with --build-cache to create one", appName)
+			}
+
+			err = claims.
+				PersistentVolumeClaims(p.Namespace).
+				Delete(app.BuildCacheName(), &metav1.DeleteOptions{})
+			switch {
+			case apierrs.IsNotFound(err):
+				fmt.Fprintf(cmd.OutOrStdout(), "The build cache of app %s is already empty\n", appName)
+			case err != nil:
+				return fmt.Errorf("failed to clear build cache: %s", err)
+			default:
+				fmt.Fprintf(cmd.OutOrStdout(), "Cleared the build cache of app %s\n", appName)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClearBuildCache(t *testing.T) {
+	t.Parallel()
+
+	cachedApp := &v1alpha1.App{}
+	cachedApp.Name = "my-app"
+	cachedApp.Spec.BuildCache = &v1alpha1.AppSpecBuildCache{}
+
+	existingClaim := &corev1.PersistentVolumeClaim{}
+	existingClaim.Name = "my-app-build-cache"
+	existingClaim.Namespace = "default"
+
+	cases := map[string]struct {
+		Namespace       string
+		Args            []string
+		Claims          []runtime.Object
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fake *fake.FakeClient)
+	}{
+		"clears cache": {
+			Namespace:       "default",
+			Args:            []string{"my-app"},
+			Claims:          []runtime.Object{existingClaim},
+			ExpectedStrings: []string{"Cleared the build cache of app my-app"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Get("default", "my-app").Return(cachedApp, nil)
+			},
+		},
+		"cache already cleared": {
+			Namespace:       "default",
+			Args:            []string{"my-app"},
+			ExpectedStrings: []string{"The build cache of app my-app is already empty"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Get("default", "my-app").Return(cachedApp, nil)
+			},
+		},
+		"app without cache": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("app my-app doesn't have a build cache, push it with --build-cache to create one"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Get("default", "my-app").Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"getting app fails": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to get app: some-error"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("some-error"))
+			},
+		},
+		"no app name": {
+			Namespace:   "default",
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := fake.NewFakeClient(ctrl)
+			kubeClient := testclient.NewSimpleClientset(tc.Claims...)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := NewClearBuildCacheCommand(p, fake, kubeClient.CoreV1())
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+			testutil.AssertEqual(t, "SilenceUsage", true, cmd.SilenceUsage)
+
+			_, err := kubeClient.CoreV1().PersistentVolumeClaims("default").Get("my-app-build-cache", metav1.GetOptions{})
+			testutil.AssertEqual(t, "claim deleted", true, apierrs.IsNotFound(err))
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/droplets"
+	"github.com/google/kf/pkg/kf/sources"
+	"github.com/spf13/cobra"
+)
+
+// NewDownloadDropletCommand creates a command that exports the image built
+// for an app as a tarball.
+func NewDownloadDropletCommand(
+	p *config.KfParams,
+	client sources.Client,
+	exportDroplet droplets.Exporter,
+) *cobra.Command {
+	var (
+		output    string
+		buildName string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "download-droplet APP_NAME",
+		Short: "Download the image built for an app as a tarball",
+		Long: `
+	Download-droplet exports the image of the app's latest successful build,
+	including builds created by kf stage, as a tarball. The tarball can be
+	loaded with docker load or deployed with kf push --droplet.
+	`,
+		Example: `
+  kf download-droplet myapp # Writes myapp.tar
+  kf download-droplet myapp --output droplet.tar
+  kf download-droplet myapp --build myapp-stage-abc12
+  `,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			appName := args[0]
+			if output == "" {
+				output = appName + ".tar"
+			}
+
+			cmd.SilenceUsage = true
+
+			source, err := dropletSource(client, p.Namespace, appName, buildName)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Downloading image %s of build %s\n", source.Status.Image, source.Name)
+			if err := exportDroplet(source.Status.Image, f); err != nil {
+				return fmt.Errorf("failed to download droplet: %s", err)
+			}
+
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Droplet of app %s written to %s\n", appName, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"Path the tarball is written to. Defaults to APP_NAME.tar",
+	)
+
+	cmd.Flags().StringVar(
+		&buildName,
+		"build",
+		"",
+		"Download the image of this build rather than the latest one, discover via kf builds",
+	)
+
+	return cmd
+}
+
+// dropletSource finds the Source of the app that built the droplet. If the
+// build name is empty the latest successful build is used.
+func dropletSource(client sources.Client, namespace, appName, buildName string) (*v1alpha1.Source, error) {
+	if buildName != "" {
+		source, err := client.Get(namespace, buildName)
+		if err != nil {
+			return nil, err
+		}
+
+		if source.Labels[v1alpha1.NameLabel] != appName {
+			return nil, fmt.Errorf("build %s doesn't belong to app %s", buildName, appName)
+		}
+
+		if finished, err := sources.SourceStatus(*source); !finished || err != nil || source.Status.Image == "" {
+			return nil, fmt.Errorf("build %s didn't succeed", buildName)
+		}
+
+		return source, nil
+	}
+
+	list, err := client.List(namespace, sources.WithListLabelSelector(map[string]string{
+		v1alpha1.NameLabel: appName,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	source := sources.LatestSucceeded(list)
+	if source == nil {
+		return nil, fmt.Errorf("app %s doesn't have a successful build yet", appName)
+	}
+
+	return source, nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/sources/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+func TestDownloadDropletCommand(t *testing.T) {
+	t.Parallel()
+
+	newSource := func(name, appName string, created int64, status corev1.ConditionStatus) v1alpha1.Source {
+		source := v1alpha1.Source{}
+		source.Name = name
+		source.Labels = map[string]string{v1alpha1.NameLabel: appName}
+		source.CreationTimestamp = metav1.Unix(created, 0)
+		source.Status.Image = name + "-image"
+		source.Status.Conditions = duckv1beta1.Conditions{
+			{Type: v1alpha1.SourceConditionSucceeded, Status: status},
+		}
+		return source
+	}
+
+	for tn, tc := range map[string]struct {
+		args       []string
+		setup      func(t *testing.T, fakeSources *fake.FakeClient)
+		exportErr  error
+		wantErr    error
+		wantImage  string
+		wantOutput []string
+	}{
+		"latest successful build": {
+			args: []string{"my-app"},
+			setup: func(t *testing.T, fakeSources *fake.FakeClient) {
+				fakeSources.
+					EXPECT().
+					List("some-namespace", gomock.Any()).
+					Return([]v1alpha1.Source{
+						newSource("my-app-1", "my-app", 1, corev1.ConditionTrue),
+						newSource("my-app-stage-2", "my-app", 2, corev1.ConditionTrue),
+						newSource("my-app-3", "my-app", 3, corev1.ConditionFalse),
+					}, nil)
+			},
+			wantImage:  "my-app-stage-2-image",
+			wantOutput: []string{"Downloading image my-app-stage-2-image of build my-app-stage-2"},
+		},
+		"specific build": {
+			args: []string{"my-app", "--build", "my-app-1"},
+			setup: func(t *testing.T, fakeSources *fake.FakeClient) {
+				source := newSource("my-app-1", "my-app", 1, corev1.ConditionTrue)
+				fakeSources.
+					EXPECT().
+					Get("some-namespace", "my-app-1").
+					Return(&source, nil)
+			},
+			wantImage: "my-app-1-image",
+		},
+		"build of another app": {
+			args: []string{"my-app", "--build", "other-app-1"},
+			setup: func(t *testing.T, fakeSources *fake.FakeClient) {
+				source := newSource("other-app-1", "other-app", 1, corev1.ConditionTrue)
+				fakeSources.
+					EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(&source, nil)
+			},
+			wantErr: errors.New("build other-app-1 doesn't belong to app my-app"),
+		},
+		"failed build": {
+			args: []string{"my-app", "--build", "my-app-1"},
+			setup: func(t *testing.T, fakeSources *fake.FakeClient) {
+				source := newSource("my-app-1", "my-app", 1, corev1.ConditionFalse)
+				fakeSources.
+					EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(&source, nil)
+			},
+			wantErr: errors.New("build my-app-1 didn't succeed"),
+		},
+		"no successful builds": {
+			args: []string{"my-app"},
+			setup: func(t *testing.T, fakeSources *fake.FakeClient) {
+				fakeSources.
+					EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+			},
+			wantErr: errors.New("app my-app doesn't have a successful build yet"),
+		},
+		"export fails": {
+			args: []string{"my-app"},
+			setup: func(t *testing.T, fakeSources *fake.FakeClient) {
+				fakeSources.
+					EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return([]v1alpha1.Source{
+						newSource("my-app-1", "my-app", 1, corev1.ConditionTrue),
+					}, nil)
+			},
+			exportErr: errors.New("some-error"),
+			wantErr:   errors.New("failed to download droplet: some-error"),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir, err := ioutil.TempDir("", "download-droplet")
+			testutil.AssertNil(t, "temp dir err", err)
+			defer os.RemoveAll(dir)
+
+			fakeSources := fake.NewFakeClient(ctrl)
+			if tc.setup != nil {
+				tc.setup(t, fakeSources)
+			}
+
+			exportDroplet := func(image string, out io.Writer) error {
+				fmt.Fprint(out, image)
+				return tc.exportErr
+			}
+
+			output := filepath.Join(dir, "droplet.tar")
+			c := NewDownloadDropletCommand(&config.KfParams{Namespace: "some-namespace"}, fakeSources, exportDroplet)
+			buffer := &bytes.Buffer{}
+			c.SetOutput(buffer)
+			c.SetArgs(append(tc.args, "--output", output))
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.wantOutput)
+			if gotErr != nil {
+				return
+			}
+
+			contents, err := ioutil.ReadFile(output)
+			testutil.AssertNil(t, "read err", err)
+			testutil.AssertEqual(t, "image", tc.wantImage, string(contents))
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/spaces"
+	"github.com/spf13/cobra"
+)
+
+// NewPromoteCommand creates a command that deploys the image of an app to
+// another space without rebuilding it.
+func NewPromoteCommand(p *config.KfParams, promoter apps.Promoter, spacesClient spaces.Client) *cobra.Command {
+	var (
+		fromSpace         string
+		toSpace           string
+		containerRegistry string
+		copyEnv           bool
+		copyScale         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "promote APP_NAME --to-space SPACE",
+		Short: "Deploy the image of an app to another space without rebuilding it",
+		Long: `
+	Promote deploys the image an app is running to the app with the same name
+	in another space, creating it if it doesn't exist. The image is copied to
+	a repository for the target space in its container registry and is
+	referenced by digest, so both apps run exactly the same image.
+
+	The app's command, ports and health check are copied. Environment
+	variables and scaling are kept from the target app unless --copy-env or
+	--copy-scale are set. Routes are never copied.
+	`,
+		Example: `
+  kf promote myapp --to-space prod
+  kf promote myapp --from-space dev --to-space staging --copy-env
+  `,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromSpace == "" {
+				if err := utils.ValidateNamespace(p); err != nil {
+					return err
+				}
+
+				fromSpace = p.Namespace
+			}
+
+			switch {
+			case toSpace == "":
+				return errors.New("--to-space is required")
+			case toSpace == fromSpace:
+				return errors.New("--to-space must be different from the space the app is in")
+			}
+
+			cmd.SilenceUsage = true
+
+			appName := args[0]
+			space, err := spacesClient.Get(toSpace)
+			if err != nil {
+				return fmt.Errorf("failed to get space %s: %s", toSpace, err)
+			}
+
+			app, err := promoter.Promote(
+				appName,
+				space,
+				apps.WithPromoteNamespace(fromSpace),
+				apps.WithPromoteContainerRegistry(containerRegistry),
+				apps.WithPromoteCopyEnv(copyEnv),
+				apps.WithPromoteCopyScale(copyScale),
+				apps.WithPromoteOutput(cmd.OutOrStdout()),
+			)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"%q successfully promoted from space %s to %s, image: %s\n",
+				appName,
+				fromSpace,
+				toSpace,
+				app.Spec.Source.ContainerImage.Image,
+			)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&fromSpace,
+		"from-space",
+		"",
+		"Space the app is promoted from. Defaults to the targeted space.",
+	)
+
+	cmd.Flags().StringVar(
+		&toSpace,
+		"to-space",
+		"",
+		"Space the app is promoted to.",
+	)
+
+	cmd.Flags().StringVar(
+		&containerRegistry,
+		"container-registry",
+		"",
+		"Container registry to copy the image to. Defaults to the target space's registry.",
+	)
+
+	cmd.Flags().BoolVar(
+		&copyEnv,
+		"copy-env",
+		false,
+		"Copy the app's environment variables to the target app, overriding ones with the same name.",
+	)
+
+	cmd.Flags().BoolVar(
+		&copyScale,
+		"copy-scale",
+		false,
+		"Copy the app's scaling bounds to the target app.",
+	)
+
+	return cmd
+}
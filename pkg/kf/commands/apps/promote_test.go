@@ -0,0 +1,139 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/commands/config"
+	spacesfake "github.com/google/kf/pkg/kf/spaces/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestPromote(t *testing.T) {
+	t.Parallel()
+
+	prod := &v1alpha1.Space{}
+	prod.Name = "prod"
+
+	promoted := &v1alpha1.App{}
+	promoted.Spec.Source.ContainerImage.Image = "some-registry/some-image@sha256:abc"
+
+	cases := map[string]struct {
+		Namespace       string
+		Args            []string
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fakePromoter *fake.FakePromoter, fakeSpaces *spacesfake.FakeClient)
+	}{
+		"promotes app": {
+			Namespace: "dev",
+			Args:      []string{"my-app", "--to-space", "prod", "--copy-env", "--copy-scale", "--container-registry", "some-registry"},
+			Setup: func(t *testing.T, fakePromoter *fake.FakePromoter, fakeSpaces *spacesfake.FakeClient) {
+				fakeSpaces.EXPECT().Get("prod").Return(prod, nil)
+				fakePromoter.
+					EXPECT().
+					Promote("my-app", prod, gomock.Any()).
+					DoAndReturn(func(appName string, to *v1alpha1.Space, opts ...apps.PromoteOption) (*v1alpha1.App, error) {
+						promoteOpts := apps.PromoteOptions(opts)
+						testutil.AssertEqual(t, "namespace", "dev", promoteOpts.Namespace())
+						testutil.AssertEqual(t, "container registry", "some-registry", promoteOpts.ContainerRegistry())
+						testutil.AssertEqual(t, "copy env", true, promoteOpts.CopyEnv())
+						testutil.AssertEqual(t, "copy scale", true, promoteOpts.CopyScale())
+						return promoted, nil
+					})
+			},
+			ExpectedStrings: []string{`"my-app" successfully promoted from space dev to prod`, "some-registry/some-image@sha256:abc"},
+		},
+		"from space flag": {
+			Args: []string{"my-app", "--from-space", "staging", "--to-space", "prod"},
+			Setup: func(t *testing.T, fakePromoter *fake.FakePromoter, fakeSpaces *spacesfake.FakeClient) {
+				fakeSpaces.EXPECT().Get("prod").Return(prod, nil)
+				fakePromoter.
+					EXPECT().
+					Promote("my-app", prod, gomock.Any()).
+					DoAndReturn(func(appName string, to *v1alpha1.Space, opts ...apps.PromoteOption) (*v1alpha1.App, error) {
+						testutil.AssertEqual(t, "namespace", "staging", apps.PromoteOptions(opts).Namespace())
+						return promoted, nil
+					})
+			},
+			ExpectedStrings: []string{"from space staging to prod"},
+		},
+		"promote fails": {
+			Namespace:   "dev",
+			Args:        []string{"my-app", "--to-space", "prod"},
+			ExpectedErr: errors.New("some-error"),
+			Setup: func(t *testing.T, fakePromoter *fake.FakePromoter, fakeSpaces *spacesfake.FakeClient) {
+				fakeSpaces.EXPECT().Get("prod").Return(prod, nil)
+				fakePromoter.EXPECT().Promote(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some-error"))
+			},
+		},
+		"space not found": {
+			Namespace:   "dev",
+			Args:        []string{"my-app", "--to-space", "prod"},
+			ExpectedErr: errors.New("failed to get space prod: some-error"),
+			Setup: func(t *testing.T, fakePromoter *fake.FakePromoter, fakeSpaces *spacesfake.FakeClient) {
+				fakeSpaces.EXPECT().Get("prod").Return(nil, errors.New("some-error"))
+			},
+		},
+		"missing to space": {
+			Namespace:   "dev",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("--to-space is required"),
+		},
+		"same space": {
+			Namespace:   "dev",
+			Args:        []string{"my-app", "--to-space", "dev"},
+			ExpectedErr: errors.New("--to-space must be different from the space the app is in"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakePromoter := fake.NewFakePromoter(ctrl)
+			fakeSpaces := spacesfake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fakePromoter, fakeSpaces)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := NewPromoteCommand(p, fakePromoter, fakeSpaces)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+			testutil.AssertEqual(t, "SilenceUsage", true, cmd.SilenceUsage)
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -22,12 +22,15 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/internal/envutil"
 	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/buildpacks"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/droplets"
 	kfi "github.com/google/kf/pkg/kf/internal/kf"
 	"github.com/google/kf/pkg/kf/manifest"
 	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
@@ -64,23 +67,54 @@ func (f SrcImageBuilderFunc) BuildSrcImage(dir, srcImage string) error {
 }
 
 // NewPushCommand creates a push command.
-func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher, b SrcImageBuilder, serviceBindingClient servicebindings.ClientInterface) *cobra.Command {
+func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher, b SrcImageBuilder, importDroplet droplets.Importer, serviceBindingClient servicebindings.ClientInterface, buildpacksClient buildpacks.Client) *cobra.Command {
+	return newPushCommand(p, pusher, nil, b, importDroplet, serviceBindingClient, buildpacksClient)
+}
+
+// NewStageCommand creates a command that builds apps the same way push does
+// without deploying them.
+func NewStageCommand(p *config.KfParams, stager apps.Stager, b SrcImageBuilder, buildpacksClient buildpacks.Client) *cobra.Command {
+	return newPushCommand(p, nil, stager, b, nil, nil, buildpacksClient)
+}
+
+// newPushCommand creates the push command or the stage command if the stager
+// is set. Flags that only change how apps are deployed aren't added to the
+// stage command.
+func newPushCommand(p *config.KfParams, pusher apps.Pusher, stager apps.Stager, b SrcImageBuilder, importDroplet droplets.Importer, serviceBindingClient servicebindings.ClientInterface, buildpacksClient buildpacks.Client) *cobra.Command {
+	staging := stager != nil
+
 	var (
 		containerRegistry  string
 		sourceImage        string
 		containerImage     string
-		manifestFile       string
+		dockerfile         string
+		droplet            string
+		gitURL             string
+		gitRef             string
+		manifestFiles      []string
+		varsFiles          []string
+		vars               []string
 		instances          int
 		serviceAccount     string
 		path               string
 		buildpack          string
+		stack              string
 		envs               []string
 		grpc               bool
 		noManifest         bool
 		noStart            bool
-		routes             []v1alpha1.RouteSpecFields
+		dryRun             bool
+		noRoute            bool
+		randomRoute        bool
+		memory             string
+		diskQuota          string
+		buildCache         string
+		noCache            bool
+		buildTimeout       time.Duration
+		command            string
 		healthCheckType    string
 		healthCheckTimeout int
+		strategy           string
 	)
 
 	var pushCmd = &cobra.Command{
@@ -90,7 +124,19 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
   kf push myapp
   kf push myapp --container-registry gcr.io/myproject
   kf push myapp --buildpack my.special.buildpack # Discover via kf buildpacks
+  kf push myapp --stack gcr.io/my-project/run:cflinuxfs3 # Discover via kf stacks
+  kf push myapp --dockerfile Dockerfile # Build the source with a Dockerfile
+  kf push myapp --git-url https://github.com/org/repo --git-ref abc123 # Build a commit without uploading source
   kf push myapp --env FOO=bar --env BAZ=foo
+  kf push myapp --memory 512M --disk-quota 1G
+  kf push myapp --build-cache 2G # Keep dependencies between builds
+  kf push myapp --build-cache 2G --no-cache # Build without the cached dependencies
+  kf push myapp --build-timeout 30m # Fail the build if it runs longer than 30 minutes
+  kf push myapp --random-route
+  kf push myapp --dry-run # Show the changes without applying them
+  kf push myapp -f manifest.yml -f prod.yml --vars-file prod-vars.yml --var instances=3
+  kf push myapp --strategy blue-green # Keep the old version serving traffic until the new one is ready
+  kf push myapp --droplet myapp.tar # Deploy an image exported by kf download-droplet
   `,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -103,6 +149,10 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 				return err
 			}
 
+			if gitRef != "" && gitURL == "" {
+				return errors.New("--git-ref can only be used with --git-url")
+			}
+
 			cmd.SilenceUsage = true
 
 			appName := ""
@@ -116,18 +166,23 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 				return err
 			}
 
+			variables, err := manifestVariables(varsFiles, vars)
+			if err != nil {
+				return err
+			}
+
 			var pushManifest *manifest.Manifest
 			switch {
 			case noManifest:
 				if pushManifest, err = manifest.New(appName); err != nil {
 					return err
 				}
-			case manifestFile != "":
-				if pushManifest, err = manifest.NewFromFile(manifestFile); err != nil {
-					return fmt.Errorf("supplied manifest file %s resulted in error: %v", manifestFile, err)
+			case len(manifestFiles) > 0:
+				if pushManifest, err = manifest.NewFromFiles(manifestFiles, variables); err != nil {
+					return fmt.Errorf("supplied manifest file %s resulted in error: %v", strings.Join(manifestFiles, ", "), err)
 				}
 			default:
-				if pushManifest, err = manifest.CheckForManifest(path); err != nil {
+				if pushManifest, err = manifest.CheckForManifest(path, variables); err != nil {
 					return fmt.Errorf("error checking directory %s for manifest file: %v", path, err)
 				}
 
@@ -138,6 +193,10 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 				}
 			}
 
+			for _, warning := range pushManifest.Warnings {
+				fmt.Fprintf(cmd.OutOrStderr(), "Warning: %s\n", warning)
+			}
+
 			appsToDeploy := pushManifest.Applications
 			if appName != "" {
 				// deploy one app from the manifest
@@ -149,9 +208,14 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 				appsToDeploy = []manifest.Application{*app}
 			}
 
+			if droplet != "" && len(appsToDeploy) > 1 {
+				return errors.New("--droplet can only be used to push a single app")
+			}
+
 			overrides := &manifest.Application{}
 			{
 				overrides.Docker.Image = containerImage
+				overrides.Docker.Dockerfile = dockerfile
 
 				// Read environment variables from cli args
 				envVars, err := envutil.ParseCLIEnvVars(envs)
@@ -164,11 +228,20 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 					overrides.Buildpacks = []string{buildpack}
 				}
 
+				overrides.Stack = stack
+
 				overrides.HealthCheckTimeout = healthCheckTimeout
 
 				if healthCheckType != "" {
 					overrides.HealthCheckType = healthCheckType
 				}
+
+				overrides.Memory = memory
+				overrides.DiskQuota = diskQuota
+				overrides.BuildCache = buildCache
+				overrides.Command = command
+				overrides.NoRoute = noRoute
+				overrides.RandomRoute = randomRoute
 			}
 
 			for _, app := range appsToDeploy {
@@ -176,19 +249,24 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 					return err
 				}
 
-				minScale, maxScale, err := calculateScaleBounds(instances, app.MinScale, app.MaxScale)
+				minScale, maxScale, err := calculateScaleBounds(instances, app.Instances, app.MinScale, app.MaxScale)
 				if err != nil {
 					return err
 				}
 
-				manifestRoutes := app.Routes
-				for _, route := range manifestRoutes {
-					// Parse route string from URL into hostname, domain, and path
-					newRoute, err := createRoute(route.Route, p.Namespace)
-					if err != nil {
-						return err
-					}
-					routes = append(routes, newRoute)
+				routes, err := appRoutes(app, p.Namespace)
+				if err != nil {
+					return err
+				}
+
+				resources, err := app.ResourceRequirements()
+				if err != nil {
+					return err
+				}
+
+				buildCacheSize, err := app.BuildCacheSize()
+				if err != nil {
+					return err
 				}
 
 				healthCheck, err := apps.NewHealthCheck(app.HealthCheckType, app.HealthCheckHTTPEndpoint, app.HealthCheckTimeout)
@@ -206,27 +284,89 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 					apps.WithPushNoStart(noStart),
 					apps.WithPushRoutes(routes),
 					apps.WithPushHealthCheck(healthCheck),
+					apps.WithPushStrategy(strategy),
+					apps.WithPushResources(resources),
+					apps.WithPushCommand(app.Command),
+					apps.WithPushRandomRoute(app.RandomRoute && !app.NoRoute),
+					apps.WithPushDryRun(dryRun),
+					apps.WithPushBuildTimeout(buildTimeout),
 				}
 
-				if app.Docker.Image == "" { // buildpack app
-					registry := containerRegistry
-					switch {
-					case registry != "":
-						break
-					case space.Spec.BuildpackBuild.ContainerRegistry != "":
-						registry = space.Spec.BuildpackBuild.ContainerRegistry
-					default:
+				switch {
+				case droplet != "":
+					if app.Docker.Image != "" || app.Docker.Dockerfile != "" || app.Buildpack() != "" || app.Stack != "" || app.BuildCache != "" || gitURL != "" {
+						return errors.New("--droplet can't be used with docker-image, dockerfile, buildpack, stack, build-cache or git-url")
+					}
+
+					registry := imageRegistry(containerRegistry, space)
+					if registry == "" {
+						return errors.New("container-registry is required for droplets")
+					}
+
+					imageName := apps.JoinRepositoryImage(registry, apps.AppImageName(p.Namespace, app.Name))
+					if dryRun {
+						fmt.Fprintf(cmd.OutOrStdout(), "Droplet %s would be uploaded to %s\n", droplet, imageName)
+					} else {
+						fmt.Fprintf(cmd.OutOrStdout(), "Uploading droplet %s to %s\n", droplet, imageName)
+						if err := importDroplet(droplet, imageName); err != nil {
+							return fmt.Errorf("failed to upload droplet: %s", err)
+						}
+					}
+
+					pushOpts = append(pushOpts, apps.WithPushContainerImage(imageName))
+				case app.Docker.Image == "": // buildpack or Dockerfile app
+					registry := imageRegistry(containerRegistry, space)
+					if registry == "" {
 						return errors.New("container-registry is required for buildpack apps")
 					}
 
+					// Check the build settings before uploading the source.
+					if app.Docker.Dockerfile != "" {
+						if app.Buildpack() != "" {
+							return errors.New("cannot use buildpack and dockerfile simultaneously")
+						}
+						if app.Stack != "" {
+							return errors.New("cannot use stack and dockerfile simultaneously")
+						}
+						if app.BuildCache != "" {
+							return errors.New("cannot use build-cache and dockerfile simultaneously")
+						}
+					} else {
+						// The space's stack is used if the app doesn't set
+						// one.
+						buildStack := app.Stack
+						if buildStack == "" {
+							buildStack = space.Spec.BuildpackBuild.Stack
+						}
+
+						if buildStack != "" {
+							if err := validateStack(buildpacksClient, space.Spec.BuildpackBuild.BuilderImage, buildStack); err != nil {
+								return err
+							}
+						}
+					}
+
 					var imageName string
 					srcPath := filepath.Join(path, app.Path)
 					switch {
+					case gitURL != "":
+						// The source is cloned by the build, the app's path
+						// is relative to the root of the repository.
+						pushOpts = append(pushOpts, apps.WithPushGit(&v1alpha1.SourceSpecGit{
+							URL:          gitURL,
+							Revision:     gitRef,
+							Subdirectory: filepath.ToSlash(app.Path),
+						}))
 					case sourceImage != "":
 						imageName = sourceImage
 					default:
 						imageName = apps.JoinRepositoryImage(registry, apps.SourceImageName(p.Namespace, app.Name))
 
+						if dryRun {
+							fmt.Fprintf(cmd.OutOrStdout(), "Source %s would be uploaded to %s\n", srcPath, imageName)
+							break
+						}
+
 						if err := b.BuildSrcImage(srcPath, imageName); err != nil {
 							return err
 						}
@@ -234,9 +374,24 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 					pushOpts = append(pushOpts,
 						apps.WithPushSourceImage(imageName),
 						apps.WithPushContainerRegistry(registry),
-						apps.WithPushBuildpack(app.Buildpack()),
 					)
-				} else {
+
+					if app.Docker.Dockerfile != "" {
+						pushOpts = append(pushOpts, apps.WithPushDockerfile(filepath.ToSlash(app.Docker.Dockerfile)))
+					} else {
+						pushOpts = append(pushOpts,
+							apps.WithPushBuildpack(app.Buildpack()),
+							apps.WithPushStack(app.Stack),
+						)
+
+						if buildCacheSize != nil {
+							pushOpts = append(pushOpts, apps.WithPushBuildCache(&v1alpha1.AppSpecBuildCache{
+								Size: *buildCacheSize,
+								Skip: noCache,
+							}))
+						}
+					}
+				default:
 					if containerRegistry != "" {
 						return errors.New("--container-registry can only be used with source pushes, not containers")
 					}
@@ -246,12 +401,48 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 					if app.Path != "" {
 						return errors.New("cannot use path and docker image simultaneously")
 					}
+					if app.Stack != "" {
+						return errors.New("cannot use stack and docker image simultaneously")
+					}
+					if app.Docker.Dockerfile != "" {
+						return errors.New("cannot use dockerfile and docker image simultaneously")
+					}
+					if gitURL != "" {
+						return errors.New("cannot use git-url and docker image simultaneously")
+					}
+					if app.BuildCache != "" {
+						return errors.New("cannot use build-cache and docker image simultaneously")
+					}
 
 					pushOpts = append(pushOpts, apps.WithPushContainerImage(app.Docker.Image))
 				}
 
+				if staging {
+					source, err := stager.Stage(app.Name, space, pushOpts...)
+					if err != nil {
+						return err
+					}
+
+					fmt.Fprintf(cmd.OutOrStdout(), "%q successfully staged as %s, image: %s\n", app.Name, source.Name, source.Status.Image)
+					continue
+				}
+
 				// Bind service if set
 				for _, serviceInstance := range app.Services {
+					if dryRun {
+						bindings, err := serviceBindingClient.List(
+							servicebindings.WithListServiceInstance(serviceInstance),
+							servicebindings.WithListAppName(app.Name),
+							servicebindings.WithListNamespace(p.Namespace))
+						if err != nil {
+							return err
+						}
+
+						if len(bindings) == 0 {
+							fmt.Fprintf(cmd.OutOrStdout(), "Service instance %q would be bound to %q\n", serviceInstance, app.Name)
+						}
+						continue
+					}
 
 					binding, created, err := serviceBindingClient.GetOrCreate(
 						serviceInstance,
@@ -311,13 +502,6 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 		"Set environment variables. Multiple can be set by using the flag multiple times (e.g., NAME=VALUE).",
 	)
 
-	pushCmd.Flags().BoolVar(
-		&grpc,
-		"grpc",
-		false,
-		"Setup the container to allow application to use gRPC.",
-	)
-
 	pushCmd.Flags().BoolVar(
 		&noManifest,
 		"no-manifest",
@@ -333,6 +517,14 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 		"Skip the 'detect' buildpack step and use the given name.",
 	)
 
+	pushCmd.Flags().StringVarP(
+		&stack,
+		"stack",
+		"s",
+		"",
+		"Base image to use for buildpack apps. Discover via kf stacks. Defaults to the space's stack.",
+	)
+
 	pushCmd.Flags().StringVar(
 		&sourceImage,
 		"source-image",
@@ -342,18 +534,87 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 	pushCmd.Flags().MarkHidden("source-image")
 
 	pushCmd.Flags().StringVar(
-		&containerImage,
-		"docker-image",
+		&dockerfile,
+		"dockerfile",
 		"",
-		"The docker image to deploy.",
+		"Build the source with the Dockerfile at this path, relative to the source directory.",
 	)
 
-	pushCmd.Flags().StringVarP(
-		&manifestFile,
+	pushCmd.Flags().StringVar(
+		&gitURL,
+		"git-url",
+		"",
+		"Build the source cloned from this Git repository instead of uploading the source directory.",
+	)
+
+	pushCmd.Flags().StringVar(
+		&gitRef,
+		"git-ref",
+		"",
+		"The branch, tag or commit to build when using --git-url. Defaults to the repository's default branch.",
+	)
+
+	pushCmd.Flags().StringArrayVarP(
+		&manifestFiles,
 		"manifest",
 		"f",
+		nil,
+		"Path to manifest. Multiple manifests can be layered by using the flag multiple times, later manifests override earlier ones.",
+	)
+
+	pushCmd.Flags().StringArrayVar(
+		&varsFiles,
+		"vars-file",
+		nil,
+		"Path to a YAML file of variables to substitute for ((variable)) references in the manifest. Multiple can be set by using the flag multiple times.",
+	)
+
+	pushCmd.Flags().StringArrayVar(
+		&vars,
+		"var",
+		nil,
+		"Variable to substitute for ((variable)) references in the manifest, overrides variables files. Multiple can be set by using the flag multiple times (e.g., NAME=VALUE).",
+	)
+
+	pushCmd.Flags().DurationVar(
+		&buildTimeout,
+		"build-timeout",
+		0,
+		"Longest the build can run before it fails (e.g., 10m, 1h). Defaults to the space's build timeout",
+	)
+
+	if staging {
+		pushCmd.Use = "stage APP_NAME"
+		pushCmd.Short = "Build an app without deploying it"
+		pushCmd.Long = `
+	Stage builds an app the same way push does but doesn't change the running
+	app. The resulting image can be exported with kf download-droplet and
+	deployed later with kf push --droplet.
+	`
+		pushCmd.Example = `
+  kf stage myapp
+  kf stage myapp --buildpack my.special.buildpack
+  kf stage myapp -f manifest.yml --vars-file prod-vars.yml
+  `
+
+		// The instances flag isn't added, -1 represents non-user input.
+		instances = -1
+
+		return pushCmd
+	}
+
+	pushCmd.Flags().BoolVar(
+		&grpc,
+		"grpc",
+		false,
+		"Setup the container to allow application to use gRPC.",
+	)
+
+	pushCmd.Flags().StringVar(
+		&containerImage,
+		"docker-image",
 		"",
-		"Path to manifest",
+		"The docker image to deploy.",
 	)
 
 	pushCmd.Flags().IntVarP(
@@ -371,6 +632,65 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 		"Do not start an app after pushing",
 	)
 
+	pushCmd.Flags().BoolVar(
+		&dryRun,
+		"dry-run",
+		false,
+		"Print the changes to the app and the service bindings that would be created without applying them",
+	)
+
+	pushCmd.Flags().BoolVar(
+		&noRoute,
+		"no-route",
+		false,
+		"Do not map a route to the app",
+	)
+
+	pushCmd.Flags().BoolVar(
+		&randomRoute,
+		"random-route",
+		false,
+		"Create a random route for the app if it doesn't already have one",
+	)
+
+	pushCmd.Flags().StringVarP(
+		&memory,
+		"memory",
+		"m",
+		"",
+		"Memory limit of each instance (e.g., 256M, 1G)",
+	)
+
+	pushCmd.Flags().StringVarP(
+		&diskQuota,
+		"disk-quota",
+		"k",
+		"",
+		"Disk limit of each instance (e.g., 256M, 1G)",
+	)
+
+	pushCmd.Flags().StringVar(
+		&buildCache,
+		"build-cache",
+		"",
+		"Size of a persistent cache kept between buildpack builds (e.g., 1G, 5G)",
+	)
+
+	pushCmd.Flags().BoolVar(
+		&noCache,
+		"no-cache",
+		false,
+		"Build the app without using its build cache, the cache is kept for later builds",
+	)
+
+	pushCmd.Flags().StringVarP(
+		&command,
+		"command",
+		"c",
+		"",
+		"Startup command for the app, this overrides the default command specified by the web process",
+	)
+
 	pushCmd.Flags().StringVarP(
 		&healthCheckType,
 		"health-check-type",
@@ -387,16 +707,93 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 		"Time (in seconds) allowed to elapse between starting up an app and the first healthy response from the app.",
 	)
 
+	pushCmd.Flags().StringVar(
+		&strategy,
+		"strategy",
+		apps.PushStrategyInPlace,
+		fmt.Sprintf("Strategy used to roll out the app (%s)", strings.Join(apps.PushStrategies, ", ")),
+	)
+
+	pushCmd.Flags().StringVar(
+		&droplet,
+		"droplet",
+		"",
+		"Deploy the image in this tarball, exported by kf download-droplet, instead of building the source",
+	)
+
 	return pushCmd
 }
 
-func calculateScaleBounds(instances int, minScale, maxScale *int) (int, int, error) {
+// imageRegistry returns the container registry images are uploaded to. The
+// flag takes precedence over the space's registry, it's empty if neither is
+// set.
+func imageRegistry(flag string, space *v1alpha1.Space) string {
+	if flag != "" {
+		return flag
+	}
+
+	return space.Spec.BuildpackBuild.ContainerRegistry
+}
+
+// validateStack checks that the stack is available on the builder image.
+func validateStack(buildpacksClient buildpacks.Client, builderImage, stack string) error {
+	stacks, err := buildpacksClient.Stacks(builderImage)
+	if err != nil {
+		return fmt.Errorf("failed to list stacks for builder %s: %v", builderImage, err)
+	}
+
+	for _, s := range stacks {
+		if s == stack {
+			return nil
+		}
+	}
+
+	return kfi.ConfigErr{
+		Reason: fmt.Sprintf("stack %q isn't available on builder %s, use one of: %s", stack, builderImage, strings.Join(stacks, ", ")),
+	}
+}
+
+// manifestVariables combines the variables from the files and the command
+// line. Later files override earlier ones and command line variables override
+// files.
+func manifestVariables(varsFiles, vars []string) (map[string]interface{}, error) {
+	variables := make(map[string]interface{})
+
+	for _, varsFile := range varsFiles {
+		fileVariables, err := manifest.NewVariablesFromFile(varsFile)
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range fileVariables {
+			variables[k] = v
+		}
+	}
+
+	cliVariables, err := manifest.ParseCLIVariables(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range cliVariables {
+		variables[k] = v
+	}
+
+	return variables, nil
+}
+
+func calculateScaleBounds(instances int, manifestInstances, minScale, maxScale *int) (int, int, error) {
 	zero := 0
 	if instances != -1 {
 		if minScale != nil || maxScale != nil {
 			return -1, -1, errors.New("couldn't set the -i flag and the minScale/maxScale flags in manifest together")
 		}
 		return instances, instances, nil
+	} else if manifestInstances != nil {
+		if minScale != nil || maxScale != nil {
+			return -1, -1, errors.New("couldn't set instances and the minScale/maxScale flags in manifest together")
+		}
+		return *manifestInstances, *manifestInstances, nil
 	} else {
 		if minScale == nil && maxScale == nil {
 			// both default bounds are 1
@@ -417,6 +814,67 @@ func calculateScaleBounds(instances int, minScale, maxScale *int) (int, int, err
 
 }
 
+// appRoutes converts the routes of a manifest application, including the
+// legacy host, hosts, domain and domains fields, into RouteSpecFields.
+func appRoutes(app manifest.Application, namespace string) ([]v1alpha1.RouteSpecFields, error) {
+	if app.NoRoute {
+		return nil, nil
+	}
+
+	hosts := app.Hosts
+	if app.Host != "" {
+		hosts = append([]string{app.Host}, hosts...)
+	}
+
+	domains := app.Domains
+	if app.Domain != "" {
+		domains = append([]string{app.Domain}, domains...)
+	}
+
+	legacy := len(hosts) > 0 || len(domains) > 0 || app.NoHostname
+	if legacy && len(app.Routes) > 0 {
+		return nil, errors.New("routes can't be used with host, hosts, domain, domains or no-hostname")
+	}
+
+	var routes []v1alpha1.RouteSpecFields
+	for _, route := range app.Routes {
+		// Parse route string from URL into hostname, domain, and path
+		newRoute, err := createRoute(route.Route, namespace)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, newRoute)
+	}
+
+	if !legacy {
+		return routes, nil
+	}
+
+	if len(hosts) == 0 {
+		if app.NoHostname {
+			hosts = []string{""}
+		} else {
+			hosts = []string{app.Name}
+		}
+	}
+
+	if len(domains) == 0 {
+		// An empty domain gets replaced by the default domain of the space.
+		domains = []string{""}
+	}
+
+	for _, domain := range domains {
+		for _, host := range hosts {
+			routes = append(routes, v1alpha1.RouteSpecFields{
+				Hostname: host,
+				Domain:   domain,
+			})
+		}
+	}
+
+	return routes, nil
+}
+
 func createRoute(routeStr, namespace string) (v1alpha1.RouteSpecFields, error) {
 	hostname, domain, path, err := parseRouteStr(routeStr)
 	if err != nil {
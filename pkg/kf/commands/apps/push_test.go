@@ -22,11 +22,13 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/apps"
 	appsfake "github.com/google/kf/pkg/kf/apps/fake"
+	bpfake "github.com/google/kf/pkg/kf/buildpacks/fake"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/utils"
 	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
@@ -34,6 +36,7 @@ import (
 	"github.com/google/kf/pkg/kf/testutil"
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 type routeParts struct {
@@ -80,6 +83,7 @@ func TestPushCommand(t *testing.T) {
 		apps.WithPushHealthCheck(defaultTCPHealthCheck),
 		apps.WithPushMinScale(1),
 		apps.WithPushMaxScale(1),
+		apps.WithPushStrategy(apps.PushStrategyInPlace),
 	}
 
 	for tn, tc := range map[string]struct {
@@ -131,6 +135,19 @@ func TestPushCommand(t *testing.T) {
 				}),
 			),
 		},
+		"blue-green strategy": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--container-registry", "some-reg.io",
+				"--strategy", "blue-green",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushStrategy(apps.PushStrategyBlueGreen),
+			),
+		},
 		"uses current working directory for empty path": {
 			namespace: "some-namespace",
 			args: []string{
@@ -242,6 +259,115 @@ func TestPushCommand(t *testing.T) {
 				apps.WithPushBuildpack("java,tomcat"),
 			),
 		},
+		"stack from flag": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--container-registry", "some-reg.io",
+				"--stack", "cflinuxfs3",
+			},
+			wantImagePrefix: "some-reg.io/src-some-namespace-app-name",
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushStack("cflinuxfs3"),
+			),
+		},
+		"stack isn't on the builder": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--container-registry", "some-reg.io",
+				"--stack", "bad",
+			},
+			wantErr: errors.New(`stack "bad" isn't available on builder gcr.io/kf-releases/buildpack-builder:latest, use one of: cflinuxfs3`),
+		},
+		"space stack isn't on the builder": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--container-registry", "some-reg.io",
+			},
+			targetSpace: &v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					BuildpackBuild: v1alpha1.SpaceSpecBuildpackBuild{
+						BuilderImage: "some-builder",
+						Stack:        "bad",
+					},
+				},
+			},
+			wantErr: errors.New(`stack "bad" isn't available on builder some-builder, use one of: cflinuxfs3`),
+		},
+		"build cache": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--container-registry", "some-reg.io",
+				"--build-cache", "2G",
+				"--no-cache",
+			},
+			wantImagePrefix: "some-reg.io/src-some-namespace-app-name",
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushBuildCache(&v1alpha1.AppSpecBuildCache{
+					Size: resource.MustParse("2Gi"),
+					Skip: true,
+				}),
+			),
+		},
+		"build timeout": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--container-registry", "some-reg.io",
+				"--build-timeout", "30m",
+			},
+			wantImagePrefix: "some-reg.io/src-some-namespace-app-name",
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushBuildTimeout(30*time.Minute),
+			),
+		},
+		"invalid build cache": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--container-registry", "some-reg.io",
+				"--build-cache", "lots",
+			},
+			wantErr: errors.New(`invalid build-cache "lots", it must be an integer followed by a unit of M, G or T`),
+		},
+		"build cache with dockerfile": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--container-registry", "some-reg.io",
+				"--dockerfile", "Dockerfile",
+				"--build-cache", "2G",
+			},
+			wantErr: errors.New("cannot use build-cache and dockerfile simultaneously"),
+		},
+		"build cache with docker image": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--docker-image", "some-image",
+				"--build-cache", "2G",
+			},
+			wantErr: errors.New("cannot use build-cache and docker image simultaneously"),
+		},
+		"stack with dockerfile": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--container-registry", "some-reg.io",
+				"--dockerfile", "Dockerfile",
+				"--stack", "cflinuxfs3",
+			},
+			wantErr: errors.New("cannot use stack and dockerfile simultaneously"),
+		},
 		"SrcImageBuilder returns an error": {
 			namespace: "some-namespace",
 			args:      []string{"app-name", "--container-registry", "some-reg.io"},
@@ -336,6 +462,94 @@ func TestPushCommand(t *testing.T) {
 				apps.WithPushContainerRegistry("some-registry.io"),
 			),
 		},
+		"dockerfile app": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--dockerfile", "Dockerfile",
+				"--container-registry", "some-registry.io",
+			},
+			wantImagePrefix: "some-registry.io/src-some-namespace-app-name",
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-registry.io"),
+				apps.WithPushDockerfile("Dockerfile"),
+			),
+		},
+		"dockerfile app from manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"dockerfile-app",
+				"--manifest", "testdata/manifest.yml",
+				"--container-registry", "some-registry.io",
+			},
+			wantImagePrefix: "some-registry.io/src-some-namespace-dockerfile-app",
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-registry.io"),
+				apps.WithPushDockerfile("docker/Dockerfile"),
+			),
+		},
+		"invalid buildpack and dockerfile": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--dockerfile", "Dockerfile",
+				"--buildpack", "some-buildpack",
+				"--container-registry", "some-registry.io",
+			},
+			wantErr: errors.New("cannot use buildpack and dockerfile simultaneously"),
+		},
+		"invalid dockerfile and container image": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--dockerfile", "Dockerfile",
+				"--docker-image", "some-image",
+			},
+			wantErr: errors.New("cannot use dockerfile and docker image simultaneously"),
+		},
+		"git source": {
+			namespace: "some-namespace",
+			args: []string{
+				"buildpack-app",
+				"--manifest", "testdata/manifest.yml",
+				"--git-url", "https://github.com/google/kf",
+				"--git-ref", "abc123",
+				"--container-registry", "some-registry.io",
+			},
+			srcImageBuilder: func(dir, srcImage string, rebase bool) error {
+				t.Fatal("source shouldn't be uploaded")
+				return nil
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushBuildpack("java,tomcat"),
+				apps.WithPushContainerRegistry("some-registry.io"),
+				apps.WithPushGit(&v1alpha1.SourceSpecGit{
+					URL:          "https://github.com/google/kf",
+					Revision:     "abc123",
+					Subdirectory: "example-app",
+				}),
+			),
+		},
+		"git ref without git url": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--git-ref", "abc123",
+			},
+			wantErr: errors.New("--git-ref can only be used with --git-url"),
+		},
+		"invalid git url and container image": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--git-url", "https://github.com/google/kf",
+				"--docker-image", "some-image",
+			},
+			wantErr: errors.New("cannot use git-url and docker image simultaneously"),
+		},
 		"manifest missing app": {
 			namespace: "some-namespace",
 			args: []string{
@@ -391,6 +605,202 @@ func TestPushCommand(t *testing.T) {
 				}),
 			),
 		},
+		"cf fields from manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"cf-fields-app",
+				"--container-registry", "some-reg.io",
+				"--manifest", "testdata/manifest.yml",
+			},
+			wantImagePrefix: "some-reg.io/src-some-namespace-cf-fields-app",
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushMinScale(3),
+				apps.WithPushMaxScale(3),
+				apps.WithPushCommand("bundle exec rackup"),
+				apps.WithPushStack("cflinuxfs3"),
+				apps.WithPushResources(&corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory:           resource.MustParse("512Mi"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory:           resource.MustParse("512Mi"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+					},
+				}),
+			),
+		},
+		"flags override cf fields from manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"cf-fields-app",
+				"--container-registry", "some-reg.io",
+				"--manifest", "testdata/manifest.yml",
+				"-i", "2",
+				"-m", "1G",
+				"-k", "2G",
+				"-c", "./start.sh",
+			},
+			wantImagePrefix: "some-reg.io/src-some-namespace-cf-fields-app",
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushMinScale(2),
+				apps.WithPushMaxScale(2),
+				apps.WithPushCommand("./start.sh"),
+				apps.WithPushStack("cflinuxfs3"),
+				apps.WithPushResources(&corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory:           resource.MustParse("1Gi"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory:           resource.MustParse("1Gi"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+					},
+				}),
+			),
+		},
+		"invalid memory": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--docker-image", "some-image",
+				"--memory", "lots",
+			},
+			wantErr: errors.New(`invalid memory "lots", it must be an integer followed by a unit of M, G or T`),
+		},
+		"legacy routes from manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"legacy-routes-app",
+				"--manifest", "testdata/manifest.yml",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/legacy-routes-app"),
+				apps.WithPushRoutes(createTestRoutes([]routeParts{
+					{hostname: "first", domain: "example.com"},
+					{hostname: "second", domain: "example.com"},
+				})),
+			),
+		},
+		"no-route from manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"no-route-app",
+				"--manifest", "testdata/manifest.yml",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/no-route-app"),
+			),
+		},
+		"random-route flag": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--docker-image", "some-image",
+				"--random-route",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushRandomRoute(true),
+			),
+		},
+		"variables from file and flags": {
+			namespace: "some-namespace",
+			args: []string{
+				"vars-app",
+				"--manifest", "testdata/manifest-vars.yml",
+				"--vars-file", "testdata/vars.yml",
+				"--var", "environment=prod",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/vars-app"),
+				apps.WithPushMinScale(2),
+				apps.WithPushMaxScale(2),
+				apps.WithPushEnvironmentVariables(map[string]string{"ENVIRONMENT": "prod"}),
+			),
+		},
+		"missing variables": {
+			namespace: "some-namespace",
+			args: []string{
+				"vars-app",
+				"--manifest", "testdata/manifest-vars.yml",
+				"--var", "image=vars-app",
+			},
+			wantErr: errors.New("supplied manifest file testdata/manifest-vars.yml resulted in error: failed to parse manifest testdata/manifest-vars.yml: expected to find variables: environment, instances"),
+		},
+		"malformed variable": {
+			namespace: "some-namespace",
+			args: []string{
+				"vars-app",
+				"--manifest", "testdata/manifest-vars.yml",
+				"--var", "image",
+			},
+			wantErr: errors.New("malformed variable: image"),
+		},
+		"layered manifests": {
+			namespace: "some-namespace",
+			args: []string{
+				"vars-app",
+				"-f", "testdata/manifest-vars.yml",
+				"-f", "testdata/manifest-prod.yml",
+				"--vars-file", "testdata/vars.yml",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/vars-app"),
+				apps.WithPushMinScale(5),
+				apps.WithPushMaxScale(5),
+				apps.WithPushEnvironmentVariables(map[string]string{"ENVIRONMENT": "dev"}),
+			),
+		},
+		"app from overlay manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"prod-only-app",
+				"-f", "testdata/manifest-vars.yml",
+				"-f", "testdata/manifest-prod.yml",
+				"--vars-file", "testdata/vars.yml",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/prod-only-app"),
+			),
+		},
+		"dry run": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--container-registry", "some-reg.io",
+				"--manifest", "testdata/manifest-services.yaml",
+				"--dry-run",
+			},
+			wantImagePrefix: "some-reg.io/src-some-namespace-app-name",
+			srcImageBuilder: func(dir, srcImage string, rebase bool) error {
+				t.Fatal("source shouldn't be uploaded during a dry run")
+				return nil
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushDryRun(true),
+			),
+			setup: func(t *testing.T, f *svbFake.FakeClientInterface) {
+				f.EXPECT().List(gomock.Any()).Do(func(opts ...servicebindings.ListOption) {
+					config := servicebindings.ListOptions(opts)
+					testutil.AssertEqual(t, "service instance", "some-service-instance", config.ServiceInstance())
+					testutil.AssertEqual(t, "app name", "app-name", config.AppName())
+					testutil.AssertEqual(t, "namespace", "some-namespace", config.Namespace())
+				}).Return(nil, nil)
+			},
+		},
 		"bad timeout": {
 			namespace: "some-namespace",
 			args: []string{
@@ -409,6 +819,13 @@ func TestPushCommand(t *testing.T) {
 			fakeApps := appsfake.NewFakeClient(ctrl)
 			fakePusher := appsfake.NewFakePusher(ctrl)
 			svbClient := svbFake.NewFakeClientInterface(ctrl)
+			fakeBuildpacks := bpfake.NewFakeClient(ctrl)
+
+			fakeBuildpacks.
+				EXPECT().
+				Stacks(gomock.Any()).
+				Return([]string{"cflinuxfs3"}, nil).
+				AnyTimes()
 
 			fakePusher.
 				EXPECT().
@@ -429,6 +846,16 @@ func TestPushCommand(t *testing.T) {
 					testutil.AssertEqual(t, "no start", expectOpts.NoStart(), actualOpts.NoStart())
 					testutil.AssertEqual(t, "routes", expectOpts.Routes(), actualOpts.Routes())
 					testutil.AssertEqual(t, "health check", expectOpts.HealthCheck(), actualOpts.HealthCheck())
+					testutil.AssertEqual(t, "strategy", expectOpts.Strategy(), actualOpts.Strategy())
+					testutil.AssertEqual(t, "resources", expectOpts.Resources(), actualOpts.Resources())
+					testutil.AssertEqual(t, "command", expectOpts.Command(), actualOpts.Command())
+					testutil.AssertEqual(t, "stack", expectOpts.Stack(), actualOpts.Stack())
+					testutil.AssertEqual(t, "random route", expectOpts.RandomRoute(), actualOpts.RandomRoute())
+					testutil.AssertEqual(t, "dry run", expectOpts.DryRun(), actualOpts.DryRun())
+					testutil.AssertEqual(t, "dockerfile", expectOpts.Dockerfile(), actualOpts.Dockerfile())
+					testutil.AssertEqual(t, "git", expectOpts.Git(), actualOpts.Git())
+					testutil.AssertEqual(t, "build cache", expectOpts.BuildCache(), actualOpts.BuildCache())
+					testutil.AssertEqual(t, "build timeout", expectOpts.BuildTimeout(), actualOpts.BuildTimeout())
 
 					if !strings.HasPrefix(actualOpts.SourceImage(), tc.wantImagePrefix) {
 						t.Errorf("Wanted srcImage to start with %s got: %s", tc.wantImagePrefix, actualOpts.SourceImage())
@@ -451,7 +878,12 @@ func TestPushCommand(t *testing.T) {
 				tc.setup(t, svbClient)
 			}
 
-			c := NewPushCommand(params, fakeApps, fakePusher, tc.srcImageBuilder, svbClient)
+			importDroplet := func(path, image string) error {
+				t.Fatal("no droplet should be uploaded")
+				return nil
+			}
+
+			c := NewPushCommand(params, fakeApps, fakePusher, tc.srcImageBuilder, importDroplet, svbClient, fakeBuildpacks)
 			buffer := &bytes.Buffer{}
 			c.SetOutput(buffer)
 			c.SetArgs(tc.args)
@@ -469,6 +901,174 @@ func TestPushCommand(t *testing.T) {
 	}
 }
 
+func TestPushCommand_droplet(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		args          []string
+		importDroplet func(t *testing.T, path, image string) error
+		wantErr       error
+		wantOutput    []string
+		wantPush      bool
+	}{
+		"uploads droplet": {
+			args: []string{"example-app", "--droplet", "app.tar", "--container-registry", "some-reg.io"},
+			importDroplet: func(t *testing.T, path, image string) error {
+				testutil.AssertEqual(t, "path", "app.tar", path)
+				testutil.AssertEqual(t, "image prefix", true, strings.HasPrefix(image, "some-reg.io/app-some-namespace-example-app:"))
+				return nil
+			},
+			wantOutput: []string{"Uploading droplet app.tar to some-reg.io/app-some-namespace-example-app:"},
+			wantPush:   true,
+		},
+		"dry run doesn't upload": {
+			args:       []string{"example-app", "--droplet", "app.tar", "--container-registry", "some-reg.io", "--dry-run"},
+			wantOutput: []string{"Droplet app.tar would be uploaded to some-reg.io/app-some-namespace-example-app:"},
+			wantPush:   true,
+		},
+		"upload fails": {
+			args: []string{"example-app", "--droplet", "app.tar", "--container-registry", "some-reg.io"},
+			importDroplet: func(t *testing.T, path, image string) error {
+				return errors.New("some-error")
+			},
+			wantErr: errors.New("failed to upload droplet: some-error"),
+		},
+		"droplet with buildpack": {
+			args:    []string{"example-app", "--droplet", "app.tar", "--container-registry", "some-reg.io", "--buildpack", "some-buildpack"},
+			wantErr: errors.New("--droplet can't be used with docker-image, dockerfile, buildpack, stack, build-cache or git-url"),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakePusher := appsfake.NewFakePusher(ctrl)
+			if tc.wantPush {
+				fakePusher.
+					EXPECT().
+					Push("example-app", gomock.Any()).
+					DoAndReturn(func(appName string, opts ...apps.PushOption) error {
+						actualOpts := apps.PushOptions(opts)
+						testutil.AssertEqual(t, "source image", "", actualOpts.SourceImage())
+						testutil.AssertEqual(t, "container image prefix", true, strings.HasPrefix(actualOpts.ContainerImage(), "some-reg.io/app-some-namespace-example-app:"))
+						return nil
+					})
+			}
+
+			importDroplet := func(path, image string) error {
+				if tc.importDroplet == nil {
+					t.Fatal("no droplet should be uploaded")
+				}
+				return tc.importDroplet(t, path, image)
+			}
+
+			srcImageBuilder := SrcImageBuilderFunc(func(dir, srcImage string, rebase bool) error {
+				t.Fatal("source shouldn't be uploaded with a droplet")
+				return nil
+			})
+
+			params := &config.KfParams{Namespace: "some-namespace"}
+			params.SetTargetSpaceToDefault()
+
+			c := NewPushCommand(
+				params,
+				appsfake.NewFakeClient(ctrl),
+				fakePusher,
+				srcImageBuilder,
+				importDroplet,
+				svbFake.NewFakeClientInterface(ctrl),
+				bpfake.NewFakeClient(ctrl),
+			)
+			buffer := &bytes.Buffer{}
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.wantOutput)
+		})
+	}
+}
+
+func TestStageCommand(t *testing.T) {
+	t.Parallel()
+
+	space := &v1alpha1.Space{}
+	space.Spec.BuildpackBuild.ContainerRegistry = "space-reg.io"
+
+	for tn, tc := range map[string]struct {
+		args       []string
+		setup      func(t *testing.T, fakeStager *appsfake.FakeStager)
+		wantErr    error
+		wantOutput []string
+	}{
+		"stages app": {
+			args: []string{"example-app", "--buildpack", "some-buildpack", "--build-timeout", "10m"},
+			setup: func(t *testing.T, fakeStager *appsfake.FakeStager) {
+				fakeStager.
+					EXPECT().
+					Stage("example-app", space, gomock.Any()).
+					DoAndReturn(func(appName string, _ *v1alpha1.Space, opts ...apps.PushOption) (*v1alpha1.Source, error) {
+						actualOpts := apps.PushOptions(opts)
+						testutil.AssertEqual(t, "namespace", "some-namespace", actualOpts.Namespace())
+						testutil.AssertEqual(t, "container registry", "space-reg.io", actualOpts.ContainerRegistry())
+						testutil.AssertEqual(t, "buildpack", "some-buildpack", actualOpts.Buildpack())
+						testutil.AssertEqual(t, "build timeout", 10*time.Minute, actualOpts.BuildTimeout())
+						testutil.AssertEqual(t, "source image prefix", true, strings.HasPrefix(actualOpts.SourceImage(), "space-reg.io/src-some-namespace-example-app"))
+
+						source := &v1alpha1.Source{}
+						source.Name = "example-app-stage-abc"
+						source.Status.Image = "some-image"
+						return source, nil
+					})
+			},
+			wantOutput: []string{`"example-app" successfully staged as example-app-stage-abc, image: some-image`},
+		},
+		"stage fails": {
+			args: []string{"example-app"},
+			setup: func(t *testing.T, fakeStager *appsfake.FakeStager) {
+				fakeStager.
+					EXPECT().
+					Stage(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+			wantErr: errors.New("some-error"),
+		},
+		"deploy flags aren't available": {
+			args:    []string{"example-app", "--instances", "3"},
+			wantErr: errors.New("unknown flag: --instances"),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeStager := appsfake.NewFakeStager(ctrl)
+			if tc.setup != nil {
+				tc.setup(t, fakeStager)
+			}
+
+			srcImageBuilder := SrcImageBuilderFunc(func(dir, srcImage string, rebase bool) error {
+				return nil
+			})
+
+			params := &config.KfParams{
+				Namespace:   "some-namespace",
+				TargetSpace: space,
+			}
+
+			c := NewStageCommand(params, fakeStager, srcImageBuilder, bpfake.NewFakeClient(ctrl))
+			buffer := &bytes.Buffer{}
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.wantOutput)
+		})
+	}
+}
+
 func createTestRoutes(routes []routeParts) []v1alpha1.RouteSpecFields {
 	newRoutes := []v1alpha1.RouteSpecFields{}
 	for _, route := range routes {
@@ -0,0 +1,109 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewRebaseCommand creates a command that moves apps onto the latest run
+// image of their stack without rebuilding them.
+func NewRebaseCommand(p *config.KfParams, client apps.Client) *cobra.Command {
+	var allApps bool
+
+	cmd := &cobra.Command{
+		Use:   "rebase [APP_NAME] [--all-apps]",
+		Short: "Move an app onto the latest run image of its stack without rebuilding it",
+		Long: `
+	Rebase replaces the base layers of an app built with buildpacks with the
+	latest run image of its stack, then deploys the new image. The app's
+	source isn't built again so it's a quick way to pick up fixes to the base
+	image. Restaging a rebased app builds it from its source again.
+
+	Apps deployed from container images or Dockerfiles can't be rebased.
+	`,
+		Example: `
+  kf rebase myapp
+  kf rebase --all-apps # rebase every app in the space
+  `,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			switch {
+			case allApps && len(args) > 0:
+				return errors.New("an app name can't be used with --all-apps")
+			case !allApps && len(args) == 0:
+				return errors.New("an app name or --all-apps is required")
+			}
+
+			cmd.SilenceUsage = true
+
+			if !allApps {
+				appName := args[0]
+				if err := client.Rebase(p.Namespace, appName); err != nil {
+					return fmt.Errorf("failed to rebase app: %s", err)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Rebasing app %s\n", appName)
+				return nil
+			}
+
+			appList, err := client.List(p.Namespace)
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, app := range appList {
+				if _, err := apps.RebaseSource(&app); err != nil {
+					fmt.Fprintf(cmd.OutOrStdout(), "Skipping app %s: %s\n", app.Name, err)
+					continue
+				}
+
+				if err := client.Rebase(p.Namespace, app.Name); err != nil {
+					fmt.Fprintf(cmd.OutOrStdout(), "Failed to rebase app %s: %s\n", app.Name, err)
+					failed++
+					continue
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Rebasing app %s\n", app.Name)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("failed to rebase %d app(s)", failed)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&allApps,
+		"all-apps",
+		false,
+		"Rebase every app in the space that was built with buildpacks",
+	)
+
+	return cmd
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestRebase(t *testing.T) {
+	t.Parallel()
+
+	buildpackApp := v1alpha1.App{}
+	buildpackApp.Name = "buildpack-app"
+	buildpackApp.Spec.Source.BuildpackBuild.Source = "some-source"
+	buildpackApp.Status.Image = "some-image"
+
+	containerApp := v1alpha1.App{}
+	containerApp.Name = "container-app"
+	containerApp.Spec.Source.ContainerImage.Image = "nginx"
+	containerApp.Status.Image = "nginx"
+
+	cases := map[string]struct {
+		Namespace       string
+		Args            []string
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fake *fake.FakeClient)
+	}{
+		"rebases app": {
+			Namespace: "default",
+			Args:      []string{"my-app"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Rebase("default", "my-app")
+			},
+			ExpectedStrings: []string{"Rebasing app my-app"},
+		},
+		"rebase fails": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to rebase app: some-error"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Rebase(gomock.Any(), gomock.Any()).Return(errors.New("some-error"))
+			},
+		},
+		"all apps": {
+			Namespace: "default",
+			Args:      []string{"--all-apps"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().List("default").Return([]v1alpha1.App{buildpackApp, containerApp}, nil)
+				fake.EXPECT().Rebase("default", "buildpack-app")
+			},
+			ExpectedStrings: []string{
+				"Rebasing app buildpack-app",
+				"Skipping app container-app: app container-app wasn't built with buildpacks",
+			},
+		},
+		"all apps with failures": {
+			Namespace:   "default",
+			Args:        []string{"--all-apps"},
+			ExpectedErr: errors.New("failed to rebase 1 app(s)"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().List("default").Return([]v1alpha1.App{buildpackApp}, nil)
+				fake.EXPECT().Rebase("default", "buildpack-app").Return(errors.New("some-error"))
+			},
+		},
+		"app name and all apps": {
+			Namespace:   "default",
+			Args:        []string{"my-app", "--all-apps"},
+			ExpectedErr: errors.New("an app name can't be used with --all-apps"),
+		},
+		"no app name": {
+			Namespace:   "default",
+			Args:        []string{},
+			ExpectedErr: errors.New("an app name or --all-apps is required"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := NewRebaseCommand(p, fake)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+			testutil.AssertEqual(t, "SilenceUsage", true, cmd.SilenceUsage)
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewRevisionsCommand creates a command that lists the deployment history of
+// an app.
+func NewRevisionsCommand(p *config.KfParams, client apps.Client) *cobra.Command {
+	return &cobra.Command{
+		Use:     "revisions APP_NAME",
+		Short:   "List the recent successful deployments of an app",
+		Example: `  kf revisions myapp`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			appName := args[0]
+			app, err := client.Get(p.Namespace, appName)
+			if err != nil {
+				return fmt.Errorf("failed to get app: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Getting revisions of app %s in namespace: %s\n", appName, p.Namespace)
+			fmt.Fprintln(cmd.OutOrStdout())
+
+			var current int
+			if latest := app.Status.LatestRevision(); latest != nil {
+				current = latest.Number
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 8, 4, 2, ' ', tabwriter.StripEscape)
+			fmt.Fprintln(w, "REVISION\tDEPLOYED\tBY\tSOURCE\tIMAGE\tTEMPLATE")
+
+			// Newest first.
+			for i := len(app.Status.Revisions) - 1; i >= 0; i-- {
+				revision := app.Status.Revisions[i]
+
+				number := fmt.Sprintf("%d", revision.Number)
+				if revision.Number == current {
+					number += " (current)"
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					number,
+					revision.DeployedAt.UTC().Format("2006-01-02 15:04:05"),
+					revision.DeployedBy,
+					revision.SourceName,
+					revision.Image,
+					revision.TemplateHash,
+				)
+			}
+
+			return w.Flush()
+		},
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewRollbackCommand creates a command that re-deploys a previous successful
+// deployment of an app.
+func NewRollbackCommand(p *config.KfParams, client apps.Client) *cobra.Command {
+	var to int
+
+	cmd := &cobra.Command{
+		Use:   "rollback APP_NAME [--to REVISION]",
+		Short: "Re-deploy the image of a previous deployment of an app without rebuilding it",
+		Long: `
+	Rollback re-deploys the container image of a previous successful deployment
+	of an app and waits for it to be ready. The app's current environment and
+	settings are kept.
+
+	Use kf revisions to see the deployments that can be rolled back to.
+	`,
+		Example: `
+  kf rollback myapp # roll back to the deployment before the current one
+  kf rollback myapp --to 3
+  `,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			if to < 0 {
+				return fmt.Errorf("invalid revision %d", to)
+			}
+
+			appName := args[0]
+			app, revision, err := client.Rollback(p.Namespace, appName, to)
+			if err != nil {
+				return fmt.Errorf("failed to roll back app: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Rolling back app %s to revision %d (%s)\n", appName, revision.Number, revision.Image)
+
+			return client.DeployLogs(
+				cmd.OutOrStdout(),
+				appName,
+				app.ResourceVersion,
+				p.Namespace,
+				app.Spec.Instances.Stopped,
+			)
+		},
+	}
+
+	cmd.Flags().IntVar(
+		&to,
+		"to",
+		0,
+		"Revision to roll back to, defaults to the revision before the current one",
+	)
+
+	return cmd
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Namespace       string
+		Args            []string
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fake *fake.FakeClient)
+	}{
+		"rolls back to previous revision": {
+			Namespace: "default",
+			Args:      []string{"my-app"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				app := &v1alpha1.App{}
+				app.ResourceVersion = "some-version"
+				fake.EXPECT().
+					Rollback("default", "my-app", 0).
+					Return(app, &v1alpha1.AppRevision{Number: 4, Image: "gcr.io/my-app:4"}, nil)
+				fake.EXPECT().DeployLogs(gomock.Any(), "my-app", "some-version", "default", false)
+			},
+			ExpectedStrings: []string{"Rolling back app my-app to revision 4 (gcr.io/my-app:4)"},
+		},
+		"rolls back to given revision": {
+			Namespace: "default",
+			Args:      []string{"my-app", "--to", "2"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Rollback("default", "my-app", 2).
+					Return(&v1alpha1.App{}, &v1alpha1.AppRevision{Number: 2, Image: "gcr.io/my-app:2"}, nil)
+				fake.EXPECT().DeployLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			ExpectedStrings: []string{"revision 2"},
+		},
+		"invalid revision": {
+			Namespace:   "default",
+			Args:        []string{"my-app", "--to", "-1"},
+			ExpectedErr: errors.New("invalid revision -1"),
+		},
+		"no app name": {
+			Namespace:   "default",
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"rollback fails": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to roll back app: some-error"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Rollback(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, errors.New("some-error"))
+			},
+		},
+		"deploy fails": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("deployment failed"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Rollback(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&v1alpha1.App{}, &v1alpha1.AppRevision{Number: 2}, nil)
+				fake.EXPECT().
+					DeployLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("deployment failed"))
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := NewRollbackCommand(p, fake)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+			testutil.AssertEqual(t, "SilenceUsage", true, cmd.SilenceUsage)
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builds
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/sources"
+	"github.com/spf13/cobra"
+)
+
+// NewCancelBuildCommand allows users to cancel a running build.
+func NewCancelBuildCommand(p *config.KfParams, client sources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-build BUILD_NAME",
+		Short: "Cancel the given build",
+		Long: `Cancel a running build and mark it as cancelled.
+
+The build is stopped by the kf controller, any kf push waiting on the build
+exits once it's marked cancelled.`,
+		Example: `
+  kf cancel-build myapp-abc123
+  `,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			buildName := args[0]
+
+			if err := client.Cancel(p.Namespace, buildName); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Build %q cancelled\n", buildName)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	kfbuild "github.com/google/kf/pkg/client/build/clientset/versioned/typed/build/v1alpha1"
 	kf "github.com/google/kf/pkg/client/clientset/versioned/typed/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/secrets"
 	"github.com/google/kf/pkg/kf/services"
@@ -38,6 +39,7 @@ import (
 	"gopkg.in/yaml.v2"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	k8sclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -192,6 +194,26 @@ func GetBuildClient(p *KfParams) build.BuildV1alpha1Interface {
 	return client
 }
 
+// GetKfBuildClient returns a Build interface from Kf's generated clientset.
+func GetKfBuildClient(p *KfParams) kfbuild.BuildV1alpha1Interface {
+	config := getRestConfig(p)
+	client, err := kfbuild.NewForConfig(config)
+	if err != nil {
+		log.Fatalf("failed to create a Build client: %s", err)
+	}
+	return client
+}
+
+// GetDynamicClient returns a dynamic K8s client.
+func GetDynamicClient(p *KfParams) dynamic.Interface {
+	config := getRestConfig(p)
+	c, err := dynamic.NewForConfig(config)
+	if err != nil {
+		log.Fatalf("failed to create a dynamic client: %s", err)
+	}
+	return c
+}
+
 // GetKubernetes returns a K8s client.
 func GetKubernetes(p *KfParams) k8sclient.Interface {
 	config := getRestConfig(p)
@@ -0,0 +1,82 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domains
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/domains"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewCreateDomainCommand creates a command to create a shared Domain.
+func NewCreateDomainCommand(
+	p *config.KfParams,
+	c domains.Client,
+) *cobra.Command {
+	var (
+		isDefault bool
+		tlsSecret string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create-domain DOMAIN [--default] [--tls-secret SECRET_NAME]",
+		Short: "Create a domain that routes can be created on in every space",
+		Example: `
+  kf create-domain example.com
+  kf create-domain example.com --default
+  kf create-domain example.com --tls-secret example-com-tls
+  `,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			domain := &v1alpha1.Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: args[0]},
+				Spec:       v1alpha1.DomainSpec{Default: isDefault},
+			}
+
+			if tlsSecret != "" {
+				domain.Spec.TLS = &v1alpha1.DomainTLS{SecretName: tlsSecret}
+			}
+
+			if _, err := c.Create(domain); err != nil {
+				return fmt.Errorf("failed to create Domain: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Created shared domain %s\n", domain.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&isDefault,
+		"default",
+		false,
+		"Use the domain as the default domain of spaces created without one.",
+	)
+
+	cmd.Flags().StringVar(
+		&tlsSecret,
+		"tls-secret",
+		"",
+		"Name of a TLS Secret in the istio-system namespace to serve the domain over HTTPS with.",
+	)
+
+	return cmd
+}
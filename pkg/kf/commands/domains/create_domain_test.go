@@ -0,0 +1,116 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domains_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/domains"
+	"github.com/google/kf/pkg/kf/domains/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestCreateDomain(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		Args   []string
+		Setup  func(t *testing.T, fake *fake.FakeClient)
+		Assert func(t *testing.T, buffer *bytes.Buffer, err error)
+	}{
+		"wrong number of args": {
+			Args: []string{"example.com", "extra"},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("accepts 1 arg(s), received 2"), err)
+			},
+		},
+		"creating domain fails": {
+			Args: []string{"example.com"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Create(gomock.Any()).Return(nil, errors.New("some-error"))
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("failed to create Domain: some-error"), err)
+			},
+		},
+		"creates shared domain": {
+			Args: []string{"example.com"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Create(gomock.Any()).DoAndReturn(func(domain *v1alpha1.Domain) (*v1alpha1.Domain, error) {
+					testutil.AssertEqual(t, "name", "example.com", domain.Name)
+					testutil.AssertEqual(t, "private", false, domain.Spec.IsPrivate())
+					testutil.AssertEqual(t, "default", false, domain.Spec.Default)
+					testutil.AssertEqual(t, "tls", (*v1alpha1.DomainTLS)(nil), domain.Spec.TLS)
+					return domain, nil
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertContainsAll(t, buffer.String(), []string{"Created shared domain example.com"})
+			},
+		},
+		"creates default domain": {
+			Args: []string{"example.com", "--default"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Create(gomock.Any()).DoAndReturn(func(domain *v1alpha1.Domain) (*v1alpha1.Domain, error) {
+					testutil.AssertEqual(t, "default", true, domain.Spec.Default)
+					return domain, nil
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"creates domain with TLS": {
+			Args: []string{"example.com", "--tls-secret", "example-com-tls"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Create(gomock.Any()).DoAndReturn(func(domain *v1alpha1.Domain) (*v1alpha1.Domain, error) {
+					testutil.AssertEqual(t, "tls", &v1alpha1.DomainTLS{SecretName: "example-com-tls"}, domain.Spec.TLS)
+					return domain, nil
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			var buffer bytes.Buffer
+			cmd := domains.NewCreateDomainCommand(&config.KfParams{}, fake)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+
+			if tc.Assert != nil {
+				tc.Assert(t, &buffer, gotErr)
+			}
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domains
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/domains"
+	"github.com/spf13/cobra"
+)
+
+// NewDeleteDomainCommand creates a command to delete a Domain.
+func NewDeleteDomainCommand(
+	p *config.KfParams,
+	c domains.Client,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete-domain DOMAIN",
+		Short: "Delete a domain",
+		Long: `Delete a domain.
+
+Existing routes on the domain are kept, but routes on it are no longer
+restricted to the spaces that owned it.`,
+		Example: `
+  kf delete-domain example.com
+  `,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if err := c.Delete(args[0]); err != nil {
+				return fmt.Errorf("failed to delete Domain: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Deleted domain %s\n", args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domains_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/domains"
+	"github.com/google/kf/pkg/kf/domains/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestDeleteDomain(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		Args   []string
+		Setup  func(t *testing.T, fake *fake.FakeClient)
+		Assert func(t *testing.T, buffer *bytes.Buffer, err error)
+	}{
+		"wrong number of args": {
+			Args: []string{},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("accepts 1 arg(s), received 0"), err)
+			},
+		},
+		"deleting domain fails": {
+			Args: []string{"example.com"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Delete("example.com").Return(errors.New("some-error"))
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("failed to delete Domain: some-error"), err)
+			},
+		},
+		"deletes domain": {
+			Args: []string{"example.com"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Delete("example.com")
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertContainsAll(t, buffer.String(), []string{"Deleted domain example.com"})
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			var buffer bytes.Buffer
+			cmd := domains.NewDeleteDomainCommand(&config.KfParams{}, fake)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+
+			if tc.Assert != nil {
+				tc.Assert(t, &buffer, gotErr)
+			}
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domains
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/describe"
+	"github.com/google/kf/pkg/kf/domains"
+	"github.com/spf13/cobra"
+)
+
+// NewDomainsCommand creates a command to list Domains.
+func NewDomainsCommand(
+	p *config.KfParams,
+	c domains.Client,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "domains",
+		Short: "List domains",
+		Example: `
+  kf domains
+  `,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			domains, err := c.List()
+			if err != nil {
+				return fmt.Errorf("failed to fetch Domains: %s", err)
+			}
+
+			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
+				fmt.Fprintln(w, "Name\tStatus\tOwner\tAllowed Spaces\tDefault")
+				for _, domain := range domains {
+					status := "shared"
+					if domain.Spec.IsPrivate() {
+						status = "private"
+					}
+
+					fmt.Fprintf(
+						w,
+						"%s\t%s\t%s\t%s\t%t\n",
+						domain.Name,
+						status,
+						domain.Spec.OwnerSpace,
+						strings.Join(domain.Spec.AllowedSpaces, ", "),
+						domain.Spec.Default,
+					)
+				}
+			})
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domains_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/domains"
+	"github.com/google/kf/pkg/kf/domains/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDomains(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		Args   []string
+		Setup  func(t *testing.T, fake *fake.FakeClient)
+		Assert func(t *testing.T, buffer *bytes.Buffer, err error)
+	}{
+		"wrong number of args": {
+			Args: []string{"example.com"},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("accepts 0 arg(s), received 1"), err)
+			},
+		},
+		"listing domains fails": {
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().List().Return(nil, errors.New("some-error"))
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("failed to fetch Domains: some-error"), err)
+			},
+		},
+		"lists domains": {
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().List().Return([]v1alpha1.Domain{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "example.com"},
+						Spec:       v1alpha1.DomainSpec{Default: true},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "apps.example.com"},
+						Spec: v1alpha1.DomainSpec{
+							OwnerSpace:    "my-space",
+							AllowedSpaces: []string{"space-a", "space-b"},
+						},
+					},
+				}, nil)
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertContainsAll(t, buffer.String(), []string{
+					"example.com       shared                               true",
+					"apps.example.com  private  my-space  space-a, space-b  false",
+				})
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			var buffer bytes.Buffer
+			cmd := domains.NewDomainsCommand(&config.KfParams{}, fake)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+
+			if tc.Assert != nil {
+				tc.Assert(t, &buffer, gotErr)
+			}
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -62,6 +62,7 @@ func NewKfCommand() *cobra.Command {
 			Message: "App Management",
 			Commands: []*cobra.Command{
 				InjectPush(p),
+				InjectStage(p),
 				InjectDelete(p),
 				InjectApps(p),
 				InjectGetApp(p),
@@ -69,6 +70,12 @@ func NewKfCommand() *cobra.Command {
 				InjectStop(p),
 				InjectRestart(p),
 				InjectRestage(p),
+				InjectClearBuildCache(p),
+				InjectRevisions(p),
+				InjectRollback(p),
+				InjectRebase(p),
+				InjectDownloadDroplet(p),
+				InjectPromote(p),
 				InjectScale(p),
 				InjectLogs(p),
 				InjectProxy(p),
@@ -99,6 +106,23 @@ func NewKfCommand() *cobra.Command {
 				InjectUnmapRoute(p),
 			},
 		},
+		{
+			Message: "Domains",
+			Commands: []*cobra.Command{
+				InjectDomains(p),
+				InjectCreateDomain(p),
+				InjectCreatePrivateDomain(p),
+				InjectDeleteDomain(p),
+			},
+		},
+		{
+			Message: "Network Policies",
+			Commands: []*cobra.Command{
+				InjectNetworkPolicies(p),
+				InjectAddNetworkPolicy(p),
+				InjectRemoveNetworkPolicy(p),
+			},
+		},
 		{
 			Message: "Quotas",
 			Commands: []*cobra.Command{
@@ -116,6 +140,8 @@ func NewKfCommand() *cobra.Command {
 				InjectGetService(p),
 				InjectListServices(p),
 				InjectMarketplace(p),
+				InjectCreateUserProvidedService(p),
+				InjectUpdateUserProvidedService(p),
 			},
 		},
 		{
@@ -135,6 +161,9 @@ func NewKfCommand() *cobra.Command {
 				InjectCreateSpace(p),
 				InjectDeleteSpace(p),
 				InjectConfigSpace(p),
+				InjectSpaceUsers(p),
+				InjectSetSpaceRole(p),
+				InjectUnsetSpaceRole(p),
 			},
 		},
 		{
@@ -142,6 +171,15 @@ func NewKfCommand() *cobra.Command {
 			Commands: []*cobra.Command{
 				InjectBuilds(p),
 				InjectBuildLogs(p),
+				InjectCancelBuild(p),
+			},
+		},
+		{
+			Message: "Tasks",
+			Commands: []*cobra.Command{
+				InjectRunTask(p),
+				InjectTasks(p),
+				InjectTerminateTask(p),
 			},
 		},
 		{
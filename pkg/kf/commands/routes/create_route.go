@@ -32,16 +32,20 @@ func NewCreateRouteCommand(
 	p *config.KfParams,
 	c routes.Client,
 ) *cobra.Command {
-	var hostname, urlPath string
+	var (
+		hostname, urlPath string
+		httpsRedirect     bool
+	)
 
 	cmd := &cobra.Command{
-		Use:   "create-route DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "create-route DOMAIN [--hostname HOSTNAME] [--path PATH] [--https-redirect]",
 		Short: "Create a route",
 		Example: `
   # Using namespace (instead of SPACE)
   kf create-route example.com --hostname myapp # myapp.example.com
   kf create-route --namespace myspace example.com --hostname myapp # myapp.example.com
   kf create-route example.com --hostname myapp --path /mypath # myapp.example.com/mypath
+  kf create-route example.com --hostname myapp --https-redirect # redirects http://myapp.example.com to https
 
   # [DEPRECATED] Using SPACE to match 'cf'
   kf create-route myspace example.com --hostname myapp # myapp.example.com
@@ -92,6 +96,7 @@ Instead use the --namespace flag.`)
 						Domain:   domain,
 						Path:     urlPath,
 					},
+					HTTPSRedirect: httpsRedirect,
 				},
 			}
 
@@ -114,6 +119,12 @@ Instead use the --namespace flag.`)
 		"",
 		"URL Path for the route",
 	)
+	cmd.Flags().BoolVar(
+		&httpsRedirect,
+		"https-redirect",
+		false,
+		"Redirect HTTP requests for the route's host to HTTPS, the domain must have TLS configured",
+	)
 
 	return cmd
 }
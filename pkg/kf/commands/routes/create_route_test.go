@@ -147,6 +147,34 @@ func TestCreateRoute(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"creates route with HTTPS redirect": {
+			Args:      []string{"example.com", "--hostname=some-hostname", "--https-redirect"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().Create(gomock.Any(),
+					&v1alpha1.Route{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "Route",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "some-space",
+							Name:      v1alpha1.GenerateRouteName("some-hostname", "example.com", "/"),
+						},
+						Spec: v1alpha1.RouteSpec{
+							RouteSpecFields: v1alpha1.RouteSpecFields{
+								Hostname: "some-hostname",
+								Domain:   "example.com",
+								Path:     "/",
+							},
+							HTTPSRedirect: true,
+						},
+					},
+				)
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
@@ -33,15 +33,19 @@ func NewMapRouteCommand(
 	routesClient routes.Client,
 	appsClient apps.Client,
 ) *cobra.Command {
-	var hostname, urlPath string
+	var (
+		hostname, urlPath string
+		weight            int
+	)
 
 	cmd := &cobra.Command{
-		Use:   "map-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "map-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH] [--weight WEIGHT]",
 		Short: "Map a route to an app",
 		Example: `
   kf map-route myapp example.com --hostname myapp # myapp.example.com
   kf map-route --namespace myspace myapp example.com --hostname myapp # myapp.example.com
   kf map-route myapp example.com --hostname myapp --path /mypath # myapp.example.com/mypath
+  kf map-route myapp-v2 example.com --hostname myapp --weight 20 # send 20% of myapp.example.com to myapp-v2
   `,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -57,9 +61,23 @@ func NewMapRouteCommand(
 				return fmt.Errorf("failed to fetch app: %s", err)
 			}
 
+			var appWeights map[string]int
+			if cmd.Flags().Changed("weight") {
+				appWeights = map[string]int{appName: weight}
+			}
+
 			merger := routes.Merger(func(newR, oldR *v1alpha1.Route) *v1alpha1.Route {
 				newR.ObjectMeta = *oldR.ObjectMeta.DeepCopy()
 				newR.Spec.AppNames = append(oldR.Spec.AppNames, appName)
+
+				weights := make(map[string]int)
+				for name, w := range oldR.Spec.AppWeights {
+					weights[name] = w
+				}
+				for name, w := range appWeights {
+					weights[name] = w
+				}
+				newR.Spec.AppWeights = weights
 				return newR
 			})
 
@@ -77,7 +95,8 @@ func NewMapRouteCommand(
 					),
 				},
 				Spec: v1alpha1.RouteSpec{
-					AppNames: []string{appName},
+					AppNames:   []string{appName},
+					AppWeights: appWeights,
 					RouteSpecFields: v1alpha1.RouteSpecFields{
 						Hostname: hostname,
 						Domain:   domain,
@@ -105,6 +124,12 @@ func NewMapRouteCommand(
 		"",
 		"URL Path for the route",
 	)
+	cmd.Flags().IntVar(
+		&weight,
+		"weight",
+		0,
+		"Percentage of the route's traffic the app should receive (0-100), apps without a weight split the rest",
+	)
 
 	return cmd
 }
@@ -136,6 +136,50 @@ func TestMapRoute(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"weight": {
+			Args:      []string{"some-app", "example.com", "--weight=20"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&v1alpha1.App{}, nil)
+				routesfake.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, newR *v1alpha1.Route, m clientroutes.Merger) {
+					testutil.AssertEqual(t, "Spec.AppWeights", map[string]int{"some-app": 20}, newR.Spec.AppWeights)
+
+					oldR := v1alpha1.Route{
+						Spec: v1alpha1.RouteSpec{
+							AppNames:   []string{"some-other-app", "some-app"},
+							AppWeights: map[string]int{"some-other-app": 80, "some-app": 50},
+						},
+					}
+					m(newR, &oldR)
+					testutil.AssertEqual(t, "weights", map[string]int{"some-other-app": 80, "some-app": 20}, newR.Spec.AppWeights)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"keeps existing weights": {
+			Args:      []string{"some-app", "example.com"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&v1alpha1.App{}, nil)
+				routesfake.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, newR *v1alpha1.Route, m clientroutes.Merger) {
+					testutil.AssertEqual(t, "Spec.AppWeights", map[string]int(nil), newR.Spec.AppWeights)
+
+					oldR := v1alpha1.Route{
+						Spec: v1alpha1.RouteSpec{
+							AppNames:   []string{"some-other-app"},
+							AppWeights: map[string]int{"some-other-app": 80},
+						},
+					}
+					m(newR, &oldR)
+					testutil.AssertEqual(t, "weights", map[string]int{"some-other-app": 80}, newR.Spec.AppWeights)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
 		"don't re-add app": {
 			Args:      []string{"some-app", "example.com", "--hostname=some-host", "--path=some-path"},
 			Namespace: "some-space",
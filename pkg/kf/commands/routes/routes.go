@@ -19,6 +19,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/utils"
 	"github.com/google/kf/pkg/kf/routes"
@@ -55,16 +56,34 @@ func NewRoutesCommand(
 			fmt.Fprintln(cmd.OutOrStdout())
 
 			w := tabwriter.NewWriter(cmd.OutOrStdout(), 8, 4, 2, ' ', tabwriter.StripEscape)
-			fmt.Fprintln(w, "HOST\tDOMAIN\tPATH\tAPPS")
+			fmt.Fprintln(w, "HOST\tDOMAIN\tPATH\tAPPS\tREADY\tREASON")
 			for _, route := range routes {
 				apps := strings.Join(route.Spec.AppNames, ", ")
+				if len(route.Spec.AppNames) > 1 {
+					percents := route.Spec.TrafficPercents()
+
+					var split []string
+					for _, appName := range route.Spec.AppNames {
+						split = append(split, fmt.Sprintf("%s (%d%%)", appName, percents[appName]))
+					}
+					apps = strings.Join(split, ", ")
+				}
+
+				var status, reason string
+				if cond := route.Status.GetCondition(v1alpha1.RouteConditionReady); cond != nil {
+					status = string(cond.Status)
+					reason = cond.Reason
+				}
+
 				fmt.Fprintf(
 					w,
-					"%s\t%s\t%s\t%s\n",
+					"%s\t%s\t%s\t%s\t%s\t%s\n",
 					route.Spec.Hostname,
 					route.Spec.Domain,
 					route.Spec.Path,
 					apps,
+					status,
+					reason,
 				)
 			}
 
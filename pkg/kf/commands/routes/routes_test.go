@@ -71,7 +71,28 @@ func TestRoutes(t *testing.T) {
 				}, nil)
 			},
 			BufferF: func(t *testing.T, buffer *bytes.Buffer) {
-				testutil.AssertContainsAll(t, buffer.String(), []string{"host-1", "example.com", "/path1", "app-1, app-2"})
+				testutil.AssertContainsAll(t, buffer.String(), []string{"host-1", "example.com", "/path1", "app-1 (50%), app-2 (50%)"})
+			},
+		},
+		"display route status": {
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, fakeRoute *fakeroute.FakeClient) {
+				route := v1alpha1.Route{
+					Spec: v1alpha1.RouteSpec{
+						RouteSpecFields: v1alpha1.RouteSpecFields{
+							Hostname: "host-1",
+							Domain:   "example.com",
+						},
+						AppNames: []string{"app-1"},
+					},
+				}
+				route.Status.InitializeConditions()
+				route.Status.MarkHostnameReservedByOtherSpace("host-1", "example.com", "other-space")
+
+				fakeRoute.EXPECT().List(gomock.Any()).Return([]v1alpha1.Route{route}, nil)
+			},
+			BufferF: func(t *testing.T, buffer *bytes.Buffer) {
+				testutil.AssertContainsAll(t, buffer.String(), []string{"READY", "REASON", "False", "ReservedByOtherSpace"})
 			},
 		},
 	} {
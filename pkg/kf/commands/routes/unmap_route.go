@@ -66,6 +66,7 @@ func NewUnmapRouteCommand(
 					r.Spec.AppNames[:idx],
 					r.Spec.AppNames[idx+1:]...,
 				)
+				delete(r.Spec.AppWeights, appName)
 				return nil
 			})
 
@@ -0,0 +1,117 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/spf13/cobra"
+)
+
+// NewCreateUserProvidedServiceCommand allows users to create service instances
+// that exist outside of the service catalog.
+func NewCreateUserProvidedServiceCommand(p *config.KfParams, client services.UserProvidedClientInterface) *cobra.Command {
+	var (
+		credentialsAsJSON string
+		tags              string
+		syslogDrainURL    string
+		routeServiceURL   string
+	)
+
+	createCmd := &cobra.Command{
+		Use:     "create-user-provided-service SERVICE_INSTANCE [-p CREDENTIALS] [-t TAGS] [-l SYSLOG_DRAIN_URL] [-r ROUTE_SERVICE_URL]",
+		Aliases: []string{"cups"},
+		Short:   "Create a standalone service instance from existing credentials",
+		Example: `
+  kf create-user-provided-service my-db -p '{"username":"admin","password":"pass"}'
+  kf create-user-provided-service my-db -p ~/workspace/tmp/credentials.json -t "mysql,database"
+  kf create-user-provided-service my-drain -l syslog://example.com`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			cmd.SilenceUsage = true
+
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			credentials, err := services.ParseJSONOrFile(credentialsAsJSON)
+			if err != nil {
+				return err
+			}
+
+			if err := client.CreateUserProvidedService(
+				instanceName,
+				services.WithCreateUserProvidedServiceNamespace(p.Namespace),
+				services.WithCreateUserProvidedServiceCredentials(credentials),
+				services.WithCreateUserProvidedServiceTags(parseTags(tags)),
+				services.WithCreateUserProvidedServiceSyslogDrainURL(syslogDrainURL),
+				services.WithCreateUserProvidedServiceRouteServiceURL(routeServiceURL)); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Created user-provided service %s\n", instanceName)
+			return nil
+		},
+	}
+
+	createCmd.Flags().StringVarP(
+		&credentialsAsJSON,
+		"parameters",
+		"p",
+		"{}",
+		"Valid JSON object containing credentials, provided in-line or in a file.")
+
+	createCmd.Flags().StringVarP(
+		&tags,
+		"tags",
+		"t",
+		"",
+		"User provided tags, separated by commas.")
+
+	createCmd.Flags().StringVarP(
+		&syslogDrainURL,
+		"syslog-drain-url",
+		"l",
+		"",
+		"URL to which logs for bound applications will be streamed.")
+
+	createCmd.Flags().StringVarP(
+		&routeServiceURL,
+		"route-service-url",
+		"r",
+		"",
+		"URL to which requests for bound routes will be forwarded. Scheme must be https.")
+
+	return createCmd
+}
+
+// parseTags splits a comma separated list of tags, an empty string results in
+// no tags.
+func parseTags(tags string) []string {
+	var out []string
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			out = append(out, tag)
+		}
+	}
+
+	return out
+}
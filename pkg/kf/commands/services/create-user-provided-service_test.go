@@ -0,0 +1,91 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	servicescmd "github.com/google/kf/pkg/kf/commands/services"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/google/kf/pkg/kf/services/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestNewCreateUserProvidedServiceCommand(t *testing.T) {
+
+	cases := map[string]userProvidedServiceTest{
+		"too few params": {
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"empty namespace": {
+			Args:        []string{"mydb"},
+			ExpectedErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"command params get passed correctly": {
+			Args: []string{"mydb",
+				`--parameters={"username":"admin"}`,
+				"--tags=sql, oracle",
+				"--syslog-drain-url=syslog://logs.example.com",
+				"--route-service-url=https://proxy.example.com",
+			},
+			Namespace: "custom-ns",
+			Setup: func(t *testing.T, f *fake.FakeUserProvidedClientInterface) {
+				f.EXPECT().CreateUserProvidedService("mydb", gomock.Any()).Do(func(instance string, opts ...services.CreateUserProvidedServiceOption) {
+					config := services.CreateUserProvidedServiceOptions(opts)
+					testutil.AssertEqual(t, "credentials", map[string]interface{}{"username": "admin"}, config.Credentials())
+					testutil.AssertEqual(t, "tags", []string{"sql", "oracle"}, config.Tags())
+					testutil.AssertEqual(t, "syslog drain", "syslog://logs.example.com", config.SyslogDrainURL())
+					testutil.AssertEqual(t, "route service", "https://proxy.example.com", config.RouteServiceURL())
+					testutil.AssertEqual(t, "namespace", "custom-ns", config.Namespace())
+				})
+			},
+			ExpectedStrings: []string{"Created user-provided service mydb"},
+		},
+		"defaults config": {
+			Args:      []string{"mydb"},
+			Namespace: "custom-ns",
+			Setup: func(t *testing.T, f *fake.FakeUserProvidedClientInterface) {
+				f.EXPECT().CreateUserProvidedService("mydb", gomock.Any()).Do(func(instance string, opts ...services.CreateUserProvidedServiceOption) {
+					config := services.CreateUserProvidedServiceOptions(opts)
+					testutil.AssertEqual(t, "credentials", map[string]interface{}{}, config.Credentials())
+					testutil.AssertEqual(t, "tags", 0, len(config.Tags()))
+				})
+			},
+		},
+		"bad path": {
+			Args:        []string{"mydb", `--parameters=/some/bad/path`},
+			Namespace:   "custom-ns",
+			ExpectedErr: errors.New("couldn't read file: open /some/bad/path: no such file or directory"),
+		},
+		"bad server call": {
+			Args:      []string{"mydb"},
+			Namespace: "custom-ns",
+			Setup: func(t *testing.T, f *fake.FakeUserProvidedClientInterface) {
+				f.EXPECT().CreateUserProvidedService(gomock.Any(), gomock.Any()).Return(errors.New("server-call-error"))
+			},
+			ExpectedErr: errors.New("server-call-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runUserProvidedTest(t, tc, servicescmd.NewCreateUserProvidedServiceCommand)
+		})
+	}
+}
@@ -71,3 +71,40 @@ func runTest(t *testing.T, tc serviceTest, newCommand commandFactory) {
 
 	testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
 }
+
+type userProvidedCommandFactory func(p *config.KfParams, client services.UserProvidedClientInterface) *cobra.Command
+
+type userProvidedServiceTest struct {
+	Args      []string
+	Setup     func(t *testing.T, f *fake.FakeUserProvidedClientInterface)
+	Namespace string
+
+	ExpectedErr     error
+	ExpectedStrings []string
+}
+
+func runUserProvidedTest(t *testing.T, tc userProvidedServiceTest, newCommand userProvidedCommandFactory) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := fake.NewFakeUserProvidedClientInterface(ctrl)
+	if tc.Setup != nil {
+		tc.Setup(t, client)
+	}
+
+	buf := new(bytes.Buffer)
+	p := &config.KfParams{
+		Namespace: tc.Namespace,
+	}
+
+	cmd := newCommand(p, client)
+	cmd.SetOutput(buf)
+	cmd.SetArgs(tc.Args)
+	_, actualErr := cmd.ExecuteC()
+	if tc.ExpectedErr != nil || actualErr != nil {
+		testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+		return
+	}
+
+	testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+}
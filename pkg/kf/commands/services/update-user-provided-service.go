@@ -0,0 +1,124 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/spf13/cobra"
+)
+
+// NewUpdateUserProvidedServiceCommand allows users to change the credentials
+// and properties of user-provided service instances.
+func NewUpdateUserProvidedServiceCommand(p *config.KfParams, client services.UserProvidedClientInterface) *cobra.Command {
+	var (
+		credentialsAsJSON string
+		tags              string
+		syslogDrainURL    string
+		routeServiceURL   string
+	)
+
+	updateCmd := &cobra.Command{
+		Use:     "update-user-provided-service SERVICE_INSTANCE [-p CREDENTIALS] [-t TAGS] [-l SYSLOG_DRAIN_URL] [-r ROUTE_SERVICE_URL]",
+		Aliases: []string{"uups"},
+		Short:   "Update a user-provided service instance",
+		Long: `
+Update a user-provided service instance. Only the properties that are
+specified are changed, passing an empty URL removes it. Apps need to be
+restarted to pick up the changes.
+  `,
+		Example: `
+  kf update-user-provided-service my-db -p '{"username":"admin","password":"new-pass"}'
+  kf update-user-provided-service my-db -t "mysql,legacy"
+  kf update-user-provided-service my-db -l "" # remove the syslog drain`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			cmd.SilenceUsage = true
+
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			opts := []services.UpdateUserProvidedServiceOption{
+				services.WithUpdateUserProvidedServiceNamespace(p.Namespace),
+			}
+
+			// An empty URL removes the existing one.
+			if cmd.Flags().Changed("syslog-drain-url") {
+				opts = append(opts, services.WithUpdateUserProvidedServiceSyslogDrainURL(&syslogDrainURL))
+			}
+
+			if cmd.Flags().Changed("route-service-url") {
+				opts = append(opts, services.WithUpdateUserProvidedServiceRouteServiceURL(&routeServiceURL))
+			}
+
+			if cmd.Flags().Changed("parameters") {
+				credentials, err := services.ParseJSONOrFile(credentialsAsJSON)
+				if err != nil {
+					return err
+				}
+
+				opts = append(opts, services.WithUpdateUserProvidedServiceCredentials(credentials))
+			}
+
+			if cmd.Flags().Changed("tags") {
+				// A non-nil slice is used so passing empty tags clears them.
+				opts = append(opts, services.WithUpdateUserProvidedServiceTags(append([]string{}, parseTags(tags)...)))
+			}
+
+			if err := client.UpdateUserProvidedService(instanceName, opts...); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Updated user-provided service %s\n", instanceName)
+			return nil
+		},
+	}
+
+	updateCmd.Flags().StringVarP(
+		&credentialsAsJSON,
+		"parameters",
+		"p",
+		"",
+		"Valid JSON object containing credentials, provided in-line or in a file.")
+
+	updateCmd.Flags().StringVarP(
+		&tags,
+		"tags",
+		"t",
+		"",
+		"User provided tags, separated by commas.")
+
+	updateCmd.Flags().StringVarP(
+		&syslogDrainURL,
+		"syslog-drain-url",
+		"l",
+		"",
+		"URL to which logs for bound applications will be streamed.")
+
+	updateCmd.Flags().StringVarP(
+		&routeServiceURL,
+		"route-service-url",
+		"r",
+		"",
+		"URL to which requests for bound routes will be forwarded. Scheme must be https.")
+
+	return updateCmd
+}
@@ -16,7 +16,9 @@ package spaces
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/internal/envutil"
@@ -26,6 +28,7 @@ import (
 	"github.com/google/kf/pkg/kf/spaces"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // NewConfigSpaceCommand creates a command that can set facets of a space.
@@ -46,9 +49,17 @@ func NewConfigSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 		newUnsetBuildpackEnvMutator(),
 		newSetContainerRegistryMutator(),
 		newSetBuildpackBuilderMutator(),
+		newSetBuildpackStackMutator(),
+		newSetBuildRetentionMutator(),
+		newSetBuildImageCleanupMutator(),
+		newSetBuildBackendMutator(),
+		newSetBuildTimeoutMutator(),
 		newAppendDomainMutator(),
+		newAppendInternalDomainMutator(),
 		newSetDefaultDomainMutator(),
 		newRemoveDomainMutator(),
+		newSetDomainTLSMutator(),
+		newUnsetDomainTLSMutator(),
 	}
 
 	for _, sm := range subcommands {
@@ -131,6 +142,109 @@ func newSetBuildpackBuilderMutator() spaceMutator {
 	}
 }
 
+func newSetBuildpackStackMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-buildpack-stack",
+		Short: "Set the default stack for buildpack builds. Discover via kf stacks.",
+		Args:  []string{"STACK"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			stack := args[0]
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.BuildpackBuild.Stack = stack
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newSetBuildRetentionMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-build-retention",
+		Short: "Set the number of successful and failed builds kept for each app.",
+		Args:  []string{"SUCCEEDED", "FAILED"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			var counts []int32
+			for _, arg := range args {
+				count, err := strconv.ParseInt(arg, 10, 32)
+				if err != nil || count < 0 {
+					return nil, fmt.Errorf("invalid build count %q, must be a non-negative integer", arg)
+				}
+				counts = append(counts, int32(count))
+			}
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.BuildpackBuild.Retention.Succeeded = &counts[0]
+				space.Spec.BuildpackBuild.Retention.Failed = &counts[1]
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newSetBuildImageCleanupMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-build-image-cleanup",
+		Short: "Set whether images of garbage collected builds are deleted from the container registry.",
+		Args:  []string{"true|false"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			enabled, err := strconv.ParseBool(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q, must be true or false", args[0])
+			}
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.BuildpackBuild.Retention.DeleteImages = enabled
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newSetBuildBackendMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-build-backend",
+		Short: fmt.Sprintf("Set the system that runs builds, one of: %s.", strings.Join(v1alpha1.BuildBackends, ", ")),
+		Args:  []string{"BACKEND"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			backend := args[0]
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.BuildpackBuild.Backend = backend
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newSetBuildTimeoutMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-build-timeout",
+		Short: "Set the default time builds may run before they fail, 0 uses the build system's default.",
+		Args:  []string{"DURATION"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			timeout, err := time.ParseDuration(args[0])
+			if err != nil || timeout < 0 {
+				return nil, fmt.Errorf("invalid duration %q, must be a non-negative duration e.g. 30m", args[0])
+			}
+
+			return func(space *v1alpha1.Space) error {
+				if timeout == 0 {
+					space.Spec.BuildpackBuild.Timeout = nil
+				} else {
+					space.Spec.BuildpackBuild.Timeout = &metav1.Duration{Duration: timeout}
+				}
+
+				return nil
+			}, nil
+		},
+	}
+}
+
 func newSetEnvMutator() spaceMutator {
 	return spaceMutator{
 		Name:  "set-env",
@@ -223,6 +337,26 @@ func newAppendDomainMutator() spaceMutator {
 	}
 }
 
+func newAppendInternalDomainMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "append-internal-domain",
+		Short: "Append a domain for a space that is only reachable from within the cluster",
+		Args:  []string{"DOMAIN"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			domain := args[0]
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.Execution.Domains = append(
+					space.Spec.Execution.Domains,
+					v1alpha1.SpaceDomain{Domain: domain, Internal: true},
+				)
+
+				return nil
+			}, nil
+		},
+	}
+}
+
 func newSetDefaultDomainMutator() spaceMutator {
 	return spaceMutator{
 		Name:  "set-default-domain",
@@ -271,3 +405,47 @@ func newRemoveDomainMutator() spaceMutator {
 		},
 	}
 }
+
+func newSetDomainTLSMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-domain-tls",
+		Short: "Serve a domain of the space over HTTPS using the certificate in a TLS Secret in the istio-system namespace",
+		Args:  []string{"DOMAIN", "SECRET_NAME"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			domain, secretName := args[0], args[1]
+
+			return func(space *v1alpha1.Space) error {
+				for i, d := range space.Spec.Execution.Domains {
+					if d.Domain == domain {
+						space.Spec.Execution.Domains[i].TLS = &v1alpha1.DomainTLS{SecretName: secretName}
+						return nil
+					}
+				}
+
+				return fmt.Errorf("failed to find domain %s", domain)
+			}, nil
+		},
+	}
+}
+
+func newUnsetDomainTLSMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "unset-domain-tls",
+		Short: "Stop serving a domain of the space over HTTPS",
+		Args:  []string{"DOMAIN"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			domain := args[0]
+
+			return func(space *v1alpha1.Space) error {
+				for i, d := range space.Spec.Execution.Domains {
+					if d.Domain == domain {
+						space.Spec.Execution.Domains[i].TLS = nil
+						return nil
+					}
+				}
+
+				return fmt.Errorf("failed to find domain %s", domain)
+			}, nil
+		},
+	}
+}
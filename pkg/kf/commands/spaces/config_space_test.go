@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -26,6 +27,7 @@ import (
 	"github.com/google/kf/pkg/kf/spaces"
 	"github.com/google/kf/pkg/kf/spaces/fake"
 	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestNewConfigSpaceCommand(t *testing.T) {
@@ -130,6 +132,71 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 			},
 		},
 
+		"set-buildpack-stack valid": {
+			args: []string{"set-buildpack-stack", space, "cflinuxfs3"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "stack", "cflinuxfs3", space.Spec.BuildpackBuild.Stack)
+			},
+		},
+
+		"set-build-retention valid": {
+			args: []string{"set-build-retention", space, "3", "0"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "succeeded", int32(3), *space.Spec.BuildpackBuild.Retention.Succeeded)
+				testutil.AssertEqual(t, "failed", int32(0), *space.Spec.BuildpackBuild.Retention.Failed)
+			},
+		},
+
+		"set-build-retention negative": {
+			args:    []string{"set-build-retention", space, "3", "-1"},
+			wantErr: errors.New(`invalid build count "-1", must be a non-negative integer`),
+		},
+
+		"set-build-image-cleanup valid": {
+			args: []string{"set-build-image-cleanup", space, "true"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "delete images", true, space.Spec.BuildpackBuild.Retention.DeleteImages)
+			},
+		},
+
+		"set-build-image-cleanup invalid": {
+			args:    []string{"set-build-image-cleanup", space, "sometimes"},
+			wantErr: errors.New(`invalid value "sometimes", must be true or false`),
+		},
+
+		"set-build-backend valid": {
+			args: []string{"set-build-backend", space, "tekton"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "backend", "tekton", space.Spec.BuildpackBuild.Backend)
+			},
+		},
+
+		"set-build-timeout valid": {
+			args: []string{"set-build-timeout", space, "45m"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "timeout", 45*time.Minute, space.Spec.BuildpackBuild.Timeout.Duration)
+			},
+		},
+
+		"set-build-timeout zero": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					BuildpackBuild: v1alpha1.SpaceSpecBuildpackBuild{
+						Timeout: &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			args: []string{"set-build-timeout", space, "0"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "timeout", (*metav1.Duration)(nil), space.Spec.BuildpackBuild.Timeout)
+			},
+		},
+
+		"set-build-timeout invalid": {
+			args:    []string{"set-build-timeout", space, "forever"},
+			wantErr: errors.New(`invalid duration "forever", must be a non-negative duration e.g. 30m`),
+		},
+
 		"append-domain valid": {
 			args: []string{"append-domain", space, "example.com"},
 			validate: func(t *testing.T, space *v1alpha1.Space) {
@@ -138,6 +205,15 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 			},
 		},
 
+		"append-internal-domain valid": {
+			args: []string{"append-internal-domain", space, "apps.internal"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "len(domains)", 1, len(space.Spec.Execution.Domains))
+				testutil.AssertEqual(t, "domains", "apps.internal", space.Spec.Execution.Domains[0].Domain)
+				testutil.AssertEqual(t, "internal", true, space.Spec.Execution.Domains[0].Internal)
+			},
+		},
+
 		"set-default-domain valid": {
 			space: v1alpha1.Space{
 				Spec: v1alpha1.SpaceSpec{
@@ -172,6 +248,52 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 			args:    []string{"set-default-domain", space, "other-example.com"},
 		},
 
+		"set-domain-tls valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					Execution: v1alpha1.SpaceSpecExecution{
+						Domains: []v1alpha1.SpaceDomain{
+							{Domain: "example.com"},
+						},
+					},
+				},
+			},
+			args: []string{"set-domain-tls", space, "example.com", "example-com-tls"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "tls", &v1alpha1.DomainTLS{SecretName: "example-com-tls"}, space.Spec.Execution.Domains[0].TLS)
+			},
+		},
+
+		"set-domain-tls invalid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					Execution: v1alpha1.SpaceSpecExecution{
+						Domains: []v1alpha1.SpaceDomain{
+							{Domain: "example.com"},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("failed to find domain other-example.com"),
+			args:    []string{"set-domain-tls", space, "other-example.com", "example-com-tls"},
+		},
+
+		"unset-domain-tls valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					Execution: v1alpha1.SpaceSpecExecution{
+						Domains: []v1alpha1.SpaceDomain{
+							{Domain: "example.com", TLS: &v1alpha1.DomainTLS{SecretName: "example-com-tls"}},
+						},
+					},
+				},
+			},
+			args: []string{"unset-domain-tls", space, "example.com"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "tls", (*v1alpha1.DomainTLS)(nil), space.Spec.Execution.Domains[0].TLS)
+			},
+		},
+
 		"remove-domain valid": {
 			space: v1alpha1.Space{
 				Spec: v1alpha1.SpaceSpec{
@@ -19,16 +19,17 @@ import (
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/domains"
 	"github.com/google/kf/pkg/kf/spaces"
 
 	"github.com/spf13/cobra"
 )
 
 // NewCreateSpaceCommand allows users to create spaces.
-func NewCreateSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
+func NewCreateSpaceCommand(p *config.KfParams, client spaces.Client, domainsClient domains.Client) *cobra.Command {
 	var (
 		containerRegistry string
-		domains           []string
+		domainNames       []string
 	)
 
 	cmd := &cobra.Command{
@@ -44,7 +45,15 @@ func NewCreateSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 			toCreate.SetName(name)
 			toCreate.SetContainerRegistry(containerRegistry)
 
-			for i, domain := range domains {
+			if len(domainNames) == 0 {
+				defaultDomains, err := defaultSharedDomains(domainsClient)
+				if err != nil {
+					return err
+				}
+				domainNames = defaultDomains
+			}
+
+			for i, domain := range domainNames {
 				toCreate.AppendDomains(v1alpha1.SpaceDomain{Domain: domain, Default: i == 0})
 			}
 
@@ -69,11 +78,29 @@ func NewCreateSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 	)
 
 	cmd.Flags().StringArrayVar(
-		&domains,
+		&domainNames,
 		"domain",
 		nil,
-		"Sets the valid domains for the space. The first provided domain will be the default.",
+		"Sets the valid domains for the space. The first provided domain will be the default. Defaults to the default shared domains of the cluster.",
 	)
 
 	return cmd
 }
+
+// defaultSharedDomains returns the names of the shared Domains that new
+// spaces should use.
+func defaultSharedDomains(client domains.Client) ([]string, error) {
+	domainList, err := client.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Domains: %s", err)
+	}
+
+	var out []string
+	for _, domain := range domainList {
+		if domain.Spec.Default && !domain.Spec.IsPrivate() {
+			out = append(out, domain.Name)
+		}
+	}
+
+	return out, nil
+}
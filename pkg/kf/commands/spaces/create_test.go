@@ -22,8 +22,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/commands/config"
+	fakedomains "github.com/google/kf/pkg/kf/domains/fake"
 	"github.com/google/kf/pkg/kf/spaces/fake"
 	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestNewCreateSpaceCommand(t *testing.T) {
@@ -32,7 +34,7 @@ func TestNewCreateSpaceCommand(t *testing.T) {
 	cases := map[string]struct {
 		wantErr error
 		args    []string
-		setup   func(t *testing.T, fakeSpaces *fake.FakeClient)
+		setup   func(t *testing.T, fakeSpaces *fake.FakeClient, fakeDomains *fakedomains.FakeClient)
 	}{
 		"invalid number of args": {
 			args:    []string{},
@@ -40,7 +42,7 @@ func TestNewCreateSpaceCommand(t *testing.T) {
 		},
 		"object passed through": {
 			args: []string{"my-ns", "--container-registry=some-registry", "--domain=domain-1", "--domain=domain-2"},
-			setup: func(t *testing.T, fakeSpaces *fake.FakeClient) {
+			setup: func(t *testing.T, fakeSpaces *fake.FakeClient, fakeDomains *fakedomains.FakeClient) {
 				fakeSpaces.
 					EXPECT().
 					Create(gomock.Any()).
@@ -51,9 +53,44 @@ func TestNewCreateSpaceCommand(t *testing.T) {
 					})
 			},
 		},
+		"default shared domains": {
+			args: []string{"my-ns"},
+			setup: func(t *testing.T, fakeSpaces *fake.FakeClient, fakeDomains *fakedomains.FakeClient) {
+				fakeDomains.
+					EXPECT().
+					List().
+					Return([]v1alpha1.Domain{
+						{ObjectMeta: metav1.ObjectMeta{Name: "default-1.com"}, Spec: v1alpha1.DomainSpec{Default: true}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "shared.com"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "default-2.com"}, Spec: v1alpha1.DomainSpec{Default: true}},
+					}, nil)
+
+				fakeSpaces.
+					EXPECT().
+					Create(gomock.Any()).
+					Do(func(space *v1alpha1.Space) {
+						testutil.AssertEqual(t, "sets domains", []v1alpha1.SpaceDomain{{Domain: "default-1.com", Default: true}, {Domain: "default-2.com"}}, space.Spec.Execution.Domains)
+					})
+			},
+		},
+		"listing domains fails": {
+			args: []string{"my-ns"},
+			setup: func(t *testing.T, fakeSpaces *fake.FakeClient, fakeDomains *fakedomains.FakeClient) {
+				fakeDomains.
+					EXPECT().
+					List().
+					Return(nil, errors.New("some-error"))
+			},
+			wantErr: errors.New("failed to fetch Domains: some-error"),
+		},
 		"server failure": {
 			args: []string{"my-ns"},
-			setup: func(t *testing.T, fakeSpaces *fake.FakeClient) {
+			setup: func(t *testing.T, fakeSpaces *fake.FakeClient, fakeDomains *fakedomains.FakeClient) {
+				fakeDomains.
+					EXPECT().
+					List().
+					Return(nil, nil)
+
 				fakeSpaces.
 					EXPECT().
 					Create(gomock.Any()).
@@ -67,14 +104,15 @@ func TestNewCreateSpaceCommand(t *testing.T) {
 		t.Run(tn, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			fakeSpaces := fake.NewFakeClient(ctrl)
+			fakeDomains := fakedomains.NewFakeClient(ctrl)
 
 			if tc.setup != nil {
-				tc.setup(t, fakeSpaces)
+				tc.setup(t, fakeSpaces, fakeDomains)
 			}
 
 			buffer := &bytes.Buffer{}
 
-			c := NewCreateSpaceCommand(&config.KfParams{Namespace: "default"}, fakeSpaces)
+			c := NewCreateSpaceCommand(&config.KfParams{Namespace: "default"}, fakeSpaces, fakeDomains)
 			c.SetOutput(buffer)
 			c.SetArgs(tc.args)
 
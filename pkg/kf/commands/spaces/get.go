@@ -15,6 +15,7 @@
 package spaces
 
 import (
+	"context"
 	"fmt"
 	"io"
 
@@ -59,6 +60,12 @@ func NewGetSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Command
 				buildpackBuild := space.Spec.BuildpackBuild
 				fmt.Fprintf(w, "Builder Image:\t%q\n", buildpackBuild.BuilderImage)
 				fmt.Fprintf(w, "Container Registry:\t%q\n", buildpackBuild.ContainerRegistry)
+				fmt.Fprintf(w, "Stack:\t%q\n", buildpackBuild.Stack)
+
+				retention := buildpackBuild.Retention.DeepCopy()
+				retention.SetDefaults(context.Background())
+				fmt.Fprintf(w, "Builds Kept:\t%d succeeded, %d failed\n", *retention.Succeeded, *retention.Failed)
+				fmt.Fprintf(w, "Delete Old Images?\t%v\n", retention.DeleteImages)
 				describe.EnvVars(w, buildpackBuild.Env)
 			})
 			fmt.Fprintln(w)
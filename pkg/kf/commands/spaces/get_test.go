@@ -74,6 +74,11 @@ func TestNewGetSpaceCommand(t *testing.T) {
 			space:      goodSpace,
 			wantOutput: []string{"Build", "some/builder/image", "some/container/registry", "BuildVar", "BuildVal"},
 		},
+		"build retention": {
+			args:       []string{"my-space"},
+			space:      goodSpace,
+			wantOutput: []string{"Builds Kept", "5 succeeded, 2 failed", "Delete Old Images?", "false"},
+		},
 		"execution": {
 			args:       []string{"my-space"},
 			space:      goodSpace,
@@ -0,0 +1,217 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/describe"
+	"github.com/google/kf/pkg/kf/spaces"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NewAddNetworkPolicyCommand creates a command that allows traffic from one
+// app to another in the targeted space.
+func NewAddNetworkPolicyCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
+	var (
+		destinationApp string
+		port           int32
+		protocol       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add-network-policy SOURCE_APP --destination-app DESTINATION_APP",
+		Short: "Allow direct network traffic from one app to another",
+		Long: `Allow direct network traffic from one app to another.
+
+Once an app is the destination of a network policy it only accepts traffic
+from the apps allowed by its policies and from outside of the kf spaces (for
+example the ingress gateway). Apps that aren't the destination of any policy
+accept traffic from every app.
+
+Traffic reaches apps through Knative's queue-proxy, so --port is the port the
+app listens on (8080 unless the app sets its own) and the policy allows the
+queue-proxy ports that forward to it.`,
+		Example: `  kf add-network-policy frontend --destination-app backend --port 8080`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			policy, err := parseNetworkPolicy(args[0], destinationApp, port, protocol)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			if err := client.Transform(p.Namespace, func(space *v1alpha1.Space) error {
+				space.Spec.Security.AddNetworkPolicy(policy)
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Allowed traffic from %q to %q in space %q\n", policy.SourceApp, policy.DestinationApp, p.Namespace)
+			return nil
+		},
+	}
+
+	addNetworkPolicyFlags(cmd, &destinationApp, &port, &protocol)
+
+	return cmd
+}
+
+// NewRemoveNetworkPolicyCommand creates a command that removes a network
+// policy from the targeted space.
+func NewRemoveNetworkPolicyCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
+	var (
+		destinationApp string
+		port           int32
+		protocol       string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "remove-network-policy SOURCE_APP --destination-app DESTINATION_APP",
+		Short:   "Remove a network policy between two apps",
+		Example: `  kf remove-network-policy frontend --destination-app backend --port 8080`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			policy, err := parseNetworkPolicy(args[0], destinationApp, port, protocol)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			if err := client.Transform(p.Namespace, func(space *v1alpha1.Space) error {
+				if !space.Spec.Security.RemoveNetworkPolicy(policy) {
+					return fmt.Errorf("no network policy from %q to %q in space %q", policy.SourceApp, policy.DestinationApp, p.Namespace)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed network policy from %q to %q in space %q\n", policy.SourceApp, policy.DestinationApp, p.Namespace)
+			return nil
+		},
+	}
+
+	addNetworkPolicyFlags(cmd, &destinationApp, &port, &protocol)
+
+	return cmd
+}
+
+// NewNetworkPoliciesCommand creates a command that lists the network policies
+// in the targeted space.
+func NewNetworkPoliciesCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network-policies",
+		Short: "List the network policies in the targeted space",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			space, err := client.Get(p.Namespace)
+			if err != nil {
+				return err
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "Getting network policies in space %s\n", space.Name)
+			fmt.Fprintln(w)
+
+			describe.TabbedWriter(w, func(w io.Writer) {
+				fmt.Fprintln(w, "Source\tDestination\tProtocol\tPort")
+				for _, policy := range space.Spec.Security.NetworkPolicies {
+					port := "any"
+					if policy.Port != 0 {
+						port = fmt.Sprint(policy.Port)
+					}
+
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", policy.SourceApp, policy.DestinationApp, policy.Protocol, port)
+				}
+			})
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func addNetworkPolicyFlags(cmd *cobra.Command, destinationApp *string, port *int32, protocol *string) {
+	cmd.Flags().StringVar(
+		destinationApp,
+		"destination-app",
+		"",
+		"Name of the app receiving the traffic.",
+	)
+
+	cmd.Flags().Int32Var(
+		port,
+		"port",
+		0,
+		"Port the destination app listens on, all ports if unset.",
+	)
+
+	cmd.Flags().StringVar(
+		protocol,
+		"protocol",
+		"tcp",
+		"Protocol of the traffic, one of tcp or udp.",
+	)
+}
+
+// parseNetworkPolicy builds a SpaceNetworkPolicy from user input.
+func parseNetworkPolicy(sourceApp, destinationApp string, port int32, protocol string) (v1alpha1.SpaceNetworkPolicy, error) {
+	policy := v1alpha1.SpaceNetworkPolicy{
+		SourceApp:      sourceApp,
+		DestinationApp: destinationApp,
+		Port:           port,
+		Protocol:       corev1.Protocol(strings.ToUpper(protocol)),
+	}
+
+	if destinationApp == "" {
+		return policy, errors.New("--destination-app is required")
+	}
+
+	if policy.Protocol != corev1.ProtocolTCP && policy.Protocol != corev1.ProtocolUDP {
+		return policy, fmt.Errorf("invalid protocol %q, must be one of tcp or udp", protocol)
+	}
+
+	if port < 0 || port > 65535 {
+		return policy, fmt.Errorf("invalid port %d, must be between 1 and 65535 or 0 for all ports", port)
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/spaces"
+	"github.com/google/kf/pkg/kf/spaces/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/spf13/cobra"
+)
+
+func TestNetworkPolicyCommands(t *testing.T) {
+	t.Parallel()
+
+	frontend := v1alpha1.SpaceNetworkPolicy{SourceApp: "frontend", DestinationApp: "backend", Port: 8080, Protocol: "TCP"}
+	worker := v1alpha1.SpaceNetworkPolicy{SourceApp: "worker", DestinationApp: "backend", Protocol: "UDP"}
+
+	cases := map[string]struct {
+		command           func(*config.KfParams, spaces.Client) *cobra.Command
+		noSpace           bool
+		args              []string
+		existing          []v1alpha1.SpaceNetworkPolicy
+		wantErr           error
+		wantPolicies      []v1alpha1.SpaceNetworkPolicy
+		wantOutput        []string
+		skipTransformMock bool
+	}{
+		"add: wrong number of args": {
+			command:           NewAddNetworkPolicyCommand,
+			args:              []string{},
+			wantErr:           errors.New("accepts 1 arg(s), received 0"),
+			skipTransformMock: true,
+		},
+		"add: no space targeted": {
+			command:           NewAddNetworkPolicyCommand,
+			noSpace:           true,
+			args:              []string{"frontend", "--destination-app", "backend"},
+			wantErr:           errors.New(utils.EmptyNamespaceError),
+			skipTransformMock: true,
+		},
+		"add: missing destination": {
+			command:           NewAddNetworkPolicyCommand,
+			args:              []string{"frontend"},
+			wantErr:           errors.New("--destination-app is required"),
+			skipTransformMock: true,
+		},
+		"add: invalid protocol": {
+			command:           NewAddNetworkPolicyCommand,
+			args:              []string{"frontend", "--destination-app", "backend", "--protocol", "icmp"},
+			wantErr:           errors.New(`invalid protocol "icmp", must be one of tcp or udp`),
+			skipTransformMock: true,
+		},
+		"add: invalid port": {
+			command:           NewAddNetworkPolicyCommand,
+			args:              []string{"frontend", "--destination-app", "backend", "--port", "70000"},
+			wantErr:           errors.New("invalid port 70000, must be between 1 and 65535 or 0 for all ports"),
+			skipTransformMock: true,
+		},
+		"add: policy": {
+			command:      NewAddNetworkPolicyCommand,
+			args:         []string{"frontend", "--destination-app", "backend", "--port", "8080"},
+			wantPolicies: []v1alpha1.SpaceNetworkPolicy{frontend},
+			wantOutput:   []string{`Allowed traffic from "frontend" to "backend" in space "my-space"`},
+		},
+		"add: udp policy": {
+			command:      NewAddNetworkPolicyCommand,
+			args:         []string{"worker", "--destination-app", "backend", "--protocol", "udp"},
+			existing:     []v1alpha1.SpaceNetworkPolicy{frontend},
+			wantPolicies: []v1alpha1.SpaceNetworkPolicy{frontend, worker},
+		},
+		"add: already exists": {
+			command:      NewAddNetworkPolicyCommand,
+			args:         []string{"frontend", "--destination-app", "backend", "--port", "8080"},
+			existing:     []v1alpha1.SpaceNetworkPolicy{frontend},
+			wantPolicies: []v1alpha1.SpaceNetworkPolicy{frontend},
+		},
+		"remove: removes policy": {
+			command:      NewRemoveNetworkPolicyCommand,
+			args:         []string{"frontend", "--destination-app", "backend", "--port", "8080"},
+			existing:     []v1alpha1.SpaceNetworkPolicy{frontend, worker},
+			wantPolicies: []v1alpha1.SpaceNetworkPolicy{worker},
+			wantOutput:   []string{`Removed network policy from "frontend" to "backend" in space "my-space"`},
+		},
+		"remove: missing policy": {
+			command:  NewRemoveNetworkPolicyCommand,
+			args:     []string{"frontend", "--destination-app", "backend"},
+			existing: []v1alpha1.SpaceNetworkPolicy{frontend},
+			wantErr:  errors.New(`no network policy from "frontend" to "backend" in space "my-space"`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeSpaces := fake.NewFakeClient(ctrl)
+
+			space := &v1alpha1.Space{}
+			space.Spec.Security.NetworkPolicies = tc.existing
+
+			if !tc.skipTransformMock {
+				fakeSpaces.EXPECT().Transform("my-space", gomock.Any()).DoAndReturn(func(spaceName string, transformer spaces.Mutator) error {
+					return transformer(space)
+				})
+			}
+
+			namespace := "my-space"
+			if tc.noSpace {
+				namespace = ""
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := tc.command(&config.KfParams{Namespace: namespace}, fakeSpaces)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+
+			if tc.wantErr == nil {
+				testutil.AssertEqual(t, "network policies", tc.wantPolicies, space.Spec.Security.NetworkPolicies)
+				testutil.AssertContainsAll(t, buffer.String(), tc.wantOutput)
+			}
+
+			ctrl.Finish()
+		})
+	}
+}
+
+func TestNewNetworkPoliciesCommand(t *testing.T) {
+	t.Parallel()
+
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+	space.Spec.Security.NetworkPolicies = []v1alpha1.SpaceNetworkPolicy{
+		{SourceApp: "frontend", DestinationApp: "backend", Port: 8080, Protocol: "TCP"},
+		{SourceApp: "worker", DestinationApp: "backend", Protocol: "UDP"},
+	}
+
+	cases := map[string]struct {
+		args       []string
+		space      *v1alpha1.Space
+		wantErr    error
+		wantOutput []string
+	}{
+		"invalid number of args": {
+			args:    []string{"my-space"},
+			wantErr: errors.New("accepts 0 arg(s), received 1"),
+		},
+		"lists policies": {
+			space: space,
+			wantOutput: []string{
+				"Getting network policies in space my-space",
+				"frontend  backend      TCP       8080",
+				"worker    backend      UDP       any",
+			},
+		},
+		"client error": {
+			wantErr: errors.New("does not exist"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeSpaces := fake.NewFakeClient(ctrl)
+
+			if tc.space != nil {
+				fakeSpaces.EXPECT().Get("my-space").Return(tc.space, nil)
+			} else {
+				fakeSpaces.EXPECT().Get(gomock.Any()).Return(nil, errors.New("does not exist")).AnyTimes()
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewNetworkPoliciesCommand(&config.KfParams{Namespace: "my-space"}, fakeSpaces)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+
+			if tc.wantErr == nil {
+				testutil.AssertContainsAll(t, buffer.String(), tc.wantOutput)
+			}
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/describe"
+	"github.com/google/kf/pkg/kf/spaces"
+	"github.com/spf13/cobra"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// NewSetSpaceRoleCommand creates a command that assigns a role in a space to
+// a user, group or service account.
+func NewSetSpaceRoleCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
+	var subjectType string
+
+	cmd := &cobra.Command{
+		Use:   "set-space-role NAME SPACE ROLE",
+		Short: "Assign a space role to a user, group or service account",
+		Long: `Assign a space role to a user, group or service account.
+
+Valid roles are developer, auditor and manager. Developers can push and manage
+apps and services, auditors have read-only access, and managers have developer
+access and can also modify the space itself.`,
+		Example: `  kf set-space-role alice@example.com my-space developer
+  kf set-space-role auditors my-space auditor --type group`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			assignment, err := parseRoleAssignment(args[0], args[2], subjectType)
+			if err != nil {
+				return err
+			}
+			spaceName := args[1]
+
+			cmd.SilenceUsage = true
+
+			if err := client.Transform(spaceName, func(space *v1alpha1.Space) error {
+				space.Spec.Security.AddRoleAssignment(assignment)
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Assigned role %s to %s %q in space %q\n", assignment.Role, assignment.Kind, assignment.Name, spaceName)
+			return nil
+		},
+	}
+
+	addSubjectTypeFlag(cmd, &subjectType)
+
+	return cmd
+}
+
+// NewUnsetSpaceRoleCommand creates a command that removes a role in a space
+// from a user, group or service account.
+func NewUnsetSpaceRoleCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
+	var subjectType string
+
+	cmd := &cobra.Command{
+		Use:     "unset-space-role NAME SPACE ROLE",
+		Short:   "Remove a space role from a user, group or service account",
+		Example: `  kf unset-space-role alice@example.com my-space developer`,
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			assignment, err := parseRoleAssignment(args[0], args[2], subjectType)
+			if err != nil {
+				return err
+			}
+			spaceName := args[1]
+
+			cmd.SilenceUsage = true
+
+			if err := client.Transform(spaceName, func(space *v1alpha1.Space) error {
+				if !space.Spec.Security.RemoveRoleAssignment(assignment) {
+					return fmt.Errorf("%s %q doesn't have role %s in space %q", assignment.Kind, assignment.Name, assignment.Role, spaceName)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed role %s from %s %q in space %q\n", assignment.Role, assignment.Kind, assignment.Name, spaceName)
+			return nil
+		},
+	}
+
+	addSubjectTypeFlag(cmd, &subjectType)
+
+	return cmd
+}
+
+// NewSpaceUsersCommand creates a command that lists the role assignments in
+// a space.
+func NewSpaceUsersCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "space-users SPACE",
+		Short: "List users, groups and service accounts with roles in the space",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			space, err := client.Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "Getting users in space %s\n", space.Name)
+			fmt.Fprintln(w)
+
+			describe.TabbedWriter(w, func(w io.Writer) {
+				fmt.Fprintln(w, "Role\tType\tName")
+				for _, role := range v1alpha1.SpaceRoles {
+					for _, a := range space.Spec.Security.RoleAssignmentsFor(role) {
+						fmt.Fprintf(w, "%s\t%s\t%s\n", a.Role, a.Kind, a.Name)
+					}
+				}
+			})
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func addSubjectTypeFlag(cmd *cobra.Command, subjectType *string) {
+	cmd.Flags().StringVar(
+		subjectType,
+		"type",
+		"user",
+		"Type of the subject, one of user, group or serviceaccount.",
+	)
+}
+
+// parseRoleAssignment builds a SpaceRoleAssignment from user input. Roles
+// may be given in the Cloud Foundry style (e.g. SpaceDeveloper) or the kf
+// style (e.g. developer).
+func parseRoleAssignment(name, role, subjectType string) (v1alpha1.SpaceRoleAssignment, error) {
+	assignment := v1alpha1.SpaceRoleAssignment{Name: name}
+
+	assignment.Role = v1alpha1.SpaceRole(strings.TrimPrefix(strings.ToLower(role), "space"))
+	if !assignment.Role.IsValid() {
+		return assignment, fmt.Errorf("invalid role %q, must be one of developer, auditor or manager", role)
+	}
+
+	switch strings.ToLower(subjectType) {
+	case "user":
+		assignment.Kind = rbacv1.UserKind
+	case "group":
+		assignment.Kind = rbacv1.GroupKind
+	case "serviceaccount":
+		assignment.Kind = rbacv1.ServiceAccountKind
+	default:
+		return assignment, fmt.Errorf("invalid type %q, must be one of user, group or serviceaccount", subjectType)
+	}
+
+	return assignment, nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/spaces"
+	"github.com/google/kf/pkg/kf/spaces/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/spf13/cobra"
+)
+
+func TestSpaceRoleCommands(t *testing.T) {
+	t.Parallel()
+
+	developer := v1alpha1.SpaceRoleAssignment{Role: v1alpha1.SpaceRoleDeveloper, Kind: "User", Name: "alice@example.com"}
+	auditors := v1alpha1.SpaceRoleAssignment{Role: v1alpha1.SpaceRoleAuditor, Kind: "Group", Name: "auditors"}
+
+	cases := map[string]struct {
+		command           func(*config.KfParams, spaces.Client) *cobra.Command
+		args              []string
+		existing          []v1alpha1.SpaceRoleAssignment
+		wantErr           error
+		wantAssignments   []v1alpha1.SpaceRoleAssignment
+		wantOutput        []string
+		skipTransformMock bool
+	}{
+		"set: wrong number of args": {
+			command:           NewSetSpaceRoleCommand,
+			args:              []string{"alice@example.com", "my-space"},
+			wantErr:           errors.New("accepts 3 arg(s), received 2"),
+			skipTransformMock: true,
+		},
+		"set: invalid role": {
+			command:           NewSetSpaceRoleCommand,
+			args:              []string{"alice@example.com", "my-space", "owner"},
+			wantErr:           errors.New(`invalid role "owner", must be one of developer, auditor or manager`),
+			skipTransformMock: true,
+		},
+		"set: invalid type": {
+			command:           NewSetSpaceRoleCommand,
+			args:              []string{"alice@example.com", "my-space", "developer", "--type", "robot"},
+			wantErr:           errors.New(`invalid type "robot", must be one of user, group or serviceaccount`),
+			skipTransformMock: true,
+		},
+		"set: user": {
+			command:         NewSetSpaceRoleCommand,
+			args:            []string{"alice@example.com", "my-space", "developer"},
+			wantAssignments: []v1alpha1.SpaceRoleAssignment{developer},
+			wantOutput:      []string{`Assigned role developer to User "alice@example.com" in space "my-space"`},
+		},
+		"set: cf style role and group": {
+			command:         NewSetSpaceRoleCommand,
+			args:            []string{"auditors", "my-space", "SpaceAuditor", "--type", "group"},
+			existing:        []v1alpha1.SpaceRoleAssignment{developer},
+			wantAssignments: []v1alpha1.SpaceRoleAssignment{developer, auditors},
+		},
+		"set: already assigned": {
+			command:         NewSetSpaceRoleCommand,
+			args:            []string{"alice@example.com", "my-space", "developer"},
+			existing:        []v1alpha1.SpaceRoleAssignment{developer},
+			wantAssignments: []v1alpha1.SpaceRoleAssignment{developer},
+		},
+		"unset: removes role": {
+			command:         NewUnsetSpaceRoleCommand,
+			args:            []string{"alice@example.com", "my-space", "developer"},
+			existing:        []v1alpha1.SpaceRoleAssignment{developer, auditors},
+			wantAssignments: []v1alpha1.SpaceRoleAssignment{auditors},
+			wantOutput:      []string{`Removed role developer from User "alice@example.com" in space "my-space"`},
+		},
+		"unset: missing role": {
+			command:  NewUnsetSpaceRoleCommand,
+			args:     []string{"alice@example.com", "my-space", "manager"},
+			existing: []v1alpha1.SpaceRoleAssignment{developer},
+			wantErr:  errors.New(`User "alice@example.com" doesn't have role manager in space "my-space"`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeSpaces := fake.NewFakeClient(ctrl)
+
+			space := &v1alpha1.Space{}
+			space.Spec.Security.RoleAssignments = tc.existing
+
+			if !tc.skipTransformMock {
+				fakeSpaces.EXPECT().Transform("my-space", gomock.Any()).DoAndReturn(func(spaceName string, transformer spaces.Mutator) error {
+					return transformer(space)
+				})
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := tc.command(&config.KfParams{}, fakeSpaces)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+
+			if tc.wantErr == nil {
+				testutil.AssertEqual(t, "role assignments", tc.wantAssignments, space.Spec.Security.RoleAssignments)
+				testutil.AssertContainsAll(t, buffer.String(), tc.wantOutput)
+			}
+
+			ctrl.Finish()
+		})
+	}
+}
+
+func TestNewSpaceUsersCommand(t *testing.T) {
+	t.Parallel()
+
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+	space.Spec.Security.RoleAssignments = []v1alpha1.SpaceRoleAssignment{
+		{Role: v1alpha1.SpaceRoleManager, Kind: "User", Name: "bob@example.com"},
+		{Role: v1alpha1.SpaceRoleDeveloper, Kind: "User", Name: "alice@example.com"},
+		{Role: v1alpha1.SpaceRoleAuditor, Kind: "ServiceAccount", Name: "ci"},
+	}
+
+	cases := map[string]struct {
+		args       []string
+		space      *v1alpha1.Space
+		wantErr    error
+		wantOutput []string
+	}{
+		"invalid number of args": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"lists users": {
+			args:  []string{"my-space"},
+			space: space,
+			wantOutput: []string{
+				"Getting users in space my-space",
+				"developer  User            alice@example.com",
+				"auditor    ServiceAccount  ci",
+				"manager    User            bob@example.com",
+			},
+		},
+		"client error": {
+			args:    []string{"my-space"},
+			wantErr: errors.New("does not exist"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeSpaces := fake.NewFakeClient(ctrl)
+
+			if tc.space != nil {
+				fakeSpaces.EXPECT().Get("my-space").Return(tc.space, nil)
+			} else {
+				fakeSpaces.EXPECT().Get(gomock.Any()).Return(nil, errors.New("does not exist")).AnyTimes()
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewSpaceUsersCommand(&config.KfParams{}, fakeSpaces)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+
+			if tc.wantErr == nil {
+				testutil.AssertContainsAll(t, buffer.String(), tc.wantOutput)
+			}
+
+			ctrl.Finish()
+		})
+	}
+}
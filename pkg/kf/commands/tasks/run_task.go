@@ -0,0 +1,156 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/internal/envutil"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// taskPollInterval is how often the Task is checked for completion.
+const taskPollInterval = 2 * time.Second
+
+// NewRunTaskCommand allows users to run one-off processes using an App's
+// image and configuration.
+func NewRunTaskCommand(p *config.KfParams, client tasks.Client) *cobra.Command {
+	var (
+		name  string
+		envs  []string
+		async bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run-task APP_NAME COMMAND",
+		Short: "Run a one-off task using an app's image and configuration",
+		Example: `
+  kf run-task myapp "rake db:migrate"
+  kf run-task myapp "rake db:migrate" --name migrate --env RAILS_ENV=production
+  kf run-task myapp "./cleanup.sh" --async
+  `,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			appName := args[0]
+			command := args[1]
+
+			env, err := envutil.ParseCLIEnvVars(envs)
+			if err != nil {
+				return err
+			}
+
+			task := &v1alpha1.Task{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Task",
+					APIVersion: "kf.dev/v1alpha1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: p.Namespace,
+				},
+				Spec: v1alpha1.TaskSpec{
+					AppName: appName,
+					// The command is passed as an argument to the image's
+					// entrypoint so buildpack launchers can set up the
+					// environment before running it.
+					Args: []string{command},
+					Env:  env,
+				},
+			}
+
+			if name == "" {
+				task.GenerateName = fmt.Sprintf("%s-", appName)
+			}
+
+			created, err := client.Create(p.Namespace, task)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Task %s created for app %s\n", created.Name, appName)
+
+			if async {
+				return nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			tailDone := make(chan error, 1)
+			go func() {
+				tailDone <- client.Tail(ctx, p.Namespace, created.Name, cmd.OutOrStdout())
+			}()
+
+			finished, err := client.WaitForCompletion(ctx, p.Namespace, created.Name, taskPollInterval)
+			if err != nil {
+				return err
+			}
+
+			// If the process ran, let the log stream drain before reporting
+			// the result otherwise there won't be any logs to wait for.
+			if finished.Status.ExitCode != nil {
+				if err := <-tailDone; err != nil {
+					fmt.Fprintf(cmd.OutOrStdout(), "Couldn't read logs: %s\n", err)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Task %s exited with code %s\n", finished.Name, tasks.ExitCode(*finished))
+			}
+
+			if _, err := tasks.TaskStatus(*finished); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Task %s succeeded\n", finished.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&name,
+		"name",
+		"",
+		"Name of the task. Defaults to a generated name based on the app.",
+	)
+
+	cmd.Flags().StringArrayVarP(
+		&envs,
+		"env",
+		"e",
+		nil,
+		"Set environment variables. Multiple can be set by using the flag multiple times (e.g., NAME=VALUE).",
+	)
+
+	cmd.Flags().BoolVar(
+		&async,
+		"async",
+		false,
+		"Don't wait for the task to complete.",
+	)
+
+	return cmd
+}
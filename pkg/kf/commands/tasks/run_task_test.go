@@ -0,0 +1,153 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/tasks/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func finishedTask(status corev1.ConditionStatus, exitCode int32) *v1alpha1.Task {
+	task := &v1alpha1.Task{}
+	task.Name = "my-task"
+	task.Status.Conditions = []apis.Condition{{
+		Type:    v1alpha1.TaskConditionSucceeded,
+		Status:  status,
+		Reason:  "SomeReason",
+		Message: "some message",
+	}}
+	task.Status.ExitCode = &exitCode
+	return task
+}
+
+func TestNewRunTaskCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		setup     func(t *testing.T, fakeTasks *fake.FakeClient)
+
+		wantErr         error
+		expectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{"my-app"},
+			wantErr: errors.New("accepts 2 arg(s), received 1"),
+		},
+		"missing namespace": {
+			args:    []string{"my-app", "rake db:migrate"},
+			wantErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		"invalid env": {
+			args:      []string{"my-app", "rake db:migrate", "--env", "INVALID"},
+			namespace: "my-ns",
+			wantErr:   errors.New("malformed environment variable: INVALID"),
+		},
+		"async": {
+			args:      []string{"my-app", "rake db:migrate", "--async", "--name", "my-task", "--env", "FOO=bar"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.
+					EXPECT().
+					Create("my-ns", gomock.Any()).
+					DoAndReturn(func(ns string, task *v1alpha1.Task, opts ...interface{}) (*v1alpha1.Task, error) {
+						testutil.AssertEqual(t, "name", "my-task", task.Name)
+						testutil.AssertEqual(t, "app", "my-app", task.Spec.AppName)
+						testutil.AssertEqual(t, "args", []string{"rake db:migrate"}, task.Spec.Args)
+						testutil.AssertEqual(t, "env", []corev1.EnvVar{{Name: "FOO", Value: "bar"}}, task.Spec.Env)
+						return task, nil
+					})
+			},
+			expectedStrings: []string{"Task my-task created for app my-app"},
+		},
+		"generated name": {
+			args:      []string{"my-app", "rake db:migrate", "--async"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.
+					EXPECT().
+					Create("my-ns", gomock.Any()).
+					DoAndReturn(func(ns string, task *v1alpha1.Task, opts ...interface{}) (*v1alpha1.Task, error) {
+						testutil.AssertEqual(t, "generateName", "my-app-", task.GenerateName)
+						task.Name = "my-app-abcde"
+						return task, nil
+					})
+			},
+			expectedStrings: []string{"Task my-app-abcde created"},
+		},
+		"waits for success": {
+			args:      []string{"my-app", "rake db:migrate", "--name", "my-task"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.EXPECT().Create("my-ns", gomock.Any()).Return(finishedTask(corev1.ConditionUnknown, 0), nil)
+				fakeTasks.EXPECT().Tail(gomock.Any(), "my-ns", "my-task", gomock.Any())
+				fakeTasks.EXPECT().WaitForCompletion(gomock.Any(), "my-ns", "my-task", gomock.Any()).Return(finishedTask(corev1.ConditionTrue, 0), nil)
+			},
+			expectedStrings: []string{"exited with code 0", "Task my-task succeeded"},
+		},
+		"task fails": {
+			args:      []string{"my-app", "rake db:migrate", "--name", "my-task"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.EXPECT().Create("my-ns", gomock.Any()).Return(finishedTask(corev1.ConditionUnknown, 0), nil)
+				fakeTasks.EXPECT().Tail(gomock.Any(), "my-ns", "my-task", gomock.Any())
+				fakeTasks.EXPECT().WaitForCompletion(gomock.Any(), "my-ns", "my-task", gomock.Any()).Return(finishedTask(corev1.ConditionFalse, 2), nil)
+			},
+			wantErr:         errors.New("task failed for reason: SomeReason with message: some message"),
+			expectedStrings: []string{"exited with code 2"},
+		},
+		"create fails": {
+			args:      []string{"my-app", "rake db:migrate"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.EXPECT().Create("my-ns", gomock.Any()).Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeTasks := fake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeTasks)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewRunTaskCommand(&config.KfParams{Namespace: tc.namespace}, fakeTasks)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta/table"
+)
+
+// NewTasksCommand allows users to list the tasks of an app.
+func NewTasksCommand(p *config.KfParams, client tasks.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "tasks APP_NAME",
+		Short:   "List the tasks of an app",
+		Example: `  kf tasks myapp`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			appName := args[0]
+
+			list, err := client.List(p.Namespace, tasks.WithListFilters([]tasks.Predicate{
+				func(task *v1alpha1.Task) bool {
+					return task.Spec.AppName == appName
+				},
+			}))
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 8, 4, 1, ' ', tabwriter.StripEscape)
+			defer w.Flush()
+
+			fmt.Fprintln(w, "Name\tAge\tSucceeded\tReason\tExit Code\tCommand")
+			for _, task := range list {
+				succeeded := ""
+				reason := ""
+				if cond := task.Status.GetCondition(v1alpha1.TaskConditionSucceeded); cond != nil {
+					succeeded = fmt.Sprintf("%v", cond.Status)
+					reason = cond.Reason
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s",
+					task.Name,
+					table.ConvertToHumanReadableDateType(task.CreationTimestamp),
+					succeeded,
+					reason,
+					tasks.ExitCode(task),
+					tasks.Command(task),
+				)
+				fmt.Fprintln(w)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/tasks/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	"knative.dev/pkg/apis"
+)
+
+func TestNewTasksCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		setup     func(t *testing.T, fakeTasks *fake.FakeClient)
+
+		wantErr         error
+		expectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"missing namespace": {
+			args:    []string{"my-app"},
+			wantErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		"no contents": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.
+					EXPECT().
+					List("my-ns", gomock.Any()).
+					Return(nil, nil)
+			},
+			expectedStrings: []string{"Name", "Age", "Succeeded", "Reason", "Exit Code", "Command"},
+		},
+		"contents": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				exitCode := int32(7)
+				task := v1alpha1.Task{}
+				task.Name = "my-task"
+				task.Spec.AppName = "my-app"
+				task.Spec.Args = []string{"rake db:migrate"}
+				task.Status.Conditions = []apis.Condition{{
+					Type:   "Succeeded",
+					Status: "TESTING",
+					Reason: "SomeMessage",
+				}}
+				task.Status.ExitCode = &exitCode
+
+				fakeTasks.
+					EXPECT().
+					List("my-ns", gomock.Any()).
+					Return([]v1alpha1.Task{task}, nil)
+			},
+			expectedStrings: []string{"my-task", "TESTING", "SomeMessage", "7", "rake db:migrate"},
+		},
+		"server failure": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.
+					EXPECT().
+					List("my-ns", gomock.Any()).
+					Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeTasks := fake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeTasks)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewTasksCommand(&config.KfParams{Namespace: tc.namespace}, fakeTasks)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -10,24 +10,31 @@ import (
 	"github.com/google/kf/pkg/client/clientset/versioned/typed/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf"
 	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/buildbackends"
 	"github.com/google/kf/pkg/kf/buildpacks"
 	builds2 "github.com/google/kf/pkg/kf/builds"
 	apps2 "github.com/google/kf/pkg/kf/commands/apps"
 	buildpacks2 "github.com/google/kf/pkg/kf/commands/buildpacks"
 	"github.com/google/kf/pkg/kf/commands/builds"
 	"github.com/google/kf/pkg/kf/commands/config"
+	domains2 "github.com/google/kf/pkg/kf/commands/domains"
 	"github.com/google/kf/pkg/kf/commands/quotas"
 	routes2 "github.com/google/kf/pkg/kf/commands/routes"
 	servicebindings2 "github.com/google/kf/pkg/kf/commands/service-bindings"
 	services2 "github.com/google/kf/pkg/kf/commands/services"
 	spaces2 "github.com/google/kf/pkg/kf/commands/spaces"
+	tasks2 "github.com/google/kf/pkg/kf/commands/tasks"
+	"github.com/google/kf/pkg/kf/domains"
+	"github.com/google/kf/pkg/kf/droplets"
 	"github.com/google/kf/pkg/kf/logs"
 	"github.com/google/kf/pkg/kf/routes"
 	"github.com/google/kf/pkg/kf/service-bindings"
 	"github.com/google/kf/pkg/kf/services"
+	"github.com/google/kf/pkg/kf/sourceimage"
 	"github.com/google/kf/pkg/kf/sources"
 	"github.com/google/kf/pkg/kf/spaces"
 	"github.com/google/kf/pkg/kf/systemenvinjector"
+	"github.com/google/kf/pkg/kf/tasks"
 	"github.com/google/wire"
 	logs2 "github.com/knative/build/pkg/logs"
 	"github.com/poy/kontext"
@@ -47,15 +54,29 @@ func InjectPush(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	pusher := apps.NewPusher(appsClient)
 	srcImageBuilder := provideSrcImageBuilder()
+	importer := provideDropletImporter()
 	servicecatalogV1beta1Interface := config.GetServiceCatalogClient(p)
 	clientInterface := config.GetSecretClient(p)
 	servicebindingsClientInterface := servicebindings.NewClient(servicecatalogV1beta1Interface, clientInterface)
-	command := apps2.NewPushCommand(p, appsClient, pusher, srcImageBuilder, servicebindingsClientInterface)
+	buildpacksClient := InjectBuildpacksClient(p)
+	command := apps2.NewPushCommand(p, appsClient, pusher, srcImageBuilder, importer, servicebindingsClientInterface, buildpacksClient)
+	return command
+}
+
+func InjectStage(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
+	stager := apps.NewStager(client)
+	srcImageBuilder := provideSrcImageBuilder()
+	buildpacksClient := InjectBuildpacksClient(p)
+	command := apps2.NewStageCommand(p, stager, srcImageBuilder, buildpacksClient)
 	return command
 }
 
@@ -64,8 +85,8 @@ func InjectDelete(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	command := apps2.NewDeleteCommand(p, appsClient)
 	return command
@@ -76,8 +97,8 @@ func InjectApps(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	command := apps2.NewAppsCommand(p, appsClient)
 	return command
@@ -88,10 +109,11 @@ func InjectGetApp(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
-	command := apps2.NewGetAppCommand(p, appsClient)
+	routesClient := routes.NewClient(kfV1alpha1Interface)
+	command := apps2.NewGetAppCommand(p, appsClient, routesClient)
 	return command
 }
 
@@ -100,8 +122,8 @@ func InjectScale(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	command := apps2.NewScaleCommand(p, appsClient)
 	return command
@@ -112,8 +134,8 @@ func InjectStart(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	command := apps2.NewStartCommand(p, appsClient)
 	return command
@@ -124,8 +146,8 @@ func InjectStop(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	command := apps2.NewStopCommand(p, appsClient)
 	return command
@@ -136,8 +158,8 @@ func InjectRestart(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	command := apps2.NewRestartCommand(p, appsClient)
 	return command
@@ -148,20 +170,95 @@ func InjectRestage(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	command := apps2.NewRestageCommand(p, appsClient)
 	return command
 }
 
+func InjectClearBuildCache(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	persistentVolumeClaimsGetter := providePersistentVolumeClaims(p)
+	command := apps2.NewClearBuildCacheCommand(p, appsClient, persistentVolumeClaimsGetter)
+	return command
+}
+
+func InjectRevisions(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	command := apps2.NewRevisionsCommand(p, appsClient)
+	return command
+}
+
+func InjectRollback(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	command := apps2.NewRollbackCommand(p, appsClient)
+	return command
+}
+
+func InjectRebase(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	command := apps2.NewRebaseCommand(p, appsClient)
+	return command
+}
+
+func InjectDownloadDroplet(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
+	exporter := provideDropletExporter()
+	command := apps2.NewDownloadDropletCommand(p, client, exporter)
+	return command
+}
+
+func InjectPromote(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	copier := provideDropletCopier()
+	promoter := apps.NewPromoter(appsClient, copier)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	spacesClient := spaces.NewClient(spacesGetter)
+	command := apps2.NewPromoteCommand(p, promoter, spacesClient)
+	return command
+}
+
 func InjectProxy(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	kubernetesInterface := config.GetKubernetes(p)
 	ingressLister := kf.NewIstioClient(kubernetesInterface)
@@ -181,8 +278,8 @@ func InjectEnv(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	command := apps2.NewEnvCommand(p, appsClient)
 	return command
@@ -193,8 +290,8 @@ func InjectSetEnv(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	command := apps2.NewSetEnvCommand(p, appsClient)
 	return command
@@ -205,8 +302,8 @@ func InjectUnsetEnv(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
 	command := apps2.NewUnsetEnvCommand(p, appsClient)
 	return command
@@ -255,6 +352,20 @@ func InjectMarketplace(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectCreateUserProvidedService(p *config.KfParams) *cobra.Command {
+	clientInterface := config.GetSecretClient(p)
+	userProvidedClientInterface := services.NewUserProvidedClient(clientInterface)
+	command := services2.NewCreateUserProvidedServiceCommand(p, userProvidedClientInterface)
+	return command
+}
+
+func InjectUpdateUserProvidedService(p *config.KfParams) *cobra.Command {
+	clientInterface := config.GetSecretClient(p)
+	userProvidedClientInterface := services.NewUserProvidedClient(clientInterface)
+	command := services2.NewUpdateUserProvidedServiceCommand(p, userProvidedClientInterface)
+	return command
+}
+
 func InjectBindingService(p *config.KfParams) *cobra.Command {
 	servicecatalogV1beta1Interface := config.GetServiceCatalogClient(p)
 	clientInterface := config.GetSecretClient(p)
@@ -325,7 +436,9 @@ func InjectCreateSpace(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
 	client := spaces.NewClient(spacesGetter)
-	command := spaces2.NewCreateSpaceCommand(p, client)
+	domainsGetter := provideKfDomains(kfV1alpha1Interface)
+	domainsClient := domains.NewClient(domainsGetter)
+	command := spaces2.NewCreateSpaceCommand(p, client, domainsClient)
 	return command
 }
 
@@ -345,6 +458,54 @@ func InjectConfigSpace(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectSetSpaceRole(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	client := spaces.NewClient(spacesGetter)
+	command := spaces2.NewSetSpaceRoleCommand(p, client)
+	return command
+}
+
+func InjectUnsetSpaceRole(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	client := spaces.NewClient(spacesGetter)
+	command := spaces2.NewUnsetSpaceRoleCommand(p, client)
+	return command
+}
+
+func InjectSpaceUsers(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	client := spaces.NewClient(spacesGetter)
+	command := spaces2.NewSpaceUsersCommand(p, client)
+	return command
+}
+
+func InjectAddNetworkPolicy(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	client := spaces.NewClient(spacesGetter)
+	command := spaces2.NewAddNetworkPolicyCommand(p, client)
+	return command
+}
+
+func InjectRemoveNetworkPolicy(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	client := spaces.NewClient(spacesGetter)
+	command := spaces2.NewRemoveNetworkPolicyCommand(p, client)
+	return command
+}
+
+func InjectNetworkPolicies(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	client := spaces.NewClient(spacesGetter)
+	command := spaces2.NewNetworkPoliciesCommand(p, client)
+	return command
+}
+
 func InjectCreateQuota(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
@@ -404,8 +565,8 @@ func InjectMapRoute(p *config.KfParams) *cobra.Command {
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	systemEnvInjectorInterface := provideSystemEnvInjector(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	sourcesClient := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	sourcesClient := sources.NewClient(sourcesGetter, registry)
 	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, sourcesClient)
 	command := routes2.NewMapRouteCommand(p, client, appsClient)
 	return command
@@ -418,11 +579,43 @@ func InjectUnmapRoute(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectDomains(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	domainsGetter := provideKfDomains(kfV1alpha1Interface)
+	client := domains.NewClient(domainsGetter)
+	command := domains2.NewDomainsCommand(p, client)
+	return command
+}
+
+func InjectCreateDomain(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	domainsGetter := provideKfDomains(kfV1alpha1Interface)
+	client := domains.NewClient(domainsGetter)
+	command := domains2.NewCreateDomainCommand(p, client)
+	return command
+}
+
+func InjectCreatePrivateDomain(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	domainsGetter := provideKfDomains(kfV1alpha1Interface)
+	client := domains.NewClient(domainsGetter)
+	command := domains2.NewCreatePrivateDomainCommand(p, client)
+	return command
+}
+
+func InjectDeleteDomain(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	domainsGetter := provideKfDomains(kfV1alpha1Interface)
+	client := domains.NewClient(domainsGetter)
+	command := domains2.NewDeleteDomainCommand(p, client)
+	return command
+}
+
 func InjectBuilds(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	command := builds.NewListBuildsCommand(p, client)
 	return command
 }
@@ -430,16 +623,64 @@ func InjectBuilds(p *config.KfParams) *cobra.Command {
 func InjectBuildLogs(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
-	buildTailer := provideSourcesBuildTailer()
-	client := sources.NewClient(sourcesGetter, buildTailer)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
 	command := builds.NewBuildLogsCommand(p, client)
 	return command
 }
 
+func InjectCancelBuild(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	registry := provideSourcesBuildBackends(p)
+	client := sources.NewClient(sourcesGetter, registry)
+	command := builds.NewCancelBuildCommand(p, client)
+	return command
+}
+
+func InjectRunTask(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	tasksGetter := provideKfTasks(kfV1alpha1Interface)
+	coreV1Interface := provideCoreV1(p)
+	client := tasks.NewClient(tasksGetter, coreV1Interface)
+	command := tasks2.NewRunTaskCommand(p, client)
+	return command
+}
+
+func InjectTasks(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	tasksGetter := provideKfTasks(kfV1alpha1Interface)
+	coreV1Interface := provideCoreV1(p)
+	client := tasks.NewClient(tasksGetter, coreV1Interface)
+	command := tasks2.NewTasksCommand(p, client)
+	return command
+}
+
+func InjectTerminateTask(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	tasksGetter := provideKfTasks(kfV1alpha1Interface)
+	coreV1Interface := provideCoreV1(p)
+	client := tasks.NewClient(tasksGetter, coreV1Interface)
+	command := tasks2.NewTerminateTaskCommand(p, client)
+	return command
+}
+
 // wire_injector.go:
 
 func provideSrcImageBuilder() apps2.SrcImageBuilder {
-	return apps2.SrcImageBuilderFunc(kontext.BuildImage)
+	return apps2.SrcImageBuilderFunc(sourceimage.WithIgnores(kontext.BuildImage))
+}
+
+func provideDropletImporter() droplets.Importer {
+	return droplets.Import
+}
+
+func provideDropletExporter() droplets.Exporter {
+	return droplets.Export
+}
+
+func provideDropletCopier() droplets.Copier {
+	return droplets.Copy
 }
 
 func provideBuildTailer() builds2.BuildTailer {
@@ -459,6 +700,10 @@ func provideCoreV1(p *config.KfParams) v1.CoreV1Interface {
 	return config.GetKubernetes(p).CoreV1()
 }
 
+func providePersistentVolumeClaims(p *config.KfParams) v1.PersistentVolumeClaimsGetter {
+	return provideCoreV1(p)
+}
+
 /////////////////
 // Buildpacks //
 ///////////////
@@ -472,12 +717,27 @@ func provideKfSpaces(ki v1alpha1.KfV1alpha1Interface) v1alpha1.SpacesGetter {
 	return ki
 }
 
-var SourcesSet = wire.NewSet(config.GetKfClient, provideSourcesBuildTailer, provideKfSources, sources.NewClient)
+var DomainsSet = wire.NewSet(config.GetKfClient, provideKfDomains, domains.NewClient)
+
+func provideKfDomains(ki v1alpha1.KfV1alpha1Interface) v1alpha1.DomainsGetter {
+	return ki
+}
+
+var SourcesSet = wire.NewSet(config.GetKfClient, provideSourcesBuildBackends, provideKfSources, sources.NewClient)
 
 func provideKfSources(ki v1alpha1.KfV1alpha1Interface) v1alpha1.SourcesGetter {
 	return ki
 }
 
-func provideSourcesBuildTailer() sources.BuildTailer {
-	return builds2.BuildTailerFunc(logs2.Tail)
+func provideSourcesBuildBackends(p *config.KfParams) buildbackends.Registry {
+	return buildbackends.NewRegistry(
+		buildbackends.NewKnativeBuild(config.GetKfBuildClient(p), nil, nil, buildbackends.BuildTailerFunc(logs2.Tail)),
+		buildbackends.NewTekton(config.GetDynamicClient(p), nil, nil, config.GetKubernetes(p).CoreV1()),
+	)
+}
+
+var TasksSet = wire.NewSet(config.GetKfClient, provideKfTasks, provideCoreV1, tasks.NewClient)
+
+func provideKfTasks(ki v1alpha1.KfV1alpha1Interface) v1alpha1.TasksGetter {
+	return ki
 }
@@ -21,24 +21,31 @@ import (
 	kfv1alpha1 "github.com/google/kf/pkg/client/clientset/versioned/typed/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf"
 	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/buildbackends"
 	"github.com/google/kf/pkg/kf/buildpacks"
 	"github.com/google/kf/pkg/kf/builds"
 	capps "github.com/google/kf/pkg/kf/commands/apps"
 	cbuildpacks "github.com/google/kf/pkg/kf/commands/buildpacks"
 	cbuilds "github.com/google/kf/pkg/kf/commands/builds"
 	"github.com/google/kf/pkg/kf/commands/config"
+	cdomains "github.com/google/kf/pkg/kf/commands/domains"
 	cquotas "github.com/google/kf/pkg/kf/commands/quotas"
 	croutes "github.com/google/kf/pkg/kf/commands/routes"
 	servicebindingscmd "github.com/google/kf/pkg/kf/commands/service-bindings"
 	servicescmd "github.com/google/kf/pkg/kf/commands/services"
 	cspaces "github.com/google/kf/pkg/kf/commands/spaces"
+	ctasks "github.com/google/kf/pkg/kf/commands/tasks"
+	"github.com/google/kf/pkg/kf/domains"
+	"github.com/google/kf/pkg/kf/droplets"
 	kflogs "github.com/google/kf/pkg/kf/logs"
 	"github.com/google/kf/pkg/kf/routes"
 	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
 	"github.com/google/kf/pkg/kf/services"
+	"github.com/google/kf/pkg/kf/sourceimage"
 	"github.com/google/kf/pkg/kf/sources"
 	"github.com/google/kf/pkg/kf/spaces"
 	"github.com/google/kf/pkg/kf/systemenvinjector"
+	"github.com/google/kf/pkg/kf/tasks"
 	"github.com/google/wire"
 	"github.com/knative/build/pkg/logs"
 	"github.com/poy/kontext"
@@ -47,7 +54,19 @@ import (
 )
 
 func provideSrcImageBuilder() capps.SrcImageBuilder {
-	return capps.SrcImageBuilderFunc(kontext.BuildImage)
+	return capps.SrcImageBuilderFunc(sourceimage.WithIgnores(kontext.BuildImage))
+}
+
+func provideDropletImporter() droplets.Importer {
+	return droplets.Import
+}
+
+func provideDropletExporter() droplets.Exporter {
+	return droplets.Export
+}
+
+func provideDropletCopier() droplets.Copier {
+	return droplets.Copy
 }
 
 func provideBuildTailer() builds.BuildTailer {
@@ -74,14 +93,27 @@ func InjectPush(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		capps.NewPushCommand,
 		provideSrcImageBuilder,
+		provideDropletImporter,
 		servicebindings.NewClient,
 		config.GetServiceCatalogClient,
 		config.GetSecretClient,
+		InjectBuildpacksClient,
 		AppsSet,
 	)
 	return nil
 }
 
+func InjectStage(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		capps.NewStageCommand,
+		apps.NewStager,
+		provideSrcImageBuilder,
+		InjectBuildpacksClient,
+		SourcesSet,
+	)
+	return nil
+}
+
 func InjectDelete(p *config.KfParams) *cobra.Command {
 	wire.Build(capps.NewDeleteCommand, AppsSet)
 
@@ -95,7 +127,7 @@ func InjectApps(p *config.KfParams) *cobra.Command {
 }
 
 func InjectGetApp(p *config.KfParams) *cobra.Command {
-	wire.Build(capps.NewGetAppCommand, AppsSet)
+	wire.Build(capps.NewGetAppCommand, routes.NewClient, AppsSet)
 
 	return nil
 }
@@ -125,6 +157,47 @@ func InjectRestage(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectClearBuildCache(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		capps.NewClearBuildCacheCommand,
+		AppsSet,
+		providePersistentVolumeClaims,
+	)
+	return nil
+}
+
+func InjectRevisions(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewRevisionsCommand, AppsSet)
+	return nil
+}
+
+func InjectRollback(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewRollbackCommand, AppsSet)
+	return nil
+}
+
+func InjectRebase(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewRebaseCommand, AppsSet)
+	return nil
+}
+
+func InjectDownloadDroplet(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewDownloadDropletCommand, provideDropletExporter, SourcesSet)
+	return nil
+}
+
+func InjectPromote(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		capps.NewPromoteCommand,
+		apps.NewPromoter,
+		provideDropletCopier,
+		AppsSet,
+		provideKfSpaces,
+		spaces.NewClient,
+	)
+	return nil
+}
+
 func InjectProxy(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		capps.NewProxyCommand,
@@ -148,6 +221,10 @@ func provideCoreV1(p *config.KfParams) corev1.CoreV1Interface {
 	return config.GetKubernetes(p).CoreV1()
 }
 
+func providePersistentVolumeClaims(p *config.KfParams) corev1.PersistentVolumeClaimsGetter {
+	return provideCoreV1(p)
+}
+
 /////////////////////////////////////
 // Environment Variables Commands //
 ///////////////////////////////////
@@ -228,6 +305,24 @@ func InjectMarketplace(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectCreateUserProvidedService(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		services.NewUserProvidedClient,
+		servicescmd.NewCreateUserProvidedServiceCommand,
+		config.GetSecretClient,
+	)
+	return nil
+}
+
+func InjectUpdateUserProvidedService(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		services.NewUserProvidedClient,
+		servicescmd.NewUpdateUserProvidedServiceCommand,
+		config.GetSecretClient,
+	)
+	return nil
+}
+
 ///////////////////////
 // Service Bindings //
 /////////////////////
@@ -325,7 +420,14 @@ func InjectSpace(p *config.KfParams) *cobra.Command {
 }
 
 func InjectCreateSpace(p *config.KfParams) *cobra.Command {
-	wire.Build(cspaces.NewCreateSpaceCommand, SpacesSet)
+	wire.Build(
+		cspaces.NewCreateSpaceCommand,
+		config.GetKfClient,
+		provideKfSpaces,
+		spaces.NewClient,
+		provideKfDomains,
+		domains.NewClient,
+	)
 
 	return nil
 }
@@ -342,6 +444,42 @@ func InjectConfigSpace(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectSetSpaceRole(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewSetSpaceRoleCommand, SpacesSet)
+
+	return nil
+}
+
+func InjectUnsetSpaceRole(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewUnsetSpaceRoleCommand, SpacesSet)
+
+	return nil
+}
+
+func InjectSpaceUsers(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewSpaceUsersCommand, SpacesSet)
+
+	return nil
+}
+
+func InjectAddNetworkPolicy(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewAddNetworkPolicyCommand, SpacesSet)
+
+	return nil
+}
+
+func InjectRemoveNetworkPolicy(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewRemoveNetworkPolicyCommand, SpacesSet)
+
+	return nil
+}
+
+func InjectNetworkPolicies(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewNetworkPoliciesCommand, SpacesSet)
+
+	return nil
+}
+
 ////////////////////
 // Quotas Command //
 ////////////////////
@@ -419,18 +557,55 @@ func InjectUnmapRoute(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+/////////////
+// Domains //
+/////////////
+
+var DomainsSet = wire.NewSet(config.GetKfClient, provideKfDomains, domains.NewClient)
+
+func provideKfDomains(ki kfv1alpha1.KfV1alpha1Interface) kfv1alpha1.DomainsGetter {
+	return ki
+}
+
+func InjectDomains(p *config.KfParams) *cobra.Command {
+	wire.Build(cdomains.NewDomainsCommand, DomainsSet)
+
+	return nil
+}
+
+func InjectCreateDomain(p *config.KfParams) *cobra.Command {
+	wire.Build(cdomains.NewCreateDomainCommand, DomainsSet)
+
+	return nil
+}
+
+func InjectCreatePrivateDomain(p *config.KfParams) *cobra.Command {
+	wire.Build(cdomains.NewCreatePrivateDomainCommand, DomainsSet)
+
+	return nil
+}
+
+func InjectDeleteDomain(p *config.KfParams) *cobra.Command {
+	wire.Build(cdomains.NewDeleteDomainCommand, DomainsSet)
+
+	return nil
+}
+
 ////////////////////
 // Builds Command //
 ////////////////////
 
-var SourcesSet = wire.NewSet(config.GetKfClient, provideSourcesBuildTailer, provideKfSources, sources.NewClient)
+var SourcesSet = wire.NewSet(config.GetKfClient, provideSourcesBuildBackends, provideKfSources, sources.NewClient)
 
 func provideKfSources(ki kfv1alpha1.KfV1alpha1Interface) kfv1alpha1.SourcesGetter {
 	return ki
 }
 
-func provideSourcesBuildTailer() sources.BuildTailer {
-	return builds.BuildTailerFunc(logs.Tail)
+func provideSourcesBuildBackends(p *config.KfParams) buildbackends.Registry {
+	return buildbackends.NewRegistry(
+		buildbackends.NewKnativeBuild(config.GetKfBuildClient(p), nil, nil, buildbackends.BuildTailerFunc(logs.Tail)),
+		buildbackends.NewTekton(config.GetDynamicClient(p), nil, nil, config.GetKubernetes(p).CoreV1()),
+	)
 }
 
 func InjectBuilds(p *config.KfParams) *cobra.Command {
@@ -444,3 +619,37 @@ func InjectBuildLogs(p *config.KfParams) *cobra.Command {
 
 	return nil
 }
+
+func InjectCancelBuild(p *config.KfParams) *cobra.Command {
+	wire.Build(cbuilds.NewCancelBuildCommand, SourcesSet)
+
+	return nil
+}
+
+///////////////////
+// Task Commands //
+///////////////////
+
+var TasksSet = wire.NewSet(config.GetKfClient, provideKfTasks, provideCoreV1, tasks.NewClient)
+
+func provideKfTasks(ki kfv1alpha1.KfV1alpha1Interface) kfv1alpha1.TasksGetter {
+	return ki
+}
+
+func InjectRunTask(p *config.KfParams) *cobra.Command {
+	wire.Build(ctasks.NewRunTaskCommand, TasksSet)
+
+	return nil
+}
+
+func InjectTasks(p *config.KfParams) *cobra.Command {
+	wire.Build(ctasks.NewTasksCommand, TasksSet)
+
+	return nil
+}
+
+func InjectTerminateTask(p *config.KfParams) *cobra.Command {
+	wire.Build(ctasks.NewTerminateTaskCommand, TasksSet)
+
+	return nil
+}
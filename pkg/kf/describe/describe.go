@@ -157,6 +157,8 @@ func SourceSpec(w io.Writer, spec kfv1alpha1.SourceSpec) {
 			fmt.Fprintln(w, "Build Type:\tcontainer")
 		case spec.IsBuildpackBuild():
 			fmt.Fprintln(w, "Build Type:\tbuildpack")
+		case spec.IsDockerfileBuild():
+			fmt.Fprintln(w, "Build Type:\tdockerfile")
 		default:
 			fmt.Fprintln(w, "Build Type:\tunknown")
 		}
@@ -178,12 +180,71 @@ func SourceSpec(w io.Writer, spec kfv1alpha1.SourceSpec) {
 				buildpackBuild := spec.BuildpackBuild
 
 				fmt.Fprintf(w, "Source:\t%s\n", buildpackBuild.Source)
+				GitSource(w, buildpackBuild.Git)
 				fmt.Fprintf(w, "Stack:\t%s\n", buildpackBuild.Stack)
 				fmt.Fprintf(w, "Bulider:\t%s\n", buildpackBuild.BuildpackBuilder)
 				fmt.Fprintf(w, "Registry:\t%s\n", buildpackBuild.Registry)
 				EnvVars(w, buildpackBuild.Env)
 			})
 		}
+
+		if spec.IsDockerfileBuild() {
+			SectionWriter(w, "Dockerfile Build", func(w io.Writer) {
+				dockerfile := spec.Dockerfile
+
+				fmt.Fprintf(w, "Source:\t%s\n", dockerfile.Source)
+				GitSource(w, dockerfile.Git)
+				fmt.Fprintf(w, "Dockerfile:\t%s\n", dockerfile.Path)
+				fmt.Fprintf(w, "Registry:\t%s\n", dockerfile.Registry)
+				SectionWriter(w, "Build Args", func(w io.Writer) {
+					for _, arg := range dockerfile.BuildArgs {
+						fmt.Fprintf(w, "%s:\t%s\n", arg.Name, arg.Value)
+					}
+				})
+			})
+		}
+	})
+}
+
+// GitSource describes the Git repository a build clones its source from. Nothing
+// is written if the build doesn't use Git.
+func GitSource(w io.Writer, git *kfv1alpha1.SourceSpecGit) {
+	if git == nil {
+		return
+	}
+
+	SectionWriter(w, "Git", func(w io.Writer) {
+		fmt.Fprintf(w, "URL:\t%s\n", git.URL)
+		fmt.Fprintf(w, "Revision:\t%s\n", git.Revision)
+		fmt.Fprintf(w, "Subdirectory:\t%s\n", git.Subdirectory)
+		fmt.Fprintf(w, "Secret:\t%s\n", git.SecretName)
+	})
+}
+
+// AppRoutes describes the routes of an app and whether they're ready.
+func AppRoutes(w io.Writer, routes []kfv1alpha1.Route) {
+
+	SectionWriter(w, "Routes", func(w io.Writer) {
+		if len(routes) == 0 {
+			return
+		}
+
+		fmt.Fprintln(w, "Host\tDomain\tPath\tReady\tReason")
+		for _, route := range routes {
+			var status, reason string
+			if cond := route.Status.GetCondition(kfv1alpha1.RouteConditionReady); cond != nil {
+				status = string(cond.Status)
+				reason = cond.Reason
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				route.Spec.Hostname,
+				route.Spec.Domain,
+				route.Spec.Path,
+				status,
+				reason,
+			)
+		}
 	})
 }
 
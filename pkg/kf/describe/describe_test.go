@@ -197,6 +197,47 @@ func ExampleDuckStatus_succeeded() {
 	//     NamespaceReady  False   <unknown>  couldn't create  NotOwned
 }
 
+func ExampleAppRoutes() {
+	notReady := kfv1alpha1.Route{
+		Spec: kfv1alpha1.RouteSpec{
+			RouteSpecFields: kfv1alpha1.RouteSpecFields{
+				Hostname: "api",
+				Domain:   "example.com",
+				Path:     "/v1",
+			},
+		},
+	}
+	notReady.Status.InitializeConditions()
+	notReady.Status.PropagateAppsStatus(nil, []string{"my-app"})
+
+	ready := kfv1alpha1.Route{
+		Spec: kfv1alpha1.RouteSpec{
+			RouteSpecFields: kfv1alpha1.RouteSpecFields{
+				Hostname: "my-app",
+				Domain:   "example.com",
+				Path:     "/",
+			},
+		},
+	}
+	ready.Status.InitializeConditions()
+	ready.Status.MarkHostnameReserved()
+	ready.Status.MarkVirtualServiceReady()
+	ready.Status.PropagateAppsStatus(nil, nil)
+
+	describe.AppRoutes(os.Stdout, []kfv1alpha1.Route{notReady, ready})
+
+	// Output: Routes:
+	//   Host    Domain       Path  Ready  Reason
+	//   api     example.com  /v1   False  AppNotFound
+	//   my-app  example.com  /     True
+}
+
+func ExampleAppRoutes_empty() {
+	describe.AppRoutes(os.Stdout, nil)
+
+	// Output: Routes: <empty>
+}
+
 func ExampleAppSpecInstances_exactly() {
 	exactly := 3
 	instances := kfv1alpha1.AppSpecInstances{}
@@ -279,6 +320,51 @@ func ExampleSourceSpec_docker() {
 	//     Image:  mysql/mysql
 }
 
+func ExampleGitSource() {
+	describe.GitSource(os.Stdout, &kfv1alpha1.SourceSpecGit{
+		URL:          "https://github.com/google/kf",
+		Revision:     "abc123",
+		Subdirectory: "samples/apps/helloworld",
+		SecretName:   "git-credentials",
+	})
+
+	// Output: Git:
+	//   URL:           https://github.com/google/kf
+	//   Revision:      abc123
+	//   Subdirectory:  samples/apps/helloworld
+	//   Secret:        git-credentials
+}
+
+func ExampleGitSource_nil() {
+	describe.GitSource(os.Stdout, nil)
+
+	// Output:
+}
+
+func ExampleSourceSpec_dockerfile() {
+	spec := kfv1alpha1.SourceSpec{
+		Dockerfile: kfv1alpha1.SourceSpecDockerfile{
+			Source:   "gcr.io/my-registry/src-mysource",
+			Path:     "docker/Dockerfile",
+			Registry: "gcr.io/my-registry",
+			BuildArgs: []corev1.EnvVar{
+				{Name: "VERSION", Value: "1.0"},
+			},
+		},
+	}
+
+	describe.SourceSpec(os.Stdout, spec)
+
+	// Output: Source:
+	//   Build Type:  dockerfile
+	//   Dockerfile Build:
+	//     Source:      gcr.io/my-registry/src-mysource
+	//     Dockerfile:  docker/Dockerfile
+	//     Registry:    gcr.io/my-registry
+	//     Build Args:
+	//       VERSION:  1.0
+}
+
 func ExampleHealthCheck_nil() {
 	describe.HealthCheck(os.Stdout, nil)
 
@@ -0,0 +1,125 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package droplets
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+// Exporter writes an image as a tarball, it's implemented by Export.
+type Exporter func(image string, out io.Writer) error
+
+// Importer uploads a tarball as an image, it's implemented by Import.
+type Importer func(path, image string) error
+
+// Copier copies an image to another registry, it's implemented by Copy.
+type Copier func(src, dst string) (string, error)
+
+// Export downloads the image and writes it to out as a tarball that can be
+// read by `docker load`. Credentials are read from the Docker config.
+func Export(image string, out io.Writer) error {
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	auth, err := authn.DefaultKeychain.Resolve(ref.Context().Registry)
+	if err != nil {
+		return err
+	}
+
+	img, err := remote.Image(ref, remote.WithAuth(auth))
+	if err != nil {
+		return err
+	}
+
+	return tarball.Write(ref, img, out)
+}
+
+// Import uploads the tarball at path to the image. The tarball may be one
+// written by Export or `docker save`. Credentials are read from the Docker
+// config.
+func Import(path, image string) error {
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	img, err := tarball.ImageFromPath(path, nil)
+	if err != nil {
+		return err
+	}
+
+	auth, err := authn.DefaultKeychain.Resolve(ref.Context().Registry)
+	if err != nil {
+		return err
+	}
+
+	return remote.Write(ref, img, auth, http.DefaultTransport)
+}
+
+// Copy copies the image src to dst and returns a reference to the copy by
+// digest. If dst is empty nothing is copied and a reference to src by digest
+// is returned. Layers are mounted from src rather than uploaded when both
+// images are in the same registry. Credentials are read from the Docker
+// config.
+func Copy(src, dst string) (string, error) {
+	srcRef, err := name.ParseReference(src, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+
+	srcAuth, err := authn.DefaultKeychain.Resolve(srcRef.Context().Registry)
+	if err != nil {
+		return "", err
+	}
+
+	img, err := remote.Image(srcRef, remote.WithAuth(srcAuth))
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	if dst == "" {
+		return fmt.Sprintf("%s@%s", srcRef.Context().Name(), digest), nil
+	}
+
+	dstRef, err := name.ParseReference(dst, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+
+	dstAuth, err := authn.DefaultKeychain.Resolve(dstRef.Context().Registry)
+	if err != nil {
+		return "", err
+	}
+
+	if err := remote.Write(dstRef, img, dstAuth, http.DefaultTransport); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s@%s", dstRef.Context().Name(), digest), nil
+}
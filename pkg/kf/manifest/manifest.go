@@ -19,13 +19,19 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/google/kf/pkg/internal/envutil"
 	"github.com/imdario/mergo"
 	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // Application is a configuration for a single 12-factor-app.
@@ -40,6 +46,41 @@ type Application struct {
 	MaxScale   *int              `yaml:"maxScale,omitempty"`
 	Routes     []Route           `yaml:"routes,omitempty"`
 
+	// Instances holds a static number of instances to run. It can't be used
+	// with MinScale or MaxScale.
+	Instances *int `yaml:"instances,omitempty"`
+
+	// Memory holds the memory limit of each instance e.g. 512M or 1G.
+	Memory string `yaml:"memory,omitempty"`
+
+	// DiskQuota holds the disk limit of each instance e.g. 512M or 1G.
+	DiskQuota string `yaml:"disk_quota,omitempty"`
+
+	// Command holds the command used to start the app. It's run in a shell.
+	Command string `yaml:"command,omitempty"`
+
+	// Stack holds the base layer used for buildpack builds.
+	Stack string `yaml:"stack,omitempty"`
+
+	// BuildCache holds the size of a persistent cache kept between buildpack
+	// builds e.g. 2G. Builds aren't cached if it's blank.
+	BuildCache string `yaml:"build-cache,omitempty"`
+
+	// NoRoute removes all routes from the app.
+	NoRoute bool `yaml:"no-route,omitempty"`
+
+	// RandomRoute creates a route with a random hostname if the app doesn't
+	// have any routes.
+	RandomRoute bool `yaml:"random-route,omitempty"`
+
+	// Host, Hosts, Domain, Domains and NoHostname are deprecated Cloud Foundry
+	// fields used to build routes. They can't be used with Routes.
+	Host       string   `yaml:"host,omitempty"`
+	Hosts      []string `yaml:"hosts,omitempty"`
+	Domain     string   `yaml:"domain,omitempty"`
+	Domains    []string `yaml:"domains,omitempty"`
+	NoHostname bool     `yaml:"no-hostname,omitempty"`
+
 	// HealthCheckTimeout holds the health check timeout.
 	// Note the serialized field is just timeout.
 	HealthCheckTimeout int `yaml:"timeout,omitempty"`
@@ -56,6 +97,10 @@ type Application struct {
 // AppDockerImage is the struct for docker configuration.
 type AppDockerImage struct {
 	Image string `yaml:"image,omitempty"`
+
+	// Dockerfile holds the path of a Dockerfile, relative to the app's path,
+	// used to build the app's source. It can't be used with Image.
+	Dockerfile string `yaml:"dockerfile,omitempty"`
 }
 
 // Route is a route name (including hostname, domain, and path) for an application.
@@ -66,33 +111,229 @@ type Route struct {
 // Manifest is an application's configuration.
 type Manifest struct {
 	Applications []Application `yaml:"applications"`
+
+	// Inherit holds the path to a parent manifest, relative to this one.
+	// Applications and top-level properties are inherited from the parent.
+	Inherit string `yaml:"inherit,omitempty"`
+
+	// Defaults holds the top-level properties that apply to every
+	// application in the manifest.
+	Defaults Application `yaml:",inline"`
+
+	// Warnings holds fields in the manifest that kf doesn't support.
+	Warnings []Warning `yaml:"-"`
+}
+
+// Warning describes a field in a manifest that is ignored by kf.
+type Warning struct {
+	// Application is the name of the application the field belongs to, it's
+	// blank for top-level fields.
+	Application string
+
+	// Field is the path of the field.
+	Field string
+
+	// Message describes the problem.
+	Message string
 }
 
-// NewFromFile creates a Manifest from a manifest file.
-func NewFromFile(manifestFile string) (*Manifest, error) {
-	reader, err := os.Open(manifestFile)
+// String implements fmt.Stringer.
+func (w Warning) String() string {
+	if w.Application == "" {
+		return fmt.Sprintf("%s: %s", w.Field, w.Message)
+	}
+
+	return fmt.Sprintf("application %q: %s: %s", w.Application, w.Field, w.Message)
+}
+
+// NewFromFile creates a Manifest from a manifest file. The variables are
+// substituted for ((variable)) references in the file.
+func NewFromFile(manifestFile string, variables map[string]interface{}) (*Manifest, error) {
+	return NewFromFiles([]string{manifestFile}, variables)
+}
+
+// NewFromFiles creates a Manifest by layering each manifest file on top of
+// the previous ones. Top-level properties are overridden, applications with
+// the same name are merged and new applications are added.
+func NewFromFiles(manifestFiles []string, variables map[string]interface{}) (*Manifest, error) {
+	if len(manifestFiles) == 0 {
+		return nil, errors.New("at least one manifest file is required")
+	}
+
+	var m *Manifest
+	for _, manifestFile := range manifestFiles {
+		overlay, err := loadFile(manifestFile, nil, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		if m == nil {
+			m = overlay
+		} else {
+			m = m.inheritedBy(overlay)
+		}
+	}
+
+	m.applyDefaults()
+	return m, nil
+}
+
+// NewFromReader creates a Manifest from a reader. Inherited manifests are
+// resolved relative to the working directory. The variables are substituted
+// for ((variable)) references in the manifest.
+func NewFromReader(reader io.Reader, variables map[string]interface{}) (*Manifest, error) {
+	bytes, err := ioutil.ReadAll(reader)
 	if err != nil {
 		return nil, err
 	}
-	return NewFromReader(reader)
+
+	m, err := parse(bytes, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Inherit != "" {
+		parent, err := loadFile(m.Inherit, nil, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load inherited manifest %s: %v", m.Inherit, err)
+		}
+
+		m = parent.inheritedBy(m)
+	}
+
+	m.applyDefaults()
+	return m, nil
 }
 
-// NewFromReader creates a Manifest from a reader.
-func NewFromReader(reader io.Reader) (*Manifest, error) {
-	bytes, err := ioutil.ReadAll(reader)
+// loadFile reads a manifest file and resolves the manifests it inherits from
+// without applying the top-level properties to the applications.
+func loadFile(manifestFile string, seen []string, variables map[string]interface{}) (*Manifest, error) {
+	absPath, err := filepath.Abs(manifestFile)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: validate manifest
-	m := Manifest{}
-	if err = yaml.UnmarshalStrict(bytes, &m); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: manifest file contains unsupported config: %v", err)
-	} else if err = yaml.Unmarshal(bytes, &m); err != nil {
+	for _, path := range seen {
+		if path == absPath {
+			return nil, fmt.Errorf("manifest %s inherits from itself", manifestFile)
+		}
+	}
+
+	bytes, err := ioutil.ReadFile(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := parse(bytes, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %v", manifestFile, err)
+	}
+
+	if m.Inherit == "" {
+		return m, nil
+	}
+
+	parentFile := m.Inherit
+	if !filepath.IsAbs(parentFile) {
+		parentFile = filepath.Join(filepath.Dir(absPath), parentFile)
+	}
+
+	parent, err := loadFile(parentFile, append(seen, absPath), variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load inherited manifest %s: %v", m.Inherit, err)
+	}
+
+	return parent.inheritedBy(m), nil
+}
+
+// parse interpolates variables into a single manifest, unmarshals it and
+// records warnings for any fields kf doesn't support.
+func parse(bytes []byte, variables map[string]interface{}) (*Manifest, error) {
+	bytes, err := interpolate(bytes, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	if err := yaml.Unmarshal(bytes, m); err != nil {
+		return nil, err
+	}
+
+	raw := make(map[interface{}]interface{})
+	if err := yaml.Unmarshal(bytes, &raw); err != nil {
 		return nil, err
 	}
+	m.Warnings = unsupportedFieldWarnings(raw)
+
+	return m, nil
+}
+
+// inheritedBy returns a Manifest with the top-level properties and
+// applications of child layered on top of m.
+func (m *Manifest) inheritedBy(child *Manifest) *Manifest {
+	out := &Manifest{
+		Defaults: m.Defaults.clone(),
+	}
+	out.Warnings = append(out.Warnings, m.Warnings...)
+	out.Warnings = append(out.Warnings, child.Warnings...)
+
+	// Errors can't occur when merging values of the same type.
+	out.Defaults.Override(&child.Defaults)
+
+	childApps := make(map[string]Application)
+	for _, app := range child.Applications {
+		childApps[app.Name] = app
+	}
+
+	for _, app := range m.Applications {
+		app = app.clone()
+		if childApp, ok := childApps[app.Name]; ok {
+			app.Override(&childApp)
+			delete(childApps, app.Name)
+		}
+		out.Applications = append(out.Applications, app)
+	}
+
+	for _, app := range child.Applications {
+		if _, ok := childApps[app.Name]; ok {
+			out.Applications = append(out.Applications, app)
+		}
+	}
+
+	return out
+}
+
+// applyDefaults layers each application on top of the top-level properties
+// and records warnings for unsupported routes.
+func (m *Manifest) applyDefaults() {
+	for i, app := range m.Applications {
+		merged := m.Defaults.clone()
+		merged.Name = ""
+		merged.Override(&app)
+		m.Applications[i] = merged
+
+		for _, route := range merged.Routes {
+			if routeHasPort(route.Route) {
+				m.Warnings = append(m.Warnings, Warning{
+					Application: merged.Name,
+					Field:       "routes",
+					Message:     fmt.Sprintf("route %q has a port, only HTTP routes are supported so the port will be ignored", route.Route),
+				})
+			}
+		}
+	}
 
-	return &m, nil
+	m.Defaults = Application{}
+	m.Inherit = ""
+}
+
+func routeHasPort(route string) bool {
+	if !strings.Contains(route, "://") {
+		route = "http://" + route
+	}
+
+	u, err := url.Parse(route)
+	return err == nil && u.Port() != ""
 }
 
 // New creates a Manifest for a single app.
@@ -110,8 +351,9 @@ func New(appName string) (*Manifest, error) {
 	}, nil
 }
 
-// CheckForManifest will optionally return a Manifest given a directory.
-func CheckForManifest(directory string) (*Manifest, error) {
+// CheckForManifest will optionally return a Manifest given a directory. The
+// variables are substituted for ((variable)) references in the manifest.
+func CheckForManifest(directory string, variables map[string]interface{}) (*Manifest, error) {
 	dirFile, err := os.Stat(directory)
 	if err != nil {
 		return nil, err
@@ -125,7 +367,7 @@ func CheckForManifest(directory string) (*Manifest, error) {
 		filePath := filepath.Join(directory, fileName)
 
 		if _, err := os.Stat(filePath); err == nil {
-			return NewFromFile(filePath)
+			return NewFromFile(filePath, variables)
 		}
 	}
 
@@ -161,8 +403,221 @@ func (app *Application) Override(overrides *Application) error {
 	return nil
 }
 
+// clone returns a copy of the Application that can be merged into without
+// modifying the original.
+func (app Application) clone() Application {
+	if app.Env != nil {
+		env := make(map[string]string)
+		for k, v := range app.Env {
+			env[k] = v
+		}
+		app.Env = env
+	}
+
+	app.MinScale = cloneInt(app.MinScale)
+	app.MaxScale = cloneInt(app.MaxScale)
+	app.Instances = cloneInt(app.Instances)
+
+	return app
+}
+
+func cloneInt(i *int) *int {
+	if i == nil {
+		return nil
+	}
+
+	out := *i
+	return &out
+}
+
 // Buildpack joings toegether the buildpacks in order as a CSV to be compatible
 // with buildpacks v3.
 func (app *Application) Buildpack() string {
 	return strings.Join(app.Buildpacks, ",")
 }
+
+// ResourceRequirements converts the Cloud Foundry memory and disk quotas into
+// Kubernetes resource requests and limits. nil is returned if neither is set.
+func (app *Application) ResourceRequirements() (*corev1.ResourceRequirements, error) {
+	if app.Memory == "" && app.DiskQuota == "" {
+		return nil, nil
+	}
+
+	resources := corev1.ResourceList{}
+
+	if app.Memory != "" {
+		memory, err := parseCFQuantity("memory", app.Memory)
+		if err != nil {
+			return nil, err
+		}
+		resources[corev1.ResourceMemory] = memory
+	}
+
+	if app.DiskQuota != "" {
+		disk, err := parseCFQuantity("disk_quota", app.DiskQuota)
+		if err != nil {
+			return nil, err
+		}
+		resources[corev1.ResourceEphemeralStorage] = disk
+	}
+
+	// Cloud Foundry quotas are hard limits and are also used for placement so
+	// they're used as both the request and the limit.
+	return &corev1.ResourceRequirements{
+		Requests: resources,
+		Limits:   resources.DeepCopy(),
+	}, nil
+}
+
+// BuildCacheSize converts the Cloud Foundry style BuildCache into a
+// Kubernetes quantity. nil is returned if it isn't set.
+func (app *Application) BuildCacheSize() (*resource.Quantity, error) {
+	if app.BuildCache == "" {
+		return nil, nil
+	}
+
+	size, err := parseCFQuantity("build-cache", app.BuildCache)
+	if err != nil {
+		return nil, err
+	}
+
+	return &size, nil
+}
+
+var cfQuantityPattern = regexp.MustCompile(`^(?i)([0-9]+)\s*(M|MB|G|GB|T|TB)$`)
+
+// parseCFQuantity converts a Cloud Foundry byte quantity e.g. 512M or 2GB to
+// the equivalent binary Kubernetes quantity.
+func parseCFQuantity(field, value string) (resource.Quantity, error) {
+	matches := cfQuantityPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return resource.Quantity{}, fmt.Errorf("invalid %s %q, it must be an integer followed by a unit of M, G or T", field, value)
+	}
+
+	suffix := strings.ToUpper(matches[2][:1]) + "i"
+	return resource.ParseQuantity(matches[1] + suffix)
+}
+
+// unsupportedFieldWarnings creates a warning for each field in the raw
+// manifest that doesn't map to a field kf understands.
+func unsupportedFieldWarnings(raw map[interface{}]interface{}) []Warning {
+	var warnings []Warning
+
+	manifestFields := yamlFields(reflect.TypeOf(Manifest{}))
+	appType := reflect.TypeOf(Application{})
+
+	for _, key := range sortedKeys(raw) {
+		value := raw[key]
+		fieldType, ok := manifestFields[key]
+
+		switch {
+		case !ok:
+			warnings = append(warnings, newUnsupportedWarning("", key))
+
+		case key == "applications":
+			apps, _ := value.([]interface{})
+			for _, rawApp := range apps {
+				appFields, _ := rawApp.(map[interface{}]interface{})
+				name, _ := appFields["name"].(string)
+
+				for _, field := range unsupportedFields(rawApp, appType, "") {
+					warnings = append(warnings, newUnsupportedWarning(name, field))
+				}
+			}
+
+		default:
+			for _, field := range unsupportedFields(value, fieldType, key+".") {
+				warnings = append(warnings, newUnsupportedWarning("", field))
+			}
+		}
+	}
+
+	return warnings
+}
+
+func newUnsupportedWarning(appName, field string) Warning {
+	return Warning{
+		Application: appName,
+		Field:       field,
+		Message:     "field is not supported and will be ignored",
+	}
+}
+
+// unsupportedFields returns the paths of fields in raw that don't exist in
+// the type t.
+func unsupportedFields(raw interface{}, t reflect.Type, prefix string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var out []string
+	switch t.Kind() {
+	case reflect.Struct:
+		fields, ok := raw.(map[interface{}]interface{})
+		if !ok {
+			return nil
+		}
+
+		known := yamlFields(t)
+		for _, key := range sortedKeys(fields) {
+			fieldType, ok := known[key]
+			if !ok {
+				out = append(out, prefix+key)
+				continue
+			}
+
+			out = append(out, unsupportedFields(fields[key], fieldType, prefix+key+".")...)
+		}
+
+	case reflect.Slice:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil
+		}
+
+		for i, item := range items {
+			itemPrefix := fmt.Sprintf("%s[%d].", strings.TrimSuffix(prefix, "."), i)
+			out = append(out, unsupportedFields(item, t.Elem(), itemPrefix)...)
+		}
+	}
+
+	return out
+}
+
+// yamlFields maps the YAML keys of a struct to the types of their fields.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	out := make(map[string]reflect.Type)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("yaml"), ",")
+
+		switch {
+		case tag[0] == "-":
+			continue
+
+		case len(tag) > 1 && tag[1] == "inline":
+			for name, fieldType := range yamlFields(field.Type) {
+				out[name] = fieldType
+			}
+
+		case tag[0] == "":
+			out[strings.ToLower(field.Name)] = field.Type
+
+		default:
+			out[tag[0]] = field.Type
+		}
+	}
+
+	return out
+}
+
+func sortedKeys(m map[interface{}]interface{}) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, fmt.Sprintf("%v", key))
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -15,6 +15,7 @@
 package manifest_test
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -24,6 +25,8 @@ import (
 
 	"github.com/google/kf/pkg/kf/manifest"
 	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestNewFromReader(t *testing.T) {
@@ -62,17 +65,342 @@ applications:
 				},
 			},
 		},
+		"dockerfile": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  docker:
+    dockerfile: docker/Dockerfile
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name: "MY-APP",
+						Docker: manifest.AppDockerImage{
+							Dockerfile: "docker/Dockerfile",
+						},
+					},
+				},
+			},
+		},
+		"cf fields": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  memory: 512M
+  disk_quota: 1G
+  instances: 3
+  command: bundle exec rackup
+  stack: cflinuxfs3
+  random-route: true
+  no-route: true
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name:        "MY-APP",
+						Memory:      "512M",
+						DiskQuota:   "1G",
+						Instances:   intPtr(3),
+						Command:     "bundle exec rackup",
+						Stack:       "cflinuxfs3",
+						RandomRoute: true,
+						NoRoute:     true,
+					},
+				},
+			},
+		},
+		"legacy route fields": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  host: my-host
+  hosts: [other-host]
+  domain: example.com
+  domains: [example.net]
+  no-hostname: true
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name:       "MY-APP",
+						Host:       "my-host",
+						Hosts:      []string{"other-host"},
+						Domain:     "example.com",
+						Domains:    []string{"example.net"},
+						NoHostname: true,
+					},
+				},
+			},
+		},
+		"top-level properties apply to every app": {
+			fileContent: `---
+memory: 1G
+env:
+  SHARED: top
+  OVERRIDDEN: top
+applications:
+- name: app-1
+  env:
+    OVERRIDDEN: app
+- name: app-2
+  memory: 2G
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name:   "app-1",
+						Memory: "1G",
+						Env:    map[string]string{"SHARED": "top", "OVERRIDDEN": "app"},
+					},
+					{
+						Name:   "app-2",
+						Memory: "2G",
+						Env:    map[string]string{"SHARED": "top", "OVERRIDDEN": "top"},
+					},
+				},
+			},
+		},
+		"unsupported fields": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  processes: []
+  docker:
+    image: gcr.io/my-image
+    username: me
+  routes:
+  - route: tcp.example.com:1024
+    protocol: tcp
+sidecars: []
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name:   "MY-APP",
+						Docker: manifest.AppDockerImage{Image: "gcr.io/my-image"},
+						Routes: []manifest.Route{{Route: "tcp.example.com:1024"}},
+					},
+				},
+				Warnings: []manifest.Warning{
+					{Application: "MY-APP", Field: "docker.username", Message: "field is not supported and will be ignored"},
+					{Application: "MY-APP", Field: "processes", Message: "field is not supported and will be ignored"},
+					{Application: "MY-APP", Field: "routes[0].protocol", Message: "field is not supported and will be ignored"},
+					{Field: "sidecars", Message: "field is not supported and will be ignored"},
+					{Application: "MY-APP", Field: "routes", Message: `route "tcp.example.com:1024" has a port, only HTTP routes are supported so the port will be ignored`},
+				},
+			},
+		},
 	}
 
 	for tn, tc := range cases {
 		t.Run(tn, func(t *testing.T) {
-			actual, err := manifest.NewFromReader(strings.NewReader(tc.fileContent))
+			actual, err := manifest.NewFromReader(strings.NewReader(tc.fileContent), nil)
 			testutil.AssertNil(t, "error", err)
 			testutil.AssertEqual(t, "manifest", tc.expected, actual)
 		})
 	}
 }
 
+func TestNewFromFile_inherit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kf-manifest-test")
+	testutil.AssertNil(t, "error creating test directory", err)
+	defer func() {
+		testutil.AssertNil(t, "error deleting test directory", os.RemoveAll(dir))
+	}()
+
+	files := map[string]string{
+		"base.yml": `---
+memory: 1G
+env:
+  ENVIRONMENT: base
+applications:
+- name: app-1
+  instances: 2
+- name: app-2
+`,
+		"child.yml": `---
+inherit: base.yml
+env:
+  ENVIRONMENT: child
+applications:
+- name: app-1
+  instances: 4
+- name: app-3
+`,
+		"loop.yml": `---
+inherit: loop.yml
+`,
+	}
+
+	for name, content := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		testutil.AssertNil(t, "error writing manifest file", err)
+	}
+
+	actual, err := manifest.NewFromFile(filepath.Join(dir, "child.yml"), nil)
+	testutil.AssertNil(t, "error", err)
+
+	env := map[string]string{"ENVIRONMENT": "child"}
+	testutil.AssertEqual(t, "manifest", &manifest.Manifest{
+		Applications: []manifest.Application{
+			{Name: "app-1", Instances: intPtr(4), Memory: "1G", Env: env},
+			{Name: "app-2", Memory: "1G", Env: env},
+			{Name: "app-3", Memory: "1G", Env: env},
+		},
+	}, actual)
+
+	_, err = manifest.NewFromFile(filepath.Join(dir, "loop.yml"), nil)
+	testutil.AssertErrorsEqual(t, errors.New("failed to load inherited manifest loop.yml: manifest "+filepath.Join(dir, "loop.yml")+" inherits from itself"), err)
+}
+
+func TestNewFromFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kf-manifest-test")
+	testutil.AssertNil(t, "error creating test directory", err)
+	defer func() {
+		testutil.AssertNil(t, "error deleting test directory", os.RemoveAll(dir))
+	}()
+
+	files := map[string]string{
+		"base.yml": `---
+applications:
+- name: web
+  memory: 512M
+  instances: 1
+  env:
+    LOG_LEVEL: debug
+    DATABASE: ((database))
+- name: worker
+  command: ./worker
+`,
+		"prod.yml": `---
+applications:
+- name: web
+  instances: ((instances))
+  env:
+    LOG_LEVEL: info
+- name: admin
+`,
+	}
+
+	for name, content := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		testutil.AssertNil(t, "error writing manifest file", err)
+	}
+
+	actual, err := manifest.NewFromFiles([]string{
+		filepath.Join(dir, "base.yml"),
+		filepath.Join(dir, "prod.yml"),
+	}, map[string]interface{}{
+		"database":  "prod-db",
+		"instances": 3,
+	})
+	testutil.AssertNil(t, "error", err)
+
+	testutil.AssertEqual(t, "manifest", &manifest.Manifest{
+		Applications: []manifest.Application{
+			{
+				Name:      "web",
+				Memory:    "512M",
+				Instances: intPtr(3),
+				Env:       map[string]string{"LOG_LEVEL": "info", "DATABASE": "prod-db"},
+			},
+			{Name: "worker", Command: "./worker"},
+			{Name: "admin"},
+		},
+	}, actual)
+
+	_, err = manifest.NewFromFiles([]string{
+		filepath.Join(dir, "base.yml"),
+		filepath.Join(dir, "prod.yml"),
+	}, nil)
+	testutil.AssertErrorsEqual(t, fmt.Errorf("failed to parse manifest %s: expected to find variables: database", filepath.Join(dir, "base.yml")), err)
+
+	_, err = manifest.NewFromFiles(nil, nil)
+	testutil.AssertErrorsEqual(t, errors.New("at least one manifest file is required"), err)
+}
+
+func TestApplication_ResourceRequirements(t *testing.T) {
+	cases := map[string]struct {
+		app         manifest.Application
+		expected    *corev1.ResourceRequirements
+		expectedErr error
+	}{
+		"no quotas": {
+			app: manifest.Application{},
+		},
+		"memory and disk": {
+			app: manifest.Application{Memory: "512M", DiskQuota: "2gb"},
+			expected: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory:           resource.MustParse("512Mi"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory:           resource.MustParse("512Mi"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+				},
+			},
+		},
+		"missing unit": {
+			app:         manifest.Application{Memory: "512"},
+			expectedErr: errors.New(`invalid memory "512", it must be an integer followed by a unit of M, G or T`),
+		},
+		"bad disk": {
+			app:         manifest.Application{DiskQuota: "1.5G"},
+			expectedErr: errors.New(`invalid disk_quota "1.5G", it must be an integer followed by a unit of M, G or T`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual, err := tc.app.ResourceRequirements()
+			testutil.AssertErrorsEqual(t, tc.expectedErr, err)
+			testutil.AssertEqual(t, "resources", tc.expected, actual)
+		})
+	}
+}
+
+func TestApplication_BuildCacheSize(t *testing.T) {
+	cases := map[string]struct {
+		app         manifest.Application
+		expected    *resource.Quantity
+		expectedErr error
+	}{
+		"no cache": {
+			app: manifest.Application{},
+		},
+		"cache": {
+			app:      manifest.Application{BuildCache: "2G"},
+			expected: quantityPtr(resource.MustParse("2Gi")),
+		},
+		"bad size": {
+			app:         manifest.Application{BuildCache: "lots"},
+			expectedErr: errors.New(`invalid build-cache "lots", it must be an integer followed by a unit of M, G or T`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual, err := tc.app.BuildCacheSize()
+			testutil.AssertErrorsEqual(t, tc.expectedErr, err)
+			testutil.AssertEqual(t, "size", tc.expected, actual)
+		})
+	}
+}
+
+func quantityPtr(q resource.Quantity) *resource.Quantity {
+	return &q
+}
+
+func ExampleWarning_String() {
+	fmt.Println(manifest.Warning{Field: "sidecars", Message: "field is not supported"})
+	fmt.Println(manifest.Warning{Application: "my-app", Field: "processes", Message: "field is not supported"})
+
+	// Output: sidecars: field is not supported
+	// application "my-app": processes: field is not supported
+}
+
 func TestCheckForManifest(t *testing.T) {
 	cases := map[string]struct {
 		fileName    string
@@ -106,7 +434,7 @@ applications:
 			err = ioutil.WriteFile(filepath.Join(dir, tc.fileName), []byte(tc.fileContent), 0644)
 			testutil.AssertNil(t, "error writing manifest file", err)
 
-			actual, err := manifest.CheckForManifest(dir)
+			actual, err := manifest.CheckForManifest(dir, nil)
 			testutil.AssertNil(t, "error", err)
 			testutil.AssertEqual(t, "manifest", tc.expected, actual)
 		})
@@ -178,3 +506,7 @@ func ExampleApplication_Buildpack() {
 	// Output: One: java
 	// Two: maven,java
 }
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,190 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// variablePattern matches Cloud Foundry style ((variable)) references.
+var variablePattern = regexp.MustCompile(`\(\(([-\w\./]+)\)\)`)
+
+// NewVariablesFromFile reads a YAML file of variables that can be referenced
+// in a manifest.
+func NewVariablesFromFile(variablesFile string) (map[string]interface{}, error) {
+	bytes, err := ioutil.ReadFile(variablesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make(map[string]interface{})
+	if err := yaml.Unmarshal(bytes, &variables); err != nil {
+		return nil, fmt.Errorf("failed to parse variables file %s: %v", variablesFile, err)
+	}
+
+	return variables, nil
+}
+
+// ParseCLIVariables parses variables in the form KEY=VALUE. Values are kept
+// as strings, they're only given a type when a reference makes up a whole
+// value in the manifest.
+func ParseCLIVariables(cliVars []string) (map[string]interface{}, error) {
+	variables := make(map[string]interface{})
+
+	for _, kv := range cliVars {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("malformed variable: %s", kv)
+		}
+
+		variables[parts[0]] = parts[1]
+	}
+
+	return variables, nil
+}
+
+// scalarPatterns match the strings that are substituted as ints or booleans
+// when they make up a whole value. Only plain decimal integers and true/false
+// are converted so values such as 0123, on or 1e3 aren't reinterpreted.
+var (
+	intScalarPattern  = regexp.MustCompile(`^-?(0|[1-9][0-9]*)$`)
+	boolScalarPattern = regexp.MustCompile(`^(true|false)$`)
+)
+
+// typedScalar converts a string variable that makes up a whole value into an
+// int or boolean so it can fill typed manifest fields.
+func typedScalar(value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	switch {
+	case intScalarPattern.MatchString(s):
+		if i, err := strconv.Atoi(s); err == nil {
+			return i
+		}
+	case boolScalarPattern.MatchString(s):
+		return s == "true"
+	}
+
+	return s
+}
+
+// interpolate replaces ((variable)) references in a YAML document with their
+// values. A reference that makes up a whole value is replaced with the
+// variable's value, so maps and lists can be substituted. Nested values are
+// referenced using dots e.g. ((database.host)).
+func interpolate(data []byte, variables map[string]interface{}) ([]byte, error) {
+	if !variablePattern.Match(data) {
+		return data, nil
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	missing := make(map[string]bool)
+	doc = interpolateValue(doc, variables, missing)
+
+	if len(missing) > 0 {
+		var names []string
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("expected to find variables: %s", strings.Join(names, ", "))
+	}
+
+	return yaml.Marshal(doc)
+}
+
+func interpolateValue(value interface{}, variables map[string]interface{}, missing map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[interface{}]interface{})
+		for key, item := range v {
+			out[key] = interpolateValue(item, variables, missing)
+		}
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = interpolateValue(item, variables, missing)
+		}
+		return out
+
+	case string:
+		if match := variablePattern.FindStringSubmatch(v); match != nil && match[0] == v {
+			resolved, ok := lookupVariable(variables, match[1])
+			if !ok {
+				missing[match[1]] = true
+				return v
+			}
+			return typedScalar(resolved)
+		}
+
+		return variablePattern.ReplaceAllStringFunc(v, func(ref string) string {
+			name := variablePattern.FindStringSubmatch(ref)[1]
+			resolved, ok := lookupVariable(variables, name)
+			if !ok {
+				missing[name] = true
+				return ref
+			}
+			return fmt.Sprint(resolved)
+		})
+
+	default:
+		return value
+	}
+}
+
+// lookupVariable finds the value of a variable, following dots into nested
+// maps.
+func lookupVariable(variables map[string]interface{}, name string) (interface{}, bool) {
+	parts := strings.Split(name, ".")
+
+	value, ok := variables[parts[0]]
+	if !ok {
+		return nil, false
+	}
+
+	for _, part := range parts[1:] {
+		switch m := value.(type) {
+		case map[interface{}]interface{}:
+			value, ok = m[part]
+		case map[string]interface{}:
+			value, ok = m[part]
+		default:
+			ok = false
+		}
+
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
@@ -0,0 +1,231 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/manifest"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestNewFromReader_variables(t *testing.T) {
+	cases := map[string]struct {
+		fileContent string
+		variables   map[string]interface{}
+		expected    *manifest.Manifest
+		expectedErr error
+	}{
+		"no variables": {
+			fileContent: `---
+applications:
+- name: my-app
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{{Name: "my-app"}},
+			},
+		},
+		"whole and partial values": {
+			fileContent: `---
+applications:
+- name: ((name))
+  instances: ((instances))
+  routes:
+  - route: ((name)).((domain))
+`,
+			variables: map[string]interface{}{
+				"name":      "my-app",
+				"instances": 2,
+				"domain":    "example.com",
+			},
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name:      "my-app",
+						Instances: intPtr(2),
+						Routes:    []manifest.Route{{Route: "my-app.example.com"}},
+					},
+				},
+			},
+		},
+		"nested values": {
+			fileContent: `---
+applications:
+- name: my-app
+  env: ((env))
+  services:
+  - ((services.database))
+`,
+			variables: map[string]interface{}{
+				"env": map[interface{}]interface{}{
+					"LOG_LEVEL": "info",
+				},
+				"services": map[interface{}]interface{}{
+					"database": "my-db",
+				},
+			},
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name:     "my-app",
+						Env:      map[string]string{"LOG_LEVEL": "info"},
+						Services: []string{"my-db"},
+					},
+				},
+			},
+		},
+		"missing variables": {
+			fileContent: `---
+applications:
+- name: ((name))
+  memory: ((memory))
+  services:
+  - ((services.database))
+  env:
+    NAME: ((name))
+`,
+			variables: map[string]interface{}{
+				"services": map[interface{}]interface{}{},
+			},
+			expectedErr: errors.New("expected to find variables: memory, name, services.database"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual, err := manifest.NewFromReader(strings.NewReader(tc.fileContent), tc.variables)
+			testutil.AssertErrorsEqual(t, tc.expectedErr, err)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			testutil.AssertEqual(t, "manifest", tc.expected, actual)
+		})
+	}
+}
+
+func TestNewVariablesFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kf-manifest-test")
+	testutil.AssertNil(t, "error creating test directory", err)
+	defer func() {
+		testutil.AssertNil(t, "error deleting test directory", os.RemoveAll(dir))
+	}()
+
+	varsFile := filepath.Join(dir, "vars.yml")
+	err = ioutil.WriteFile(varsFile, []byte(`---
+instances: 2
+database:
+  host: db.example.com
+`), 0644)
+	testutil.AssertNil(t, "error writing variables file", err)
+
+	actual, err := manifest.NewVariablesFromFile(varsFile)
+	testutil.AssertNil(t, "error", err)
+	testutil.AssertEqual(t, "variables", map[string]interface{}{
+		"instances": 2,
+		"database": map[interface{}]interface{}{
+			"host": "db.example.com",
+		},
+	}, actual)
+}
+
+func TestParseCLIVariables(t *testing.T) {
+	cases := map[string]struct {
+		args        []string
+		expected    map[string]interface{}
+		expectedErr error
+	}{
+		"valid": {
+			args:     []string{"name=my-app", "env=a=b"},
+			expected: map[string]interface{}{"name": "my-app", "env": "a=b"},
+		},
+		"values are kept as strings": {
+			args: []string{"instances=3", "build=0123", "flag=on", "exp=1e3", "empty="},
+			expected: map[string]interface{}{
+				"instances": "3",
+				"build":     "0123",
+				"flag":      "on",
+				"exp":       "1e3",
+				"empty":     "",
+			},
+		},
+		"missing value": {
+			args:        []string{"name"},
+			expectedErr: errors.New("malformed variable: name"),
+		},
+		"missing key": {
+			args:        []string{"=value"},
+			expectedErr: errors.New("malformed variable: =value"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual, err := manifest.ParseCLIVariables(tc.args)
+			testutil.AssertErrorsEqual(t, tc.expectedErr, err)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			testutil.AssertEqual(t, "variables", tc.expected, actual)
+		})
+	}
+}
+
+func TestParseCLIVariables_manifest(t *testing.T) {
+	variables, err := manifest.ParseCLIVariables([]string{
+		"name=my-app",
+		"instances=3",
+		"build=0123",
+		"flag=on",
+		"exp=1e3",
+	})
+	testutil.AssertNil(t, "error", err)
+
+	actual, err := manifest.NewFromReader(strings.NewReader(`---
+applications:
+- name: ((name))
+  instances: ((instances))
+  routes:
+  - route: ((build)).example.com
+  env:
+    BUILD: ((build))
+    FLAG: ((flag))
+    EXP: ((exp))
+    SUMMARY: ((flag))-((exp))
+`), variables)
+	testutil.AssertNil(t, "error", err)
+
+	testutil.AssertEqual(t, "manifest", &manifest.Manifest{
+		Applications: []manifest.Application{
+			{
+				Name:      "my-app",
+				Instances: intPtr(3),
+				Routes:    []manifest.Route{{Route: "0123.example.com"}},
+				Env: map[string]string{
+					"BUILD":   "0123",
+					"FLAG":    "on",
+					"EXP":     "1e3",
+					"SUMMARY": "on-1e3",
+				},
+			},
+		},
+	}, actual)
+}
@@ -17,6 +17,8 @@
 package secrets
 
 type createConfig struct {
+	// Annotations is annotations to set on the secret.
+	Annotations map[string]string
 	// Data is data to store in the secret. Values MUST be base64.
 	Data map[string][]byte
 	// Labels is labels to set on the secret.
@@ -53,6 +55,12 @@ func (opts CreateOptions) Extend(other CreateOptions) CreateOptions {
 	return out
 }
 
+// Annotations returns the last set value for Annotations or the empty value
+// if not set.
+func (opts CreateOptions) Annotations() map[string]string {
+	return opts.toConfig().Annotations
+}
+
 // Data returns the last set value for Data or the empty value
 // if not set.
 func (opts CreateOptions) Data() map[string][]byte {
@@ -77,6 +85,13 @@ func (opts CreateOptions) StringData() map[string]string {
 	return opts.toConfig().StringData
 }
 
+// WithCreateAnnotations creates an Option that sets annotations to set on the secret.
+func WithCreateAnnotations(val map[string]string) CreateOption {
+	return func(cfg *createConfig) {
+		cfg.Annotations = val
+	}
+}
+
 // WithCreateData creates an Option that sets data to store in the secret. Values MUST be base64.
 func WithCreateData(val map[string][]byte) CreateOption {
 	return func(cfg *createConfig) {
@@ -112,6 +127,117 @@ func CreateOptionDefaults() CreateOptions {
 	}
 }
 
+type updateConfig struct {
+	// Annotations is annotations to add to the secret.
+	Annotations map[string]string
+	// Data is data to replace the secret's contents with. Values MUST be base64.
+	Data map[string][]byte
+	// Labels is labels to add to the secret.
+	Labels map[string]string
+	// Namespace is the Kubernetes namespace to use
+	Namespace string
+	// StringData is data to replace the secret's contents with. Values are encoded in base64 automatically.
+	StringData map[string]string
+}
+
+// UpdateOption is a single option for configuring a updateConfig
+type UpdateOption func(*updateConfig)
+
+// UpdateOptions is a configuration set defining a updateConfig
+type UpdateOptions []UpdateOption
+
+// toConfig applies all the options to a new updateConfig and returns it.
+func (opts UpdateOptions) toConfig() updateConfig {
+	cfg := updateConfig{}
+
+	for _, v := range opts {
+		v(&cfg)
+	}
+
+	return cfg
+}
+
+// Extend creates a new UpdateOptions with the contents of other overriding
+// the values set in this UpdateOptions.
+func (opts UpdateOptions) Extend(other UpdateOptions) UpdateOptions {
+	var out UpdateOptions
+	out = append(out, opts...)
+	out = append(out, other...)
+	return out
+}
+
+// Annotations returns the last set value for Annotations or the empty value
+// if not set.
+func (opts UpdateOptions) Annotations() map[string]string {
+	return opts.toConfig().Annotations
+}
+
+// Data returns the last set value for Data or the empty value
+// if not set.
+func (opts UpdateOptions) Data() map[string][]byte {
+	return opts.toConfig().Data
+}
+
+// Labels returns the last set value for Labels or the empty value
+// if not set.
+func (opts UpdateOptions) Labels() map[string]string {
+	return opts.toConfig().Labels
+}
+
+// Namespace returns the last set value for Namespace or the empty value
+// if not set.
+func (opts UpdateOptions) Namespace() string {
+	return opts.toConfig().Namespace
+}
+
+// StringData returns the last set value for StringData or the empty value
+// if not set.
+func (opts UpdateOptions) StringData() map[string]string {
+	return opts.toConfig().StringData
+}
+
+// WithUpdateAnnotations creates an Option that sets annotations to add to the secret.
+func WithUpdateAnnotations(val map[string]string) UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.Annotations = val
+	}
+}
+
+// WithUpdateData creates an Option that sets data to replace the secret's contents with. Values MUST be base64.
+func WithUpdateData(val map[string][]byte) UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.Data = val
+	}
+}
+
+// WithUpdateLabels creates an Option that sets labels to add to the secret.
+func WithUpdateLabels(val map[string]string) UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.Labels = val
+	}
+}
+
+// WithUpdateNamespace creates an Option that sets the Kubernetes namespace to use
+func WithUpdateNamespace(val string) UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.Namespace = val
+	}
+}
+
+// WithUpdateStringData creates an Option that sets data to replace the secret's contents with. Values are encoded in base64 automatically.
+func WithUpdateStringData(val map[string]string) UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.StringData = val
+	}
+}
+
+// UpdateOptionDefaults gets the default values for Update.
+func UpdateOptionDefaults() UpdateOptions {
+	return UpdateOptions{
+		WithUpdateNamespace("default"),
+	}
+}
+
 type deleteConfig struct {
 	// Namespace is the Kubernetes namespace to use
 	Namespace string
@@ -31,6 +31,9 @@ type ClientInterface interface {
 	// Get retrieves a Kubernetes secret by its given name.
 	Get(name string, options ...GetOption) (*corev1.Secret, error)
 
+	// Update replaces the contents of an existing Kubernetes secret.
+	Update(name string, options ...UpdateOption) error
+
 	// Delete removes a Kubernetes secret with the given name.
 	Delete(name string, options ...DeleteOption) error
 
@@ -64,6 +67,7 @@ func (c *Client) Create(name string, options ...CreateOption) error {
 	secret.Namespace = config.Namespace
 	secret.APIVersion = "v1"
 	secret.Labels = config.Labels
+	secret.Annotations = config.Annotations
 
 	if _, err := c.kclient.CoreV1().Secrets(config.Namespace).Create(secret); err != nil {
 		return err
@@ -84,6 +88,28 @@ func (c *Client) Get(name string, options ...GetOption) (*corev1.Secret, error)
 	return secret, nil
 }
 
+// Update replaces the contents of an existing Kubernetes secret. Labels and
+// annotations are added to the existing ones.
+func (c *Client) Update(name string, options ...UpdateOption) error {
+	config := UpdateOptionDefaults().Extend(options).toConfig()
+
+	secret, err := c.kclient.CoreV1().Secrets(config.Namespace).Get(name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	secret.StringData = config.StringData
+	secret.Data = config.Data
+	secret.Labels = labels.Merge(secret.Labels, config.Labels)
+	secret.Annotations = labels.Merge(secret.Annotations, config.Annotations)
+
+	if _, err := c.kclient.CoreV1().Secrets(config.Namespace).Update(secret); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Delete removes a Kubernetes secret with the given name.
 func (c *Client) Delete(name string, options ...DeleteOption) error {
 	config := DeleteOptionDefaults().Extend(options).toConfig()
@@ -30,13 +30,14 @@ func TestClient_Create(t *testing.T) {
 	t.Parallel()
 
 	cases := map[string]struct {
-		Name             string
-		Options          []CreateOption
-		ExpectErr        error
-		ExpectNamespace  string
-		ExpectStringData map[string]string
-		ExpectData       map[string][]byte
-		ExpectLabels     map[string]string
+		Name              string
+		Options           []CreateOption
+		ExpectErr         error
+		ExpectNamespace   string
+		ExpectStringData  map[string]string
+		ExpectData        map[string][]byte
+		ExpectLabels      map[string]string
+		ExpectAnnotations map[string]string
 	}{
 		"use default namespace by default": {
 			Name: "broker-secret",
@@ -70,6 +71,13 @@ func TestClient_Create(t *testing.T) {
 			},
 			ExpectLabels: map[string]string{"key": "value"},
 		},
+		"annotations": {
+			Name: "broker-secret",
+			Options: []CreateOption{
+				WithCreateAnnotations(map[string]string{"key": "value"}),
+			},
+			ExpectAnnotations: map[string]string{"key": "value"},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -93,6 +101,87 @@ func TestClient_Create(t *testing.T) {
 			testutil.AssertEqual(t, "StringData", tc.ExpectStringData, secret.StringData)
 			testutil.AssertEqual(t, "Data", tc.ExpectData, secret.Data)
 			testutil.AssertEqual(t, "labels", tc.ExpectLabels, secret.Labels)
+			testutil.AssertEqual(t, "annotations", tc.ExpectAnnotations, secret.Annotations)
+		})
+	}
+}
+
+func TestClient_Update(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Name              string
+		Options           []UpdateOption
+		ExpectErr         error
+		ExpectNamespace   string
+		ExpectData        map[string][]byte
+		ExpectLabels      map[string]string
+		ExpectAnnotations map[string]string
+		Setup             func(mockK8s kubernetes.Interface)
+	}{
+		"secret does not exist": {
+			Name:            "some-secret",
+			Options:         []UpdateOption{},
+			ExpectNamespace: "default",
+			ExpectErr:       errors.New(`secrets "some-secret" not found`),
+		},
+		"custom namespace": {
+			Name: "some-secret",
+			Options: []UpdateOption{
+				WithUpdateNamespace("my-namespace"),
+				WithUpdateData(map[string][]byte{"username": []byte("user")}),
+			},
+			Setup:             createDummySecret("some-secret", "my-namespace", nil),
+			ExpectNamespace:   "my-namespace",
+			ExpectData:        map[string][]byte{"username": []byte("user")},
+			ExpectLabels:      map[string]string{},
+			ExpectAnnotations: map[string]string{},
+		},
+		"replaces data and merges metadata": {
+			Name: "some-secret",
+			Options: []UpdateOption{
+				WithUpdateData(map[string][]byte{"password": []byte("new")}),
+				WithUpdateLabels(map[string]string{"addk": "addv"}),
+				WithUpdateAnnotations(map[string]string{"note": "v2"}),
+			},
+			Setup: func(mockK8s kubernetes.Interface) {
+				secret := &corev1.Secret{Data: map[string][]byte{"username": []byte("user")}}
+				secret.Name = "some-secret"
+				secret.Labels = map[string]string{"origk": "origv"}
+				secret.Annotations = map[string]string{"note": "v1"}
+				mockK8s.CoreV1().Secrets("default").Create(secret)
+			},
+			ExpectNamespace:   "default",
+			ExpectData:        map[string][]byte{"password": []byte("new")},
+			ExpectLabels:      map[string]string{"origk": "origv", "addk": "addv"},
+			ExpectAnnotations: map[string]string{"note": "v2"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+
+			mockK8s := testclient.NewSimpleClientset()
+			if tc.Setup != nil {
+				tc.Setup(mockK8s)
+			}
+
+			secretsClient := NewClient(mockK8s)
+			actualErr := secretsClient.Update(tc.Name, tc.Options...)
+			if tc.ExpectErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectErr, actualErr)
+
+				return
+			}
+
+			secret, err := mockK8s.CoreV1().Secrets(tc.ExpectNamespace).Get(tc.Name, v1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutil.AssertEqual(t, "Data", tc.ExpectData, secret.Data)
+			testutil.AssertEqual(t, "labels", tc.ExpectLabels, secret.Labels)
+			testutil.AssertEqual(t, "annotations", tc.ExpectAnnotations, secret.Annotations)
 		})
 	}
 }
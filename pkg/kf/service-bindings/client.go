@@ -19,9 +19,12 @@ import (
 	"fmt"
 
 	"github.com/google/kf/pkg/kf/secrets"
+	"github.com/google/kf/pkg/kf/services"
 	apiv1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	clientv1beta1 "github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
 	servicecatalog "github.com/poy/service-catalog/pkg/svcat/service-catalog"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -85,6 +88,17 @@ func (c *Client) Create(serviceInstanceName, appName string, opts ...CreateOptio
 	}
 
 	bindingReference := serviceBindingName(appName, serviceInstanceName)
+
+	instanceSecret, err := c.sc.Get(
+		services.UserProvidedServiceSecretName(serviceInstanceName),
+		secrets.WithGetNamespace(cfg.Namespace))
+	switch {
+	case err == nil && services.IsUserProvidedService(instanceSecret):
+		return c.createUserProvided(serviceInstanceName, appName, bindingName, cfg)
+	case err != nil && !apierrs.IsNotFound(err):
+		return nil, err
+	}
+
 	request := &apiv1beta1.ServiceBinding{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      bindingReference,
@@ -106,6 +120,34 @@ func (c *Client) Create(serviceInstanceName, appName string, opts ...CreateOptio
 	return c.c.ServiceBindings(cfg.Namespace).Create(request)
 }
 
+// createUserProvided binds a user-provided service instance to an app. The
+// service catalog doesn't know about user-provided services so the binding is
+// stored as a labelled secret instead.
+func (c *Client) createUserProvided(serviceInstanceName, appName, bindingName string, cfg createConfig) (*apiv1beta1.ServiceBinding, error) {
+	if len(cfg.Params) > 0 {
+		return nil, errors.New("binding parameters are not supported for user-provided service instances")
+	}
+
+	secret := corev1.Secret{}
+	secret.Name = serviceBindingName(appName, serviceInstanceName)
+	secret.Namespace = cfg.Namespace
+	secret.Labels = map[string]string{
+		BindingNameLabel:                  bindingName,
+		AppNameLabel:                      appName,
+		services.UserProvidedServiceLabel: serviceInstanceName,
+	}
+
+	if err := c.sc.Create(
+		secret.Name,
+		secrets.WithCreateNamespace(cfg.Namespace),
+		secrets.WithCreateLabels(secret.Labels)); err != nil {
+		return nil, err
+	}
+
+	binding := userProvidedBinding(secret)
+	return &binding, nil
+}
+
 // GetOrCreate binds a service instance to an app if a binding does not already exist.
 func (c *Client) GetOrCreate(serviceInstanceName, appName string, opts ...CreateOption) (*apiv1beta1.ServiceBinding, bool, error) {
 
@@ -135,7 +177,18 @@ func (c *Client) Delete(serviceInstanceName, appName string, opts ...DeleteOptio
 	cfg := DeleteOptionDefaults().Extend(opts).toConfig()
 
 	bindingReference := serviceBindingName(appName, serviceInstanceName)
-	return c.c.ServiceBindings(cfg.Namespace).Delete(bindingReference, &v1.DeleteOptions{})
+	err := c.c.ServiceBindings(cfg.Namespace).Delete(bindingReference, &v1.DeleteOptions{})
+	if !apierrs.IsNotFound(err) {
+		return err
+	}
+
+	// Bindings to user-provided services aren't in the service catalog.
+	secret, serr := c.sc.Get(bindingReference, secrets.WithGetNamespace(cfg.Namespace))
+	if serr != nil || secret.Labels[services.UserProvidedServiceLabel] == "" {
+		return err
+	}
+
+	return c.sc.Delete(bindingReference, secrets.WithDeleteNamespace(cfg.Namespace))
 }
 
 // List queries Kubernetes for service bindings.
@@ -147,6 +200,17 @@ func (c *Client) List(opts ...ListOption) ([]apiv1beta1.ServiceBinding, error) {
 		return nil, err
 	}
 
+	userProvided, err := c.sc.List(
+		secrets.WithListNamespace(cfg.Namespace),
+		secrets.WithListLabelSelector(fmt.Sprintf("%s,%s", AppNameLabel, services.UserProvidedServiceLabel)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, secret := range userProvided {
+		bindings.Items = append(bindings.Items, userProvidedBinding(secret))
+	}
+
 	// Filter the results
 	filterByServiceInstance := cfg.ServiceInstance != ""
 	filterByAppName := cfg.AppName != ""
@@ -180,6 +244,21 @@ func (c *Client) GetVcapServices(appName string, opts ...GetVcapServicesOption)
 
 	out := VcapServicesMap{}
 	for _, binding := range bindings {
+		if IsUserProvidedBinding(binding) {
+			secret, err := c.sc.Get(binding.Spec.SecretName, secrets.WithGetNamespace(cfg.Namespace))
+			if err != nil {
+				return nil, fmt.Errorf("couldn't create VCAP_SERVICES, couldn't get user-provided instance for binding %s: %v", binding.Name, err)
+			}
+
+			service, err := NewUserProvidedVcapService(binding, secret)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't create VCAP_SERVICES: %v", err)
+			}
+
+			out.Add(service)
+			continue
+		}
+
 		instance, err := c.c.ServiceInstances(cfg.Namespace).Get(binding.Spec.InstanceRef.Name, v1.GetOptions{})
 		if err != nil {
 			return nil, fmt.Errorf("couldn't create VCAP_SERVICES, couldn't get instance for binding %s: %v", binding.Name, err)
@@ -205,3 +284,34 @@ func (c *Client) GetVcapServices(appName string, opts ...GetVcapServicesOption)
 func serviceBindingName(appName, instanceName string) string {
 	return fmt.Sprintf("kf-binding-%s-%s", appName, instanceName)
 }
+
+// IsUserProvidedBinding returns true if the binding is for a user-provided
+// service instance.
+func IsUserProvidedBinding(binding apiv1beta1.ServiceBinding) bool {
+	return binding.Labels[services.UserProvidedServiceLabel] != ""
+}
+
+// userProvidedBinding converts the secret a user-provided binding is stored in
+// to a ServiceBinding so it can be treated the same as service catalog
+// bindings.
+func userProvidedBinding(secret corev1.Secret) apiv1beta1.ServiceBinding {
+	instanceName := secret.Labels[services.UserProvidedServiceLabel]
+
+	return apiv1beta1.ServiceBinding{
+		ObjectMeta: secret.ObjectMeta,
+		Spec: apiv1beta1.ServiceBindingSpec{
+			InstanceRef: apiv1beta1.LocalObjectReference{
+				Name: instanceName,
+			},
+			SecretName: services.UserProvidedServiceSecretName(instanceName),
+		},
+		Status: apiv1beta1.ServiceBindingStatus{
+			Conditions: []apiv1beta1.ServiceBindingCondition{{
+				Type:    apiv1beta1.ServiceBindingConditionReady,
+				Status:  apiv1beta1.ConditionTrue,
+				Reason:  "UserProvided",
+				Message: "Bound to a user-provided service",
+			}},
+		},
+	}
+}
@@ -19,12 +19,15 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/kf/secrets"
 	secretsfake "github.com/google/kf/pkg/kf/secrets/fake"
 	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
+	"github.com/google/kf/pkg/kf/services"
 	"github.com/google/kf/pkg/kf/testutil"
 	apiv1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	testclient "github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/fake"
 	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -54,10 +57,31 @@ func (tc *ServiceBindingApiTestCase) ExecuteTest(t *testing.T) {
 	tc.Run(t, fakeDependencies{apiserver: fakeApiServer, secrets: fakeSecrets}, client)
 }
 
+func expectNoUserProvidedInstance(deps fakeDependencies) {
+	deps.secrets.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(nil, apierrs.NewNotFound(corev1.Resource("secrets"), "kf-user-provided-mydb"))
+}
+
+func expectNoUserProvidedBindings(deps fakeDependencies) {
+	deps.secrets.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+}
+
+func userProvidedInstanceSecret(name string) *corev1.Secret {
+	secret := &corev1.Secret{}
+	secret.Name = services.UserProvidedServiceSecretName(name)
+	secret.Labels = map[string]string{services.UserProvidedServiceLabel: name}
+	secret.Data = map[string][]byte{"username": []byte("admin")}
+	return secret
+}
+
 func TestClient_Create(t *testing.T) {
 	cases := map[string]ServiceBindingApiTestCase{
 		"server error": {
 			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				expectNoUserProvidedInstance(deps)
 				deps.apiserver.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("api error"))
 				_, err := client.Create("mydb", "myapp")
 				testutil.AssertErrorsEqual(t, errors.New("api error"), err)
@@ -65,6 +89,7 @@ func TestClient_Create(t *testing.T) {
 		},
 		"custom namespace": {
 			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				expectNoUserProvidedInstance(deps)
 				deps.apiserver.EXPECT().Create(gomock.Any(), "custom-ns", gomock.Any()).Return(nil, nil)
 
 				_, err := client.Create("mydb", "myapp", servicebindings.WithCreateNamespace("custom-ns"))
@@ -73,6 +98,7 @@ func TestClient_Create(t *testing.T) {
 		},
 		"call semantics": {
 			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				expectNoUserProvidedInstance(deps)
 				deps.apiserver.EXPECT().Create(gomock.Any(), "custom-ns", gomock.Any()).DoAndReturn(func(grv schema.GroupVersionResource, ns string, obj runtime.Object) (runtime.Object, error) {
 					testutil.AssertEqual(t, "group", "servicecatalog.k8s.io", grv.Group)
 					testutil.AssertEqual(t, "resource", "servicebindings", grv.Resource)
@@ -86,6 +112,7 @@ func TestClient_Create(t *testing.T) {
 		},
 		"default values": {
 			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				expectNoUserProvidedInstance(deps)
 				deps.apiserver.EXPECT().Create(gomock.Any(), "default", gomock.Any()).DoAndReturn(func(grv schema.GroupVersionResource, ns string, obj runtime.Object) (runtime.Object, error) {
 					binding := obj.(*apiv1beta1.ServiceBinding)
 					testutil.AssertEqual(t, "name", "kf-binding-myapp-mydb", binding.Name)
@@ -101,6 +128,7 @@ func TestClient_Create(t *testing.T) {
 		},
 		"custom values": {
 			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				expectNoUserProvidedInstance(deps)
 				deps.apiserver.EXPECT().Create(gomock.Any(), "custom-ns", gomock.Any()).DoAndReturn(func(grv schema.GroupVersionResource, ns string, obj runtime.Object) (runtime.Object, error) {
 					binding := obj.(*apiv1beta1.ServiceBinding)
 					testutil.AssertEqual(t, "name", "kf-binding-myapp-mydb", binding.Name)
@@ -118,6 +146,55 @@ func TestClient_Create(t *testing.T) {
 					servicebindings.WithCreateParams(map[string]interface{}{"username": "my-user"}))
 			},
 		},
+		"user-provided instance": {
+			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				deps.secrets.EXPECT().
+					Get("kf-user-provided-mydb", gomock.Any()).
+					Return(userProvidedInstanceSecret("mydb"), nil)
+
+				deps.secrets.EXPECT().
+					Create("kf-binding-myapp-mydb", gomock.Any()).
+					DoAndReturn(func(name string, opts ...secrets.CreateOption) error {
+						cfg := secrets.CreateOptions(opts)
+						testutil.AssertEqual(t, "namespace", "custom-ns", cfg.Namespace())
+						testutil.AssertEqual(t, "labels", map[string]string{
+							"kf-binding-name":          "binding-name",
+							"kf-app-name":              "myapp",
+							"kf-user-provided-service": "mydb",
+						}, cfg.Labels())
+						return nil
+					})
+
+				binding, err := client.Create("mydb", "myapp",
+					servicebindings.WithCreateBindingName("binding-name"),
+					servicebindings.WithCreateNamespace("custom-ns"))
+				testutil.AssertNil(t, "create err", err)
+				testutil.AssertEqual(t, "name", "kf-binding-myapp-mydb", binding.Name)
+				testutil.AssertEqual(t, "Spec.InstanceRef.Name", "mydb", binding.Spec.InstanceRef.Name)
+				testutil.AssertEqual(t, "Spec.SecretName", "kf-user-provided-mydb", binding.Spec.SecretName)
+			},
+		},
+		"user-provided instance with params": {
+			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				deps.secrets.EXPECT().
+					Get("kf-user-provided-mydb", gomock.Any()).
+					Return(userProvidedInstanceSecret("mydb"), nil)
+
+				_, err := client.Create("mydb", "myapp",
+					servicebindings.WithCreateParams(map[string]interface{}{"username": "my-user"}))
+				testutil.AssertErrorsEqual(t, errors.New("binding parameters are not supported for user-provided service instances"), err)
+			},
+		},
+		"secret lookup error": {
+			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				deps.secrets.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("api error"))
+
+				_, err := client.Create("mydb", "myapp")
+				testutil.AssertErrorsEqual(t, errors.New("api error"), err)
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -136,6 +213,8 @@ func TestClient_GetOrCreate(t *testing.T) {
 				deps.apiserver.EXPECT().
 					List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(emptyBindingList, nil)
+				expectNoUserProvidedBindings(deps)
+				expectNoUserProvidedInstance(deps)
 
 				deps.apiserver.EXPECT().Create(gomock.Any(), "custom-ns", gomock.Any()).DoAndReturn(func(grv schema.GroupVersionResource, ns string, obj runtime.Object) (runtime.Object, error) {
 					binding := obj.(*apiv1beta1.ServiceBinding)
@@ -172,6 +251,7 @@ func TestClient_GetOrCreate(t *testing.T) {
 							},
 						},
 					}, nil)
+				expectNoUserProvidedBindings(deps)
 
 				binding, created, err := client.GetOrCreate("mydb", "myapp", servicebindings.WithCreateNamespace("custom-ns"))
 				testutil.AssertNil(t, "err", err)
@@ -213,6 +293,31 @@ func TestClient_Delete(t *testing.T) {
 				testutil.AssertNil(t, "delete err", err)
 			},
 		},
+		"user-provided binding": {
+			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				deps.apiserver.EXPECT().
+					Delete(gomock.Any(), "custom-ns", "kf-binding-myapp-mydb").
+					Return(apierrs.NewNotFound(schema.GroupResource{Group: "servicecatalog.k8s.io", Resource: "servicebindings"}, "kf-binding-myapp-mydb"))
+
+				bindingSecret := &corev1.Secret{}
+				bindingSecret.Labels = map[string]string{services.UserProvidedServiceLabel: "mydb"}
+				deps.secrets.EXPECT().Get("kf-binding-myapp-mydb", gomock.Any()).Return(bindingSecret, nil)
+				deps.secrets.EXPECT().Delete("kf-binding-myapp-mydb", gomock.Any()).Return(nil)
+
+				err := client.Delete("mydb", "myapp", servicebindings.WithDeleteNamespace("custom-ns"))
+				testutil.AssertNil(t, "delete err", err)
+			},
+		},
+		"binding doesn't exist": {
+			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				notFound := apierrs.NewNotFound(schema.GroupResource{Group: "servicecatalog.k8s.io", Resource: "servicebindings"}, "kf-binding-myapp-mydb")
+				deps.apiserver.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound)
+				deps.secrets.EXPECT().Get("kf-binding-myapp-mydb", gomock.Any()).Return(nil, errors.New("not found"))
+
+				err := client.Delete("mydb", "myapp")
+				testutil.AssertErrorsEqual(t, notFound, err)
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -227,6 +332,7 @@ func TestClient_List(t *testing.T) {
 				deps.apiserver.EXPECT().
 					List(gomock.Any(), "default", gomock.Any(), gomock.Any()).
 					Return(&apiv1beta1.ServiceBindingList{}, nil)
+				expectNoUserProvidedBindings(deps)
 
 				_, err := client.List()
 				testutil.AssertNil(t, "list err", err)
@@ -247,6 +353,7 @@ func TestClient_List(t *testing.T) {
 				deps.apiserver.EXPECT().
 					List(gomock.Any(), "custom-ns", gomock.Any(), gomock.Any()).
 					Return(&apiv1beta1.ServiceBindingList{}, nil)
+				expectNoUserProvidedBindings(deps)
 
 				_, err := client.List(servicebindings.WithListNamespace("custom-ns"))
 				testutil.AssertNil(t, "list err", err)
@@ -263,6 +370,7 @@ func TestClient_List(t *testing.T) {
 							{},
 						},
 					}, nil)
+				expectNoUserProvidedBindings(deps)
 
 				list, err := client.List()
 				testutil.AssertNil(t, "list err", err)
@@ -282,6 +390,7 @@ func TestClient_List(t *testing.T) {
 				deps.apiserver.EXPECT().
 					List(gomock.Any(), "default", gomock.Any(), gomock.Any()).
 					Return(&apiv1beta1.ServiceBindingList{Items: []apiv1beta1.ServiceBinding{mybinding, otherbinding}}, nil)
+				expectNoUserProvidedBindings(deps)
 
 				list, err := client.List(servicebindings.WithListAppName("my-app"))
 				testutil.AssertNil(t, "list err", err)
@@ -302,6 +411,7 @@ func TestClient_List(t *testing.T) {
 				deps.apiserver.EXPECT().
 					List(gomock.Any(), "default", gomock.Any(), gomock.Any()).
 					Return(&apiv1beta1.ServiceBindingList{Items: []apiv1beta1.ServiceBinding{mybinding, otherbinding}}, nil)
+				expectNoUserProvidedBindings(deps)
 
 				list, err := client.List(servicebindings.WithListServiceInstance("my-service"))
 				testutil.AssertNil(t, "list err", err)
@@ -309,6 +419,43 @@ func TestClient_List(t *testing.T) {
 				testutil.AssertEqual(t, "filtered item", mybinding, list[0])
 			},
 		},
+		"user-provided bindings get passed back": {
+			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				deps.apiserver.EXPECT().
+					List(gomock.Any(), "default", gomock.Any(), gomock.Any()).
+					Return(&apiv1beta1.ServiceBindingList{}, nil)
+
+				bindingSecret := corev1.Secret{}
+				bindingSecret.Name = "kf-binding-my-app-mydb"
+				bindingSecret.Labels = map[string]string{
+					servicebindings.AppNameLabel:      "my-app",
+					services.UserProvidedServiceLabel: "mydb",
+				}
+				deps.secrets.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return([]corev1.Secret{bindingSecret}, nil)
+
+				list, err := client.List(servicebindings.WithListAppName("my-app"))
+				testutil.AssertNil(t, "list err", err)
+				testutil.AssertEqual(t, "item count", 1, len(list))
+				testutil.AssertEqual(t, "name", "kf-binding-my-app-mydb", list[0].Name)
+				testutil.AssertEqual(t, "instance", "mydb", list[0].Spec.InstanceRef.Name)
+				testutil.AssertEqual(t, "user-provided", true, servicebindings.IsUserProvidedBinding(list[0]))
+			},
+		},
+		"user-provided list error": {
+			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				deps.apiserver.EXPECT().
+					List(gomock.Any(), "default", gomock.Any(), gomock.Any()).
+					Return(&apiv1beta1.ServiceBindingList{}, nil)
+				deps.secrets.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("api-error"))
+
+				_, err := client.List()
+				testutil.AssertErrorsEqual(t, errors.New("api-error"), err)
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -358,6 +505,7 @@ func TestClient_GetVcapServices(t *testing.T) {
 				deps.apiserver.EXPECT().
 					List(gomock.Any(), "default", gomock.Any(), gomock.Any()).
 					Return(emptyBindingList, nil)
+				expectNoUserProvidedBindings(deps)
 
 				_, err := client.GetVcapServices("my-app")
 				testutil.AssertNil(t, "GetVcapServices err", err)
@@ -368,6 +516,7 @@ func TestClient_GetVcapServices(t *testing.T) {
 				deps.apiserver.EXPECT().
 					List(gomock.Any(), "default", gomock.Any(), gomock.Any()).
 					Return(fakeBindingList, nil)
+				expectNoUserProvidedBindings(deps)
 
 				deps.apiserver.EXPECT().
 					Get(gomock.Any(), "default", "my-instance").
@@ -388,6 +537,7 @@ func TestClient_GetVcapServices(t *testing.T) {
 				deps.apiserver.EXPECT().
 					List(gomock.Any(), "default", gomock.Any(), gomock.Any()).
 					Return(fakeBindingList, nil)
+				expectNoUserProvidedBindings(deps)
 
 				deps.apiserver.EXPECT().
 					Get(gomock.Any(), "default", "my-instance").
@@ -408,6 +558,7 @@ func TestClient_GetVcapServices(t *testing.T) {
 				deps.apiserver.EXPECT().
 					List(gomock.Any(), "default", gomock.Any(), gomock.Any()).
 					Return(fakeBindingList, nil)
+				expectNoUserProvidedBindings(deps)
 
 				deps.apiserver.EXPECT().
 					Get(gomock.Any(), "default", "my-instance").
@@ -423,6 +574,42 @@ func TestClient_GetVcapServices(t *testing.T) {
 				testutil.AssertEqual(t, "vcap services", expectedVcap, actualVcap)
 			},
 		},
+		"user-provided service": {
+			Run: func(t *testing.T, deps fakeDependencies, client servicebindings.ClientInterface) {
+				deps.apiserver.EXPECT().
+					List(gomock.Any(), "default", gomock.Any(), gomock.Any()).
+					Return(emptyBindingList, nil)
+
+				bindingSecret := corev1.Secret{}
+				bindingSecret.Name = "kf-binding-my-app-mydb"
+				bindingSecret.Labels = map[string]string{
+					servicebindings.AppNameLabel:      "my-app",
+					servicebindings.BindingNameLabel:  "binding-name",
+					services.UserProvidedServiceLabel: "mydb",
+				}
+				deps.secrets.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return([]corev1.Secret{bindingSecret}, nil)
+
+				deps.secrets.EXPECT().
+					Get("kf-user-provided-mydb", gomock.Any()).
+					Return(userProvidedInstanceSecret("mydb"), nil)
+
+				actualVcap, err := client.GetVcapServices("my-app")
+				testutil.AssertNil(t, "GetVcapServices err", err)
+
+				expectedVcap := servicebindings.VcapServicesMap{
+					"user-provided": {{
+						BindingName:  "binding-name",
+						InstanceName: "mydb",
+						Name:         "kf-binding-my-app-mydb",
+						Label:        "user-provided",
+						Credentials:  map[string]string{"username": "admin"},
+					}},
+				}
+				testutil.AssertEqual(t, "vcap services", expectedVcap, actualVcap)
+			},
+		},
 	}
 
 	for tn, tc := range cases {
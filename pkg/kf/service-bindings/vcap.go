@@ -15,6 +15,7 @@
 package servicebindings
 
 import (
+	"github.com/google/kf/pkg/kf/services"
 	apiv1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -43,6 +44,9 @@ type VcapService struct {
 	Tags         []string          `json:"tags"`          // An array of strings an app can use to identify a service instance.
 	Plan         string            `json:"plan"`          // The service plan selected when the service instance was created.
 	Credentials  map[string]string `json:"credentials"`   // The service-specific credentials needed to access the service instance.
+
+	SyslogDrainURL  string `json:"syslog_drain_url,omitempty"`  // The URL logs should be drained to, only set for user-provided services.
+	RouteServiceURL string `json:"route_service_url,omitempty"` // The URL of the route service, only set for user-provided services.
 }
 
 // NewVcapService creates a new VcapService given a binding and associated
@@ -81,3 +85,23 @@ func NewVcapService(instance apiv1beta1.ServiceInstance, binding apiv1beta1.Serv
 
 	return vs
 }
+
+// NewUserProvidedVcapService creates a new VcapService given a binding to a
+// user-provided service and the secret backing the service instance.
+func NewUserProvidedVcapService(binding apiv1beta1.ServiceBinding, secret *corev1.Secret) (VcapService, error) {
+	ups, err := services.UserProvidedServiceFromSecret(secret)
+	if err != nil {
+		return VcapService{}, err
+	}
+
+	return VcapService{
+		BindingName:     binding.Labels[BindingNameLabel],
+		Name:            binding.Name,
+		InstanceName:    ups.Name,
+		Label:           services.UserProvidedServiceClass,
+		Tags:            ups.Tags,
+		Credentials:     ups.Credentials,
+		SyslogDrainURL:  ups.SyslogDrainURL,
+		RouteServiceURL: ups.RouteServiceURL,
+	}, nil
+}
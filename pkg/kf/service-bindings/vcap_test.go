@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
+	"github.com/google/kf/pkg/kf/services"
 	apiv1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -72,3 +73,37 @@ func ExampleNewVcapService() {
 	// Service: my-service
 	// Plan: my-service-plan
 }
+
+func ExampleNewUserProvidedVcapService() {
+	binding := apiv1beta1.ServiceBinding{}
+	binding.Spec.InstanceRef.Name = "my-instance"
+	binding.Name = "my-binding"
+	binding.Labels = map[string]string{
+		servicebindings.BindingNameLabel: "custom-binding-name",
+	}
+
+	secret := corev1.Secret{}
+	secret.Labels = map[string]string{
+		services.UserProvidedServiceLabel: "my-instance",
+	}
+	secret.Data = map[string][]byte{
+		"key1": []byte("value1"),
+	}
+
+	vs, err := servicebindings.NewUserProvidedVcapService(binding, &secret)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Name: %s\n", vs.Name)
+	fmt.Printf("InstanceName: %s\n", vs.InstanceName)
+	fmt.Printf("BindingName: %s\n", vs.BindingName)
+	fmt.Printf("Credentials: %v\n", vs.Credentials)
+	fmt.Printf("Service: %v\n", vs.Label)
+
+	// Output: Name: my-binding
+	// InstanceName: my-instance
+	// BindingName: custom-binding-name
+	// Credentials: map[key1:value1]
+	// Service: user-provided
+}
@@ -22,3 +22,10 @@ import "github.com/google/kf/pkg/kf/services"
 type ClientInterface interface {
 	services.ClientInterface
 }
+
+//go:generate mockgen --package=fake --destination=fake_user_provided_client_interface.go --copyright_file ../../internal/tools/option-builder/LICENSE_HEADER --mock_names=UserProvidedClientInterface=FakeUserProvidedClientInterface github.com/google/kf/pkg/kf/services/fake UserProvidedClientInterface
+
+// UserProvidedClientInterface is implementd by services.UserProvidedClient.
+type UserProvidedClientInterface interface {
+	services.UserProvidedClientInterface
+}
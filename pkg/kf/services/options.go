@@ -285,3 +285,276 @@ func MarketplaceOptionDefaults() MarketplaceOptions {
 		WithMarketplaceNamespace("default"),
 	}
 }
+
+type createUserProvidedServiceConfig struct {
+	// Credentials is the credentials to expose to bound apps.
+	Credentials map[string]interface{}
+	// Namespace is the Kubernetes namespace to use.
+	Namespace string
+	// RouteServiceURL is the URL of a route service that proxies requests to bound apps.
+	RouteServiceURL string
+	// SyslogDrainURL is the URL bound apps should drain their logs to.
+	SyslogDrainURL string
+	// Tags is tags apps can use to identify the service instance.
+	Tags []string
+}
+
+// CreateUserProvidedServiceOption is a single option for configuring a createUserProvidedServiceConfig
+type CreateUserProvidedServiceOption func(*createUserProvidedServiceConfig)
+
+// CreateUserProvidedServiceOptions is a configuration set defining a createUserProvidedServiceConfig
+type CreateUserProvidedServiceOptions []CreateUserProvidedServiceOption
+
+// toConfig applies all the options to a new createUserProvidedServiceConfig and returns it.
+func (opts CreateUserProvidedServiceOptions) toConfig() createUserProvidedServiceConfig {
+	cfg := createUserProvidedServiceConfig{}
+
+	for _, v := range opts {
+		v(&cfg)
+	}
+
+	return cfg
+}
+
+// Extend creates a new CreateUserProvidedServiceOptions with the contents of other overriding
+// the values set in this CreateUserProvidedServiceOptions.
+func (opts CreateUserProvidedServiceOptions) Extend(other CreateUserProvidedServiceOptions) CreateUserProvidedServiceOptions {
+	var out CreateUserProvidedServiceOptions
+	out = append(out, opts...)
+	out = append(out, other...)
+	return out
+}
+
+// Credentials returns the last set value for Credentials or the empty value
+// if not set.
+func (opts CreateUserProvidedServiceOptions) Credentials() map[string]interface{} {
+	return opts.toConfig().Credentials
+}
+
+// Namespace returns the last set value for Namespace or the empty value
+// if not set.
+func (opts CreateUserProvidedServiceOptions) Namespace() string {
+	return opts.toConfig().Namespace
+}
+
+// RouteServiceURL returns the last set value for RouteServiceURL or the empty value
+// if not set.
+func (opts CreateUserProvidedServiceOptions) RouteServiceURL() string {
+	return opts.toConfig().RouteServiceURL
+}
+
+// SyslogDrainURL returns the last set value for SyslogDrainURL or the empty value
+// if not set.
+func (opts CreateUserProvidedServiceOptions) SyslogDrainURL() string {
+	return opts.toConfig().SyslogDrainURL
+}
+
+// Tags returns the last set value for Tags or the empty value
+// if not set.
+func (opts CreateUserProvidedServiceOptions) Tags() []string {
+	return opts.toConfig().Tags
+}
+
+// WithCreateUserProvidedServiceCredentials creates an Option that sets the credentials to expose to bound apps.
+func WithCreateUserProvidedServiceCredentials(val map[string]interface{}) CreateUserProvidedServiceOption {
+	return func(cfg *createUserProvidedServiceConfig) {
+		cfg.Credentials = val
+	}
+}
+
+// WithCreateUserProvidedServiceNamespace creates an Option that sets the Kubernetes namespace to use.
+func WithCreateUserProvidedServiceNamespace(val string) CreateUserProvidedServiceOption {
+	return func(cfg *createUserProvidedServiceConfig) {
+		cfg.Namespace = val
+	}
+}
+
+// WithCreateUserProvidedServiceRouteServiceURL creates an Option that sets the URL of a route service that proxies requests to bound apps.
+func WithCreateUserProvidedServiceRouteServiceURL(val string) CreateUserProvidedServiceOption {
+	return func(cfg *createUserProvidedServiceConfig) {
+		cfg.RouteServiceURL = val
+	}
+}
+
+// WithCreateUserProvidedServiceSyslogDrainURL creates an Option that sets the URL bound apps should drain their logs to.
+func WithCreateUserProvidedServiceSyslogDrainURL(val string) CreateUserProvidedServiceOption {
+	return func(cfg *createUserProvidedServiceConfig) {
+		cfg.SyslogDrainURL = val
+	}
+}
+
+// WithCreateUserProvidedServiceTags creates an Option that sets tags apps can use to identify the service instance.
+func WithCreateUserProvidedServiceTags(val []string) CreateUserProvidedServiceOption {
+	return func(cfg *createUserProvidedServiceConfig) {
+		cfg.Tags = val
+	}
+}
+
+// CreateUserProvidedServiceOptionDefaults gets the default values for CreateUserProvidedService.
+func CreateUserProvidedServiceOptionDefaults() CreateUserProvidedServiceOptions {
+	return CreateUserProvidedServiceOptions{
+		WithCreateUserProvidedServiceNamespace("default"),
+	}
+}
+
+type updateUserProvidedServiceConfig struct {
+	// Credentials is the credentials to expose to bound apps, replaces the existing credentials if set.
+	Credentials map[string]interface{}
+	// Namespace is the Kubernetes namespace to use.
+	Namespace string
+	// RouteServiceURL is the URL of a route service that proxies requests to bound apps, replaces the existing URL if set, an empty URL removes it.
+	RouteServiceURL *string
+	// SyslogDrainURL is the URL bound apps should drain their logs to, replaces the existing URL if set, an empty URL removes it.
+	SyslogDrainURL *string
+	// Tags is tags apps can use to identify the service instance, replaces the existing tags if set.
+	Tags []string
+}
+
+// UpdateUserProvidedServiceOption is a single option for configuring a updateUserProvidedServiceConfig
+type UpdateUserProvidedServiceOption func(*updateUserProvidedServiceConfig)
+
+// UpdateUserProvidedServiceOptions is a configuration set defining a updateUserProvidedServiceConfig
+type UpdateUserProvidedServiceOptions []UpdateUserProvidedServiceOption
+
+// toConfig applies all the options to a new updateUserProvidedServiceConfig and returns it.
+func (opts UpdateUserProvidedServiceOptions) toConfig() updateUserProvidedServiceConfig {
+	cfg := updateUserProvidedServiceConfig{}
+
+	for _, v := range opts {
+		v(&cfg)
+	}
+
+	return cfg
+}
+
+// Extend creates a new UpdateUserProvidedServiceOptions with the contents of other overriding
+// the values set in this UpdateUserProvidedServiceOptions.
+func (opts UpdateUserProvidedServiceOptions) Extend(other UpdateUserProvidedServiceOptions) UpdateUserProvidedServiceOptions {
+	var out UpdateUserProvidedServiceOptions
+	out = append(out, opts...)
+	out = append(out, other...)
+	return out
+}
+
+// Credentials returns the last set value for Credentials or the empty value
+// if not set.
+func (opts UpdateUserProvidedServiceOptions) Credentials() map[string]interface{} {
+	return opts.toConfig().Credentials
+}
+
+// Namespace returns the last set value for Namespace or the empty value
+// if not set.
+func (opts UpdateUserProvidedServiceOptions) Namespace() string {
+	return opts.toConfig().Namespace
+}
+
+// RouteServiceURL returns the last set value for RouteServiceURL or the empty value
+// if not set.
+func (opts UpdateUserProvidedServiceOptions) RouteServiceURL() *string {
+	return opts.toConfig().RouteServiceURL
+}
+
+// SyslogDrainURL returns the last set value for SyslogDrainURL or the empty value
+// if not set.
+func (opts UpdateUserProvidedServiceOptions) SyslogDrainURL() *string {
+	return opts.toConfig().SyslogDrainURL
+}
+
+// Tags returns the last set value for Tags or the empty value
+// if not set.
+func (opts UpdateUserProvidedServiceOptions) Tags() []string {
+	return opts.toConfig().Tags
+}
+
+// WithUpdateUserProvidedServiceCredentials creates an Option that sets the credentials to expose to bound apps, replaces the existing credentials if set.
+func WithUpdateUserProvidedServiceCredentials(val map[string]interface{}) UpdateUserProvidedServiceOption {
+	return func(cfg *updateUserProvidedServiceConfig) {
+		cfg.Credentials = val
+	}
+}
+
+// WithUpdateUserProvidedServiceNamespace creates an Option that sets the Kubernetes namespace to use.
+func WithUpdateUserProvidedServiceNamespace(val string) UpdateUserProvidedServiceOption {
+	return func(cfg *updateUserProvidedServiceConfig) {
+		cfg.Namespace = val
+	}
+}
+
+// WithUpdateUserProvidedServiceRouteServiceURL creates an Option that sets the URL of a route service that proxies requests to bound apps, replaces the existing URL if set, an empty URL removes it.
+func WithUpdateUserProvidedServiceRouteServiceURL(val *string) UpdateUserProvidedServiceOption {
+	return func(cfg *updateUserProvidedServiceConfig) {
+		cfg.RouteServiceURL = val
+	}
+}
+
+// WithUpdateUserProvidedServiceSyslogDrainURL creates an Option that sets the URL bound apps should drain their logs to, replaces the existing URL if set, an empty URL removes it.
+func WithUpdateUserProvidedServiceSyslogDrainURL(val *string) UpdateUserProvidedServiceOption {
+	return func(cfg *updateUserProvidedServiceConfig) {
+		cfg.SyslogDrainURL = val
+	}
+}
+
+// WithUpdateUserProvidedServiceTags creates an Option that sets tags apps can use to identify the service instance, replaces the existing tags if set.
+func WithUpdateUserProvidedServiceTags(val []string) UpdateUserProvidedServiceOption {
+	return func(cfg *updateUserProvidedServiceConfig) {
+		cfg.Tags = val
+	}
+}
+
+// UpdateUserProvidedServiceOptionDefaults gets the default values for UpdateUserProvidedService.
+func UpdateUserProvidedServiceOptionDefaults() UpdateUserProvidedServiceOptions {
+	return UpdateUserProvidedServiceOptions{
+		WithUpdateUserProvidedServiceNamespace("default"),
+	}
+}
+
+type getUserProvidedServiceConfig struct {
+	// Namespace is the Kubernetes namespace to use.
+	Namespace string
+}
+
+// GetUserProvidedServiceOption is a single option for configuring a getUserProvidedServiceConfig
+type GetUserProvidedServiceOption func(*getUserProvidedServiceConfig)
+
+// GetUserProvidedServiceOptions is a configuration set defining a getUserProvidedServiceConfig
+type GetUserProvidedServiceOptions []GetUserProvidedServiceOption
+
+// toConfig applies all the options to a new getUserProvidedServiceConfig and returns it.
+func (opts GetUserProvidedServiceOptions) toConfig() getUserProvidedServiceConfig {
+	cfg := getUserProvidedServiceConfig{}
+
+	for _, v := range opts {
+		v(&cfg)
+	}
+
+	return cfg
+}
+
+// Extend creates a new GetUserProvidedServiceOptions with the contents of other overriding
+// the values set in this GetUserProvidedServiceOptions.
+func (opts GetUserProvidedServiceOptions) Extend(other GetUserProvidedServiceOptions) GetUserProvidedServiceOptions {
+	var out GetUserProvidedServiceOptions
+	out = append(out, opts...)
+	out = append(out, other...)
+	return out
+}
+
+// Namespace returns the last set value for Namespace or the empty value
+// if not set.
+func (opts GetUserProvidedServiceOptions) Namespace() string {
+	return opts.toConfig().Namespace
+}
+
+// WithGetUserProvidedServiceNamespace creates an Option that sets the Kubernetes namespace to use.
+func WithGetUserProvidedServiceNamespace(val string) GetUserProvidedServiceOption {
+	return func(cfg *getUserProvidedServiceConfig) {
+		cfg.Namespace = val
+	}
+}
+
+// GetUserProvidedServiceOptionDefaults gets the default values for GetUserProvidedService.
+func GetUserProvidedServiceOptionDefaults() GetUserProvidedServiceOptions {
+	return GetUserProvidedServiceOptions{
+		WithGetUserProvidedServiceNamespace("default"),
+	}
+}
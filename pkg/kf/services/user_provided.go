@@ -0,0 +1,251 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/kf/pkg/kf/secrets"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// UserProvidedServiceLabel is the label used on secrets to mark them as
+	// belonging to a user-provided service instance. The value is the name of
+	// the instance.
+	UserProvidedServiceLabel = "kf-user-provided-service"
+
+	// UserProvidedServiceClass is the name of the service offering
+	// user-provided services are listed under in VCAP_SERVICES.
+	UserProvidedServiceClass = "user-provided"
+
+	tagsAnnotation            = "kf-user-provided-service-tags"
+	syslogDrainURLAnnotation  = "kf-user-provided-service-syslog-drain-url"
+	routeServiceURLAnnotation = "kf-user-provided-service-route-service-url"
+)
+
+// UserProvidedService is a service instance that is managed outside of the
+// service catalog. The credentials are supplied directly by the user.
+type UserProvidedService struct {
+	Name            string
+	Credentials     map[string]string
+	Tags            []string
+	SyslogDrainURL  string
+	RouteServiceURL string
+}
+
+// UserProvidedClientInterface is a client capable of interacting with
+// user-provided service instances. Instances are stored as labelled secrets.
+type UserProvidedClientInterface interface {
+	// CreateUserProvidedService creates a new user-provided service instance.
+	CreateUserProvidedService(instanceName string, opts ...CreateUserProvidedServiceOption) error
+
+	// UpdateUserProvidedService changes the properties of an existing
+	// user-provided service instance.
+	UpdateUserProvidedService(instanceName string, opts ...UpdateUserProvidedServiceOption) error
+
+	// GetUserProvidedService gets a user-provided service instance.
+	GetUserProvidedService(instanceName string, opts ...GetUserProvidedServiceOption) (*UserProvidedService, error)
+}
+
+// NewUserProvidedClient creates a new client capable of interacting with
+// user-provided service instances.
+func NewUserProvidedClient(sc secrets.ClientInterface) UserProvidedClientInterface {
+	return &UserProvidedClient{
+		sc: sc,
+	}
+}
+
+// UserProvidedClient is an implementation of UserProvidedClientInterface
+// that stores instances as Kubernetes secrets.
+type UserProvidedClient struct {
+	sc secrets.ClientInterface
+}
+
+// CreateUserProvidedService creates a new user-provided service instance.
+func (c *UserProvidedClient) CreateUserProvidedService(instanceName string, opts ...CreateUserProvidedServiceOption) error {
+	cfg := CreateUserProvidedServiceOptionDefaults().Extend(opts).toConfig()
+
+	if err := ValidateRouteServiceURL(cfg.RouteServiceURL); err != nil {
+		return err
+	}
+
+	data, err := credentialsToData(cfg.Credentials)
+	if err != nil {
+		return err
+	}
+
+	return c.sc.Create(
+		UserProvidedServiceSecretName(instanceName),
+		secrets.WithCreateNamespace(cfg.Namespace),
+		secrets.WithCreateData(data),
+		secrets.WithCreateLabels(map[string]string{
+			UserProvidedServiceLabel: instanceName,
+		}),
+		secrets.WithCreateAnnotations(userProvidedAnnotations(cfg.Tags, cfg.SyslogDrainURL, cfg.RouteServiceURL)),
+	)
+}
+
+// UpdateUserProvidedService changes the properties of an existing
+// user-provided service instance. Properties that aren't set are left as-is,
+// URLs set to the empty string are removed.
+func (c *UserProvidedClient) UpdateUserProvidedService(instanceName string, opts ...UpdateUserProvidedServiceOption) error {
+	cfg := UpdateUserProvidedServiceOptionDefaults().Extend(opts).toConfig()
+
+	existing, err := c.GetUserProvidedService(instanceName, WithGetUserProvidedServiceNamespace(cfg.Namespace))
+	if err != nil {
+		return err
+	}
+
+	data := make(map[string][]byte)
+	for k, v := range existing.Credentials {
+		data[k] = []byte(v)
+	}
+
+	if cfg.Credentials != nil {
+		data, err = credentialsToData(cfg.Credentials)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.Tags != nil {
+		existing.Tags = cfg.Tags
+	}
+
+	if cfg.SyslogDrainURL != nil {
+		existing.SyslogDrainURL = *cfg.SyslogDrainURL
+	}
+
+	if cfg.RouteServiceURL != nil {
+		if err := ValidateRouteServiceURL(*cfg.RouteServiceURL); err != nil {
+			return err
+		}
+
+		existing.RouteServiceURL = *cfg.RouteServiceURL
+	}
+
+	return c.sc.Update(
+		UserProvidedServiceSecretName(instanceName),
+		secrets.WithUpdateNamespace(cfg.Namespace),
+		secrets.WithUpdateData(data),
+		secrets.WithUpdateAnnotations(userProvidedAnnotations(existing.Tags, existing.SyslogDrainURL, existing.RouteServiceURL)),
+	)
+}
+
+// GetUserProvidedService gets a user-provided service instance.
+func (c *UserProvidedClient) GetUserProvidedService(instanceName string, opts ...GetUserProvidedServiceOption) (*UserProvidedService, error) {
+	cfg := GetUserProvidedServiceOptionDefaults().Extend(opts).toConfig()
+
+	secret, err := c.sc.Get(
+		UserProvidedServiceSecretName(instanceName),
+		secrets.WithGetNamespace(cfg.Namespace),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return UserProvidedServiceFromSecret(secret)
+}
+
+// ValidateRouteServiceURL checks that a route service URL uses https. Route
+// services receive every request sent to bound routes so they must not be
+// reached over plain text. An empty URL is valid.
+func ValidateRouteServiceURL(routeServiceURL string) error {
+	if routeServiceURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(routeServiceURL)
+	if err != nil {
+		return fmt.Errorf("invalid route service URL: %v", err)
+	}
+
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("route service URL %q must be an https URL", routeServiceURL)
+	}
+
+	return nil
+}
+
+// UserProvidedServiceSecretName creates a deterministic secret name for a
+// user-provided service instance.
+func UserProvidedServiceSecretName(instanceName string) string {
+	return fmt.Sprintf("kf-user-provided-%s", instanceName)
+}
+
+// IsUserProvidedService returns true if the secret backs a user-provided
+// service instance.
+func IsUserProvidedService(secret *corev1.Secret) bool {
+	return secret != nil && secret.Labels[UserProvidedServiceLabel] != ""
+}
+
+// UserProvidedServiceFromSecret reads a user-provided service instance from
+// the secret that backs it.
+func UserProvidedServiceFromSecret(secret *corev1.Secret) (*UserProvidedService, error) {
+	if !IsUserProvidedService(secret) {
+		return nil, fmt.Errorf("secret %s is not a user-provided service", secret.Name)
+	}
+
+	ups := &UserProvidedService{
+		Name:            secret.Labels[UserProvidedServiceLabel],
+		Credentials:     make(map[string]string),
+		SyslogDrainURL:  secret.Annotations[syslogDrainURLAnnotation],
+		RouteServiceURL: secret.Annotations[routeServiceURLAnnotation],
+	}
+
+	if tags := secret.Annotations[tagsAnnotation]; tags != "" {
+		ups.Tags = strings.Split(tags, ",")
+	}
+
+	for k, v := range secret.Data {
+		ups.Credentials[k] = string(v)
+	}
+
+	return ups, nil
+}
+
+// credentialsToData converts user supplied JSON credentials into secret data.
+// Credentials are stored flat, like the service catalog stores them, so
+// non-string values are stored as their JSON encoding.
+func credentialsToData(credentials map[string]interface{}) (map[string][]byte, error) {
+	data := make(map[string][]byte)
+	for k, v := range credentials {
+		if s, ok := v.(string); ok {
+			data[k] = []byte(s)
+			continue
+		}
+
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't encode credential %q: %v", k, err)
+		}
+
+		data[k] = encoded
+	}
+
+	return data, nil
+}
+
+func userProvidedAnnotations(tags []string, syslogDrainURL, routeServiceURL string) map[string]string {
+	return map[string]string{
+		tagsAnnotation:            strings.Join(tags, ","),
+		syslogDrainURLAnnotation:  syslogDrainURL,
+		routeServiceURLAnnotation: routeServiceURL,
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/kf/secrets"
+	secretsfake "github.com/google/kf/pkg/kf/secrets/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func userProvidedSecret() *corev1.Secret {
+	secret := &corev1.Secret{}
+	secret.Name = "kf-user-provided-my-db"
+	secret.Labels = map[string]string{UserProvidedServiceLabel: "my-db"}
+	secret.Annotations = map[string]string{
+		tagsAnnotation:            "sql,oracle",
+		syslogDrainURLAnnotation:  "syslog://logs.example.com",
+		routeServiceURLAnnotation: "https://proxy.example.com",
+	}
+	secret.Data = map[string][]byte{"username": []byte("admin")}
+	return secret
+}
+
+func TestUserProvidedClient_CreateUserProvidedService(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Options []CreateUserProvidedServiceOption
+		Setup   func(t *testing.T, fakeSecrets *secretsfake.FakeClientInterface)
+
+		ExpectErr error
+	}{
+		"default values": {
+			Setup: func(t *testing.T, fakeSecrets *secretsfake.FakeClientInterface) {
+				fakeSecrets.EXPECT().
+					Create("kf-user-provided-my-db", gomock.Any()).
+					DoAndReturn(func(name string, opts ...secrets.CreateOption) error {
+						cfg := secrets.CreateOptions(opts)
+						testutil.AssertEqual(t, "namespace", "default", cfg.Namespace())
+						testutil.AssertEqual(t, "labels", map[string]string{UserProvidedServiceLabel: "my-db"}, cfg.Labels())
+						testutil.AssertEqual(t, "data", map[string][]byte{}, cfg.Data())
+						return nil
+					})
+			},
+		},
+		"custom values": {
+			Options: []CreateUserProvidedServiceOption{
+				WithCreateUserProvidedServiceNamespace("custom-ns"),
+				WithCreateUserProvidedServiceCredentials(map[string]interface{}{"username": "admin", "port": 1521}),
+				WithCreateUserProvidedServiceTags([]string{"sql", "oracle"}),
+				WithCreateUserProvidedServiceSyslogDrainURL("syslog://logs.example.com"),
+				WithCreateUserProvidedServiceRouteServiceURL("https://proxy.example.com"),
+			},
+			Setup: func(t *testing.T, fakeSecrets *secretsfake.FakeClientInterface) {
+				fakeSecrets.EXPECT().
+					Create("kf-user-provided-my-db", gomock.Any()).
+					DoAndReturn(func(name string, opts ...secrets.CreateOption) error {
+						cfg := secrets.CreateOptions(opts)
+						testutil.AssertEqual(t, "namespace", "custom-ns", cfg.Namespace())
+						testutil.AssertEqual(t, "data", map[string][]byte{
+							"username": []byte("admin"),
+							"port":     []byte("1521"),
+						}, cfg.Data())
+						testutil.AssertEqual(t, "annotations", userProvidedSecret().Annotations, cfg.Annotations())
+						return nil
+					})
+			},
+		},
+		"route service without https": {
+			Options: []CreateUserProvidedServiceOption{
+				WithCreateUserProvidedServiceRouteServiceURL("http://proxy.example.com"),
+			},
+			ExpectErr: errors.New(`route service URL "http://proxy.example.com" must be an https URL`),
+		},
+		"create error": {
+			Setup: func(t *testing.T, fakeSecrets *secretsfake.FakeClientInterface) {
+				fakeSecrets.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("some-error"))
+			},
+			ExpectErr: errors.New("some-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeSecrets := secretsfake.NewFakeClientInterface(ctrl)
+			if tc.Setup != nil {
+				tc.Setup(t, fakeSecrets)
+			}
+
+			client := NewUserProvidedClient(fakeSecrets)
+			actualErr := client.CreateUserProvidedService("my-db", tc.Options...)
+			testutil.AssertErrorsEqual(t, tc.ExpectErr, actualErr)
+		})
+	}
+}
+
+func TestUserProvidedClient_UpdateUserProvidedService(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Options []UpdateUserProvidedServiceOption
+		Setup   func(t *testing.T, fakeSecrets *secretsfake.FakeClientInterface)
+
+		ExpectErr error
+	}{
+		"keeps existing values": {
+			Setup: func(t *testing.T, fakeSecrets *secretsfake.FakeClientInterface) {
+				fakeSecrets.EXPECT().Get("kf-user-provided-my-db", gomock.Any()).Return(userProvidedSecret(), nil)
+				fakeSecrets.EXPECT().
+					Update("kf-user-provided-my-db", gomock.Any()).
+					DoAndReturn(func(name string, opts ...secrets.UpdateOption) error {
+						cfg := secrets.UpdateOptions(opts)
+						testutil.AssertEqual(t, "data", userProvidedSecret().Data, cfg.Data())
+						testutil.AssertEqual(t, "annotations", userProvidedSecret().Annotations, cfg.Annotations())
+						return nil
+					})
+			},
+		},
+		"replaces set values": {
+			Options: []UpdateUserProvidedServiceOption{
+				WithUpdateUserProvidedServiceCredentials(map[string]interface{}{"password": "secret"}),
+				WithUpdateUserProvidedServiceTags([]string{"legacy"}),
+			},
+			Setup: func(t *testing.T, fakeSecrets *secretsfake.FakeClientInterface) {
+				fakeSecrets.EXPECT().Get("kf-user-provided-my-db", gomock.Any()).Return(userProvidedSecret(), nil)
+				fakeSecrets.EXPECT().
+					Update("kf-user-provided-my-db", gomock.Any()).
+					DoAndReturn(func(name string, opts ...secrets.UpdateOption) error {
+						cfg := secrets.UpdateOptions(opts)
+						testutil.AssertEqual(t, "data", map[string][]byte{"password": []byte("secret")}, cfg.Data())
+						testutil.AssertEqual(t, "tags", "legacy", cfg.Annotations()[tagsAnnotation])
+						testutil.AssertEqual(t, "syslog", "syslog://logs.example.com", cfg.Annotations()[syslogDrainURLAnnotation])
+						return nil
+					})
+			},
+		},
+		"removes empty URLs": {
+			Options: []UpdateUserProvidedServiceOption{
+				WithUpdateUserProvidedServiceSyslogDrainURL(new(string)),
+				WithUpdateUserProvidedServiceRouteServiceURL(new(string)),
+			},
+			Setup: func(t *testing.T, fakeSecrets *secretsfake.FakeClientInterface) {
+				fakeSecrets.EXPECT().Get("kf-user-provided-my-db", gomock.Any()).Return(userProvidedSecret(), nil)
+				fakeSecrets.EXPECT().
+					Update("kf-user-provided-my-db", gomock.Any()).
+					DoAndReturn(func(name string, opts ...secrets.UpdateOption) error {
+						cfg := secrets.UpdateOptions(opts)
+						testutil.AssertEqual(t, "syslog", "", cfg.Annotations()[syslogDrainURLAnnotation])
+						testutil.AssertEqual(t, "route service", "", cfg.Annotations()[routeServiceURLAnnotation])
+						testutil.AssertEqual(t, "tags", "sql,oracle", cfg.Annotations()[tagsAnnotation])
+						return nil
+					})
+			},
+		},
+		"route service without https": {
+			Options: []UpdateUserProvidedServiceOption{
+				WithUpdateUserProvidedServiceRouteServiceURL(func() *string {
+					routeServiceURL := "http://proxy.example.com"
+					return &routeServiceURL
+				}()),
+			},
+			Setup: func(t *testing.T, fakeSecrets *secretsfake.FakeClientInterface) {
+				fakeSecrets.EXPECT().Get("kf-user-provided-my-db", gomock.Any()).Return(userProvidedSecret(), nil)
+			},
+			ExpectErr: errors.New(`route service URL "http://proxy.example.com" must be an https URL`),
+		},
+		"not a user-provided service": {
+			Setup: func(t *testing.T, fakeSecrets *secretsfake.FakeClientInterface) {
+				secret := &corev1.Secret{}
+				secret.Name = "kf-user-provided-my-db"
+				fakeSecrets.EXPECT().Get("kf-user-provided-my-db", gomock.Any()).Return(secret, nil)
+			},
+			ExpectErr: errors.New("secret kf-user-provided-my-db is not a user-provided service"),
+		},
+		"get error": {
+			Setup: func(t *testing.T, fakeSecrets *secretsfake.FakeClientInterface) {
+				fakeSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("some-error"))
+			},
+			ExpectErr: errors.New("some-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeSecrets := secretsfake.NewFakeClientInterface(ctrl)
+			if tc.Setup != nil {
+				tc.Setup(t, fakeSecrets)
+			}
+
+			client := NewUserProvidedClient(fakeSecrets)
+			actualErr := client.UpdateUserProvidedService("my-db", tc.Options...)
+			testutil.AssertErrorsEqual(t, tc.ExpectErr, actualErr)
+		})
+	}
+}
+
+func TestUserProvidedServiceFromSecret(t *testing.T) {
+	t.Parallel()
+
+	actual, err := UserProvidedServiceFromSecret(userProvidedSecret())
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "service", &UserProvidedService{
+		Name:            "my-db",
+		Credentials:     map[string]string{"username": "admin"},
+		Tags:            []string{"sql", "oracle"},
+		SyslogDrainURL:  "syslog://logs.example.com",
+		RouteServiceURL: "https://proxy.example.com",
+	}, actual)
+}
@@ -0,0 +1,217 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourceimage
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// KfIgnoreFile holds gitignore style patterns of files that aren't
+	// uploaded with an app's source. Its patterns take precedence over the
+	// ones in CFIgnoreFile.
+	KfIgnoreFile = ".kfignore"
+
+	// CFIgnoreFile is the Cloud Foundry equivalent of KfIgnoreFile.
+	CFIgnoreFile = ".cfignore"
+)
+
+// DefaultIgnores holds the patterns of files that are never uploaded unless
+// an ignore file negates them. They match the defaults of the Cloud Foundry
+// CLI.
+var DefaultIgnores = []string{
+	KfIgnoreFile,
+	CFIgnoreFile,
+	"_darcs",
+	".DS_Store",
+	".git",
+	".gitignore",
+	".hg",
+	".svn",
+	"/manifest.yml",
+	"/manifest.yaml",
+}
+
+// Matcher decides which files are ignored using gitignore style patterns.
+// Later patterns take precedence over earlier ones.
+type Matcher struct {
+	patterns []pattern
+}
+
+// NewMatcher creates a Matcher from gitignore style pattern lines. Blank
+// lines and comments are skipped.
+func NewMatcher(lines []string) *Matcher {
+	m := &Matcher{}
+	for _, line := range lines {
+		if p, ok := parsePattern(line); ok {
+			m.patterns = append(m.patterns, p)
+		}
+	}
+
+	return m
+}
+
+// LoadMatcher creates a Matcher for the source in dir from the
+// DefaultIgnores followed by the patterns in CFIgnoreFile and KfIgnoreFile
+// if they exist.
+func LoadMatcher(dir string) (*Matcher, error) {
+	lines := append([]string{}, DefaultIgnores...)
+
+	for _, name := range []string{CFIgnoreFile, KfIgnoreFile} {
+		fileLines, err := readLines(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, fileLines...)
+	}
+
+	return NewMatcher(lines), nil
+}
+
+// Ignored returns true if the slash separated path, relative to the root of
+// the source, is ignored.
+func (m *Matcher) Ignored(relPath string, isDir bool) bool {
+	segments := strings.Split(path.Clean(relPath), "/")
+
+	ignored := false
+	for _, p := range m.patterns {
+		if p.matches(segments, isDir) {
+			ignored = !p.negate
+		}
+	}
+
+	return ignored
+}
+
+// pattern is a single parsed gitignore line.
+type pattern struct {
+	// negate re-includes the files matched by the pattern.
+	negate bool
+
+	// dirOnly only matches directories.
+	dirOnly bool
+
+	// anchored patterns are matched from the root of the source, otherwise
+	// the pattern is matched against the name of the file.
+	anchored bool
+
+	segments []string
+}
+
+func parsePattern(line string) (pattern, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return pattern{}, false
+	}
+
+	p := pattern{}
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\#`) || strings.HasPrefix(line, `\!`) {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	if strings.Contains(line, "/") {
+		p.anchored = true
+		line = strings.TrimLeft(line, "/")
+	}
+
+	if line == "" {
+		return pattern{}, false
+	}
+
+	p.segments = strings.Split(line, "/")
+	return p, true
+}
+
+func (p *pattern) matches(segments []string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	if !p.anchored {
+		return matchSegments(p.segments, segments[len(segments)-1:])
+	}
+
+	return matchSegments(p.segments, segments)
+}
+
+// matchSegments matches path segments against pattern segments. A ** segment
+// matches any number of path segments.
+func matchSegments(patterns, segments []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			rest := patterns[1:]
+
+			// A trailing ** matches everything inside, but not the directory
+			// itself.
+			if len(rest) == 0 {
+				return len(segments) > 0
+			}
+
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(rest, segments[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+
+		if ok, _ := path.Match(patterns[0], segments[0]); !ok {
+			return false
+		}
+
+		patterns, segments = patterns[1:], segments[1:]
+	}
+
+	return len(segments) == 0
+}
+
+// readLines reads the lines of a file, a file that doesn't exist has no
+// lines.
+func readLines(filename string) ([]string, error) {
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines, scanner.Err()
+}
@@ -0,0 +1,165 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourceimage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestMatcher_Ignored(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		patterns []string
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		"no patterns": {
+			path:     "main.go",
+			expected: false,
+		},
+		"name matches at any depth": {
+			patterns: []string{"node_modules"},
+			path:     "web/node_modules",
+			isDir:    true,
+			expected: true,
+		},
+		"glob": {
+			patterns: []string{"*.log"},
+			path:     "logs/out.log",
+			expected: true,
+		},
+		"comments and blank lines are skipped": {
+			patterns: []string{"# *.go", ""},
+			path:     "main.go",
+			expected: false,
+		},
+		"escaped comment": {
+			patterns: []string{`\#notes`},
+			path:     "#notes",
+			expected: true,
+		},
+		"directory only pattern skips files": {
+			patterns: []string{"build/"},
+			path:     "build",
+			expected: false,
+		},
+		"directory only pattern": {
+			patterns: []string{"build/"},
+			path:     "build",
+			isDir:    true,
+			expected: true,
+		},
+		"leading slash anchors to the root": {
+			patterns: []string{"/target"},
+			path:     "sub/target",
+			isDir:    true,
+			expected: false,
+		},
+		"middle slash anchors to the root": {
+			patterns: []string{"docs/*.md"},
+			path:     "docs/README.md",
+			expected: true,
+		},
+		"leading double star": {
+			patterns: []string{"**/cache"},
+			path:     "a/b/cache",
+			isDir:    true,
+			expected: true,
+		},
+		"middle double star": {
+			patterns: []string{"a/**/b"},
+			path:     "a/x/y/b",
+			expected: true,
+		},
+		"trailing double star matches contents": {
+			patterns: []string{"tmp/**"},
+			path:     "tmp/file",
+			expected: true,
+		},
+		"trailing double star doesn't match the directory": {
+			patterns: []string{"tmp/**"},
+			path:     "tmp",
+			isDir:    true,
+			expected: false,
+		},
+		"negation": {
+			patterns: []string{"*.json", "!package.json"},
+			path:     "package.json",
+			expected: false,
+		},
+		"later patterns take precedence": {
+			patterns: []string{"!package.json", "*.json"},
+			path:     "package.json",
+			expected: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			m := NewMatcher(tc.patterns)
+
+			testutil.AssertEqual(t, "ignored", tc.expected, m.Ignored(tc.path, tc.isDir))
+		})
+	}
+}
+
+func TestLoadMatcher(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "sourceimage")
+	testutil.AssertNil(t, "err", err)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, filepath.Join(dir, CFIgnoreFile), "*.log\nvendor/\n")
+	writeFile(t, filepath.Join(dir, KfIgnoreFile), "!keep.log\n.git\n!.git\n")
+
+	m, err := LoadMatcher(dir)
+	testutil.AssertNil(t, "err", err)
+
+	testutil.AssertEqual(t, "default", true, m.Ignored("manifest.yml", false))
+	testutil.AssertEqual(t, "cfignore", true, m.Ignored("out.log", false))
+	testutil.AssertEqual(t, "cfignore dir", true, m.Ignored("vendor", true))
+	testutil.AssertEqual(t, "kfignore negates cfignore", false, m.Ignored("keep.log", false))
+	testutil.AssertEqual(t, "kfignore negates default", false, m.Ignored(".git", true))
+	testutil.AssertEqual(t, "not ignored", false, m.Ignored("main.go", false))
+}
+
+func TestLoadMatcher_noIgnoreFiles(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "sourceimage")
+	testutil.AssertNil(t, "err", err)
+	defer os.RemoveAll(dir)
+
+	m, err := LoadMatcher(dir)
+	testutil.AssertNil(t, "err", err)
+
+	testutil.AssertEqual(t, "default", true, m.Ignored(".git", true))
+	testutil.AssertEqual(t, "not ignored", false, m.Ignored("main.go", false))
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+
+	testutil.AssertNil(t, "MkdirAll", os.MkdirAll(filepath.Dir(path), 0755))
+	testutil.AssertNil(t, "WriteFile", ioutil.WriteFile(path, []byte(contents), 0644))
+}
@@ -0,0 +1,169 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourceimage
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// BuildImageFunc builds a container image from the contents of a directory.
+// It matches kontext.BuildImage.
+type BuildImageFunc func(dir, image string, rebase bool) error
+
+// WithIgnores wraps a BuildImageFunc so only the files in the directory that
+// aren't ignored are built into the image. The number and size of the files
+// are logged so accidentally large uploads can be spotted.
+func WithIgnores(build BuildImageFunc) BuildImageFunc {
+	return func(dir, image string, rebase bool) error {
+		staged, stats, err := Stage(dir)
+		if err != nil {
+			return fmt.Errorf("failed to prepare source: %s", err)
+		}
+		defer os.RemoveAll(staged)
+
+		log.Printf("Packaging %s", stats)
+		return build(staged, image, rebase)
+	}
+}
+
+// Stats describes the files staged for upload.
+type Stats struct {
+	// Files is the number of regular files.
+	Files int
+
+	// Bytes is the total size of the regular files.
+	Bytes int64
+}
+
+// String formats the stats for people e.g. "12 files, 3.4 MiB".
+func (s Stats) String() string {
+	return fmt.Sprintf("%d files, %s", s.Files, formatBytes(s.Bytes))
+}
+
+// Stage copies the files in dir that aren't ignored by the Matcher loaded
+// for dir into a new temporary directory. The caller is responsible for
+// removing the returned directory.
+func Stage(dir string) (string, Stats, error) {
+	matcher, err := LoadMatcher(dir)
+	if err != nil {
+		return "", Stats{}, err
+	}
+
+	staged, err := ioutil.TempDir("", "kf-source")
+	if err != nil {
+		return "", Stats{}, err
+	}
+
+	stats, err := copyTree(dir, staged, matcher)
+	if err != nil {
+		os.RemoveAll(staged)
+		return "", Stats{}, err
+	}
+
+	return staged, stats, nil
+}
+
+// copyTree copies the files in src that aren't ignored to dest. Symlinks are
+// copied as-is rather than followed.
+func copyTree(src, dest string, matcher *Matcher) (Stats, error) {
+	var stats Stats
+
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		if rel == "." {
+			return nil
+		}
+
+		if matcher.Ignored(filepath.ToSlash(rel), info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		target := filepath.Join(dest, rel)
+		switch mode := info.Mode(); {
+		case mode.IsDir():
+			return os.MkdirAll(target, 0755)
+
+		case mode&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+
+		case mode.IsRegular():
+			stats.Files++
+			stats.Bytes += info.Size()
+			return copyFile(path, target, mode.Perm())
+
+		default:
+			// Devices, sockets and pipes can't be uploaded.
+			return nil
+		}
+	})
+
+	return stats, err
+}
+
+func copyFile(src, dest string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// formatBytes formats a size using binary units e.g. 1.5 KiB.
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,105 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourceimage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestStage(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "sourceimage")
+	testutil.AssertNil(t, "err", err)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, filepath.Join(dir, KfIgnoreFile), "node_modules\n")
+	writeFile(t, filepath.Join(dir, "package.json"), "{}")
+	writeFile(t, filepath.Join(dir, "src", "index.js"), "console.log('hi')")
+	writeFile(t, filepath.Join(dir, "node_modules", "dep", "index.js"), "module.exports = {}")
+	writeFile(t, filepath.Join(dir, ".git", "HEAD"), "ref: refs/heads/master")
+	testutil.AssertNil(t, "Symlink", os.Symlink("src/index.js", filepath.Join(dir, "main.js")))
+
+	staged, stats, err := Stage(dir)
+	testutil.AssertNil(t, "err", err)
+	defer os.RemoveAll(staged)
+
+	var files []string
+	filepath.Walk(staged, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			rel, _ := filepath.Rel(staged, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(files)
+
+	testutil.AssertEqual(t, "files", []string{"main.js", "package.json", "src/index.js"}, files)
+	testutil.AssertEqual(t, "stats", Stats{Files: 2, Bytes: 19}, stats)
+
+	link, err := os.Readlink(filepath.Join(staged, "main.js"))
+	testutil.AssertNil(t, "Readlink", err)
+	testutil.AssertEqual(t, "symlink", "src/index.js", link)
+}
+
+func TestWithIgnores(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "sourceimage")
+	testutil.AssertNil(t, "err", err)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, filepath.Join(dir, "main.go"), "package main")
+	writeFile(t, filepath.Join(dir, ".git", "HEAD"), "ref: refs/heads/master")
+
+	var builtDir string
+	build := WithIgnores(func(dir, image string, rebase bool) error {
+		builtDir = dir
+		testutil.AssertEqual(t, "image", "some-image", image)
+
+		_, err := os.Stat(filepath.Join(dir, "main.go"))
+		testutil.AssertNil(t, "main.go err", err)
+
+		_, err = os.Stat(filepath.Join(dir, ".git"))
+		testutil.AssertEqual(t, ".git ignored", true, os.IsNotExist(err))
+		return nil
+	})
+
+	testutil.AssertNil(t, "err", build(dir, "some-image", false))
+
+	_, err = os.Stat(builtDir)
+	testutil.AssertEqual(t, "staged dir removed", true, os.IsNotExist(err))
+}
+
+func ExampleStats_String() {
+	fmt.Println(Stats{Files: 1, Bytes: 512})
+	fmt.Println(Stats{Files: 12, Bytes: 3565158})
+	fmt.Println(Stats{Files: 3000, Bytes: 5 * 1024 * 1024 * 1024})
+
+	// Output: 1 files, 512 B
+	// 12 files, 3.4 MiB
+	// 3000 files, 5.0 GiB
+}
@@ -19,36 +19,44 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	cv1alpha1 "github.com/google/kf/pkg/client/clientset/versioned/typed/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/buildbackends"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ClientExtension holds additional functions that should be exposed by client.
 type ClientExtension interface {
 	Tail(ctx context.Context, namespace, name string, writer io.Writer) error
 	Status(namespace, name string) (bool, error)
-}
+	Cancel(namespace, name string) error
 
-// BuildTailer is implemented by github.com/knative/build/pkg/logs
-type BuildTailer interface {
-	Tail(ctx context.Context, out io.Writer, buildName, namespace string) error
+	// WaitForBuild writes the build logs of the source to out and blocks
+	// until the build finishes. The finished source is returned along with
+	// an error if the build failed.
+	WaitForBuild(out io.Writer, namespace, name string) (*v1alpha1.Source, error)
 }
 
 type sourcesClient struct {
 	coreClient
 
-	buildTailer BuildTailer
+	backends buildbackends.Registry
 }
 
-// NewClient creates a new build client.
-func NewClient(kclient cv1alpha1.SourcesGetter, buildTailer BuildTailer) Client {
+// NewClient creates a new build client. The backends are used to tail the
+// logs of builds.
+func NewClient(kclient cv1alpha1.SourcesGetter, backends buildbackends.Registry) Client {
 	return &sourcesClient{
 		coreClient: coreClient{
 			kclient:             kclient,
 			upsertMutate:        MutatorList{},
 			membershipValidator: AllPredicate(),
 		},
-		buildTailer: buildTailer,
+		backends: backends,
 	}
 }
 
@@ -75,6 +83,79 @@ func (c *sourcesClient) Tail(ctx context.Context, namespace, name string, writer
 		return errors.New("The build hasn't started yet")
 	}
 
+	backend, err := c.backends.Get(bld.Status.BuildBackend)
+	if err != nil {
+		return err
+	}
+
 	fmt.Fprintf(writer, "Logs for %s (backed by build: %s)\n", name, buildName)
-	return c.buildTailer.Tail(ctx, writer, buildName, namespace)
+	return backend.Tail(ctx, writer, buildName, namespace)
+}
+
+// Cancel asks the controller to stop the build of the source with the given
+// name, the source is marked cancelled once the build is stopped. An error
+// is returned if the build already finished.
+func (c *sourcesClient) Cancel(namespace, name string) error {
+	return c.coreClient.Transform(namespace, name, func(src *v1alpha1.Source) error {
+		buildName := src.Status.BuildName
+		if buildName == "" {
+			return errors.New("the build hasn't started yet")
+		}
+
+		if finished, _ := SourceStatus(*src); finished {
+			return fmt.Errorf("the build %q already finished", name)
+		}
+
+		if src.Annotations == nil {
+			src.Annotations = make(map[string]string)
+		}
+		src.Annotations[v1alpha1.CancelledBuildAnnotation] = buildName
+
+		return nil
+	})
+}
+
+// WaitForBuild watches the source until its build finishes, the build logs
+// are streamed to out once the build starts.
+func (c *sourcesClient) WaitForBuild(out io.Writer, namespace, name string) (*v1alpha1.Source, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var tailOnce sync.Once
+	for {
+		ws, err := c.kclient.Sources(namespace).Watch(metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String(),
+			Watch:         true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for e := range ws.ResultChan() {
+			if e.Type == watch.Deleted {
+				ws.Stop()
+				return nil, fmt.Errorf("the build %q was deleted", name)
+			}
+
+			src, ok := e.Object.(*v1alpha1.Source)
+			if !ok {
+				continue
+			}
+
+			if src.Status.BuildName != "" {
+				tailOnce.Do(func() {
+					// ignoring tail errs because they are spurious
+					go c.Tail(ctx, namespace, name, out)
+				})
+			}
+
+			if finished, err := SourceStatus(*src); finished {
+				ws.Stop()
+				return src, err
+			}
+		}
+
+		// The watch timed out, start a new one.
+		ws.Stop()
+	}
 }
@@ -51,6 +51,20 @@ func (m *FakeClient) EXPECT() *FakeClientMockRecorder {
 	return m.recorder
 }
 
+// Cancel mocks base method
+func (m *FakeClient) Cancel(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel
+func (mr *FakeClientMockRecorder) Cancel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*FakeClient)(nil).Cancel), arg0, arg1)
+}
+
 // Create mocks base method
 func (m *FakeClient) Create(arg0 string, arg1 *v1alpha1.Source, arg2 ...sources.CreateOption) (*v1alpha1.Source, error) {
 	m.ctrl.T.Helper()
@@ -207,3 +221,18 @@ func (mr *FakeClientMockRecorder) Upsert(arg0, arg1, arg2 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*FakeClient)(nil).Upsert), arg0, arg1, arg2)
 }
+
+// WaitForBuild mocks base method
+func (m *FakeClient) WaitForBuild(arg0 io.Writer, arg1, arg2 string) (*v1alpha1.Source, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForBuild", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha1.Source)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForBuild indicates an expected call of WaitForBuild
+func (mr *FakeClientMockRecorder) WaitForBuild(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForBuild", reflect.TypeOf((*FakeClient)(nil).WaitForBuild), arg0, arg1, arg2)
+}
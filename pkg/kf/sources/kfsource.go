@@ -15,8 +15,11 @@
 package sources
 
 import (
+	"time"
+
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // KfSource provides a facade around v1alpha1.Source for accessing and mutating
@@ -64,6 +67,16 @@ func (k *KfSource) SetBuildpackBuildSource(sourceImage string) {
 	k.Spec.BuildpackBuild.Source = sourceImage
 }
 
+// SetBuildpackBuildGit sets the Git repository that contains the source code.
+func (k *KfSource) SetBuildpackBuildGit(git *v1alpha1.SourceSpecGit) {
+	k.Spec.BuildpackBuild.Git = git
+}
+
+// GetBuildpackBuildGit gets the Git repository that contains the source code.
+func (k *KfSource) GetBuildpackBuildGit() *v1alpha1.SourceSpecGit {
+	return k.Spec.BuildpackBuild.Git
+}
+
 // SetBuildpackBuildRegistry sets the container registry that the built code
 // will be pushed to.
 func (k *KfSource) SetBuildpackBuildRegistry(registry string) {
@@ -96,6 +109,82 @@ func (k *KfSource) GetBuildpackBuildBuildpack() string {
 	return k.Spec.BuildpackBuild.Buildpack
 }
 
+// SetBuildpackBuildStack sets the stack for a buildpack build.
+func (k *KfSource) SetBuildpackBuildStack(stack string) {
+	k.Spec.BuildpackBuild.Stack = stack
+}
+
+// GetBuildpackBuildStack gets the stack for a buildpack build.
+func (k *KfSource) GetBuildpackBuildStack() string {
+	return k.Spec.BuildpackBuild.Stack
+}
+
+// SetDockerfileSource sets the image that contains the source code for a
+// Dockerfile build.
+func (k *KfSource) SetDockerfileSource(sourceImage string) {
+	k.Spec.Dockerfile.Source = sourceImage
+}
+
+// GetDockerfileSource gets the image that contains the source code for a
+// Dockerfile build.
+func (k *KfSource) GetDockerfileSource() string {
+	return k.Spec.Dockerfile.Source
+}
+
+// SetDockerfileGit sets the Git repository that contains the source code for
+// a Dockerfile build.
+func (k *KfSource) SetDockerfileGit(git *v1alpha1.SourceSpecGit) {
+	k.Spec.Dockerfile.Git = git
+}
+
+// GetDockerfileGit gets the Git repository that contains the source code for
+// a Dockerfile build.
+func (k *KfSource) GetDockerfileGit() *v1alpha1.SourceSpecGit {
+	return k.Spec.Dockerfile.Git
+}
+
+// SetDockerfilePath sets the path of the Dockerfile within the source.
+func (k *KfSource) SetDockerfilePath(path string) {
+	k.Spec.Dockerfile.Path = path
+}
+
+// GetDockerfilePath gets the path of the Dockerfile within the source.
+func (k *KfSource) GetDockerfilePath() string {
+	return k.Spec.Dockerfile.Path
+}
+
+// SetDockerfileRegistry sets the container registry that the built image
+// will be pushed to.
+func (k *KfSource) SetDockerfileRegistry(registry string) {
+	k.Spec.Dockerfile.Registry = registry
+}
+
+// GetDockerfileRegistry gets the container registry that the built image
+// will be pushed to.
+func (k *KfSource) GetDockerfileRegistry() string {
+	return k.Spec.Dockerfile.Registry
+}
+
+// SetTimeout sets the longest the build can run. A zero timeout unsets it so
+// the space's default is used.
+func (k *KfSource) SetTimeout(timeout time.Duration) {
+	if timeout == 0 {
+		k.Spec.Timeout = nil
+		return
+	}
+
+	k.Spec.Timeout = &metav1.Duration{Duration: timeout}
+}
+
+// GetTimeout gets the longest the build can run or zero if it isn't set.
+func (k *KfSource) GetTimeout() time.Duration {
+	if k.Spec.Timeout == nil {
+		return 0
+	}
+
+	return k.Spec.Timeout.Duration
+}
+
 // ToSource casts this alias back into a Namespace.
 func (k *KfSource) ToSource() *v1alpha1.Source {
 	return (*v1alpha1.Source)(k)
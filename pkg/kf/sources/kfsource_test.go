@@ -16,7 +16,9 @@ package sources
 
 import (
 	"fmt"
+	"time"
 
+	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -29,12 +31,16 @@ func ExampleKfSource_buildpack() {
 	source.SetBuildpackBuildEnv([]corev1.EnvVar{{Name: "JAVA_VERSION", Value: "11"}})
 	source.SetBuildpackBuildBuildpack("java")
 	source.SetBuildpackBuildRegistry("gcr.io/some-registry")
+	source.SetBuildpackBuildStack("cflinuxfs3")
+	source.SetTimeout(30 * time.Minute)
 
 	fmt.Println("Name:", source.GetName())
 	fmt.Println("Namespace:", source.GetNamespace())
 	fmt.Println("Source:", source.GetBuildpackBuildSource())
 	fmt.Println("Buildpack:", source.GetBuildpackBuildBuildpack())
 	fmt.Println("Registry:", source.GetBuildpackBuildRegistry())
+	fmt.Println("Stack:", source.GetBuildpackBuildStack())
+	fmt.Println("Timeout:", source.GetTimeout())
 
 	for _, env := range source.GetBuildpackBuildEnv() {
 		fmt.Println("Env:", env.Name, "=", env.Value)
@@ -45,6 +51,8 @@ func ExampleKfSource_buildpack() {
 	// Source: gcr.io/my-source-code-image
 	// Buildpack: java
 	// Registry: gcr.io/some-registry
+	// Stack: cflinuxfs3
+	// Timeout: 30m0s
 	// Env: JAVA_VERSION = 11
 }
 
@@ -63,3 +71,43 @@ func ExampleKfSource_docker() {
 	// Namespace: my-namespace
 	// Source: mysql/mysql
 }
+
+func ExampleKfSource_git() {
+	source := NewKfSource()
+
+	source.SetBuildpackBuildGit(&v1alpha1.SourceSpecGit{
+		URL:      "https://github.com/google/kf",
+		Revision: "master",
+	})
+	source.SetDockerfileGit(&v1alpha1.SourceSpecGit{
+		URL: "https://github.com/google/kf-dockerfile",
+	})
+
+	fmt.Println("Buildpack Git:", source.GetBuildpackBuildGit().URL, "@", source.GetBuildpackBuildGit().Revision)
+	fmt.Println("Dockerfile Git:", source.GetDockerfileGit().URL)
+
+	// Output: Buildpack Git: https://github.com/google/kf @ master
+	// Dockerfile Git: https://github.com/google/kf-dockerfile
+}
+
+func ExampleKfSource_dockerfile() {
+	source := NewKfSource()
+
+	source.SetName("my-dockerfile-build")
+	source.SetNamespace("my-namespace")
+	source.SetDockerfileSource("gcr.io/my-source-code-image")
+	source.SetDockerfilePath("docker/Dockerfile")
+	source.SetDockerfileRegistry("gcr.io/some-registry")
+
+	fmt.Println("Name:", source.GetName())
+	fmt.Println("Namespace:", source.GetNamespace())
+	fmt.Println("Source:", source.GetDockerfileSource())
+	fmt.Println("Dockerfile:", source.GetDockerfilePath())
+	fmt.Println("Registry:", source.GetDockerfileRegistry())
+
+	// Output: Name: my-dockerfile-build
+	// Namespace: my-namespace
+	// Source: gcr.io/my-source-code-image
+	// Dockerfile: docker/Dockerfile
+	// Registry: gcr.io/some-registry
+}
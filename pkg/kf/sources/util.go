@@ -15,9 +15,7 @@
 package sources
 
 import (
-	"context"
 	"fmt"
-	"io"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
@@ -46,10 +44,20 @@ func SourceStatus(source v1alpha1.Source) (finished bool, err error) {
 	}
 }
 
-// BuildTailerFunc converts a func into a BuildTailer.
-type BuildTailerFunc func(ctx context.Context, out io.Writer, buildName, namespace string) error
+// LatestSucceeded returns the most recently created Source that built an
+// image successfully or nil if there isn't one.
+func LatestSucceeded(sources []v1alpha1.Source) *v1alpha1.Source {
+	var latest *v1alpha1.Source
+	for i := range sources {
+		source := &sources[i]
+		if finished, err := SourceStatus(*source); !finished || err != nil || source.Status.Image == "" {
+			continue
+		}
+
+		if latest == nil || latest.CreationTimestamp.Before(&source.CreationTimestamp) {
+			latest = source
+		}
+	}
 
-// Tail implements BuildTailer.
-func (f BuildTailerFunc) Tail(ctx context.Context, out io.Writer, buildName, namespace string) error {
-	return f(ctx, out, buildName, namespace)
+	return latest
 }
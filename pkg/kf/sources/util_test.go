@@ -22,6 +22,7 @@ import (
 	"github.com/google/kf/pkg/kf/sources"
 	"github.com/google/kf/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	duck "knative.dev/pkg/apis/duck/v1beta1"
 )
 
@@ -86,3 +87,59 @@ func TestBuildStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestLatestSucceeded(t *testing.T) {
+	newSource := func(name string, created int64, status corev1.ConditionStatus, image string) v1alpha1.Source {
+		source := v1alpha1.Source{}
+		source.Name = name
+		source.CreationTimestamp = metav1.Unix(created, 0)
+		source.Status.Image = image
+		source.Status.Conditions = duck.Conditions{
+			{Type: v1alpha1.SourceConditionSucceeded, Status: status},
+		}
+		return source
+	}
+
+	cases := map[string]struct {
+		sources  []v1alpha1.Source
+		wantName string
+	}{
+		"no sources": {
+			wantName: "",
+		},
+		"newest succeeded": {
+			sources: []v1alpha1.Source{
+				newSource("old", 1, corev1.ConditionTrue, "some-image-1"),
+				newSource("new", 3, corev1.ConditionTrue, "some-image-3"),
+				newSource("middle", 2, corev1.ConditionTrue, "some-image-2"),
+			},
+			wantName: "new",
+		},
+		"skips failed and running": {
+			sources: []v1alpha1.Source{
+				newSource("succeeded", 1, corev1.ConditionTrue, "some-image"),
+				newSource("failed", 2, corev1.ConditionFalse, ""),
+				newSource("running", 3, corev1.ConditionUnknown, ""),
+			},
+			wantName: "succeeded",
+		},
+		"skips missing image": {
+			sources: []v1alpha1.Source{
+				newSource("no-image", 1, corev1.ConditionTrue, ""),
+			},
+			wantName: "",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			latest := sources.LatestSucceeded(tc.sources)
+
+			gotName := ""
+			if latest != nil {
+				gotName = latest.Name
+			}
+			testutil.AssertEqual(t, "name", tc.wantName, gotName)
+		})
+	}
+}
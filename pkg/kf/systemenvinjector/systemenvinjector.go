@@ -51,7 +51,13 @@ func (s *SystemEnvInjector) ComputeSystemEnv(app *v1alpha1.App) (computed []core
 	}
 	computed = append(computed, va)
 
-	vs, err := s.bindingsClient.GetVcapServices(app.Name, servicebindings.WithGetVcapServicesNamespace(app.Namespace))
+	// Apps can expose the services bound to another App.
+	bindingsApp := app.Name
+	if name := app.Annotations[v1alpha1.ServiceBindingsAppAnnotation]; name != "" {
+		bindingsApp = name
+	}
+
+	vs, err := s.bindingsClient.GetVcapServices(bindingsApp, servicebindings.WithGetVcapServicesNamespace(app.Namespace))
 	if err != nil {
 		return nil, err
 	}
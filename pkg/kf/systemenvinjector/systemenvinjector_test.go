@@ -53,6 +53,20 @@ func TestSystemEnvInjector(t *testing.T) {
 				}
 			},
 		},
+		"bindings of another app": {
+			setup: func(app *v1alpha1.App, fake *fakebindings.FakeClientInterface) {
+				app.Name = "foo-venerable"
+				app.Namespace = "ns"
+				app.Annotations = map[string]string{v1alpha1.ServiceBindingsAppAnnotation: "foo"}
+
+				fake.EXPECT().GetVcapServices("foo", gomock.Any()).Return(servicebindings.VcapServicesMap{}, nil)
+			},
+			validate: func(t *testing.T, env map[string]string) {
+				if _, ok := env["VCAP_SERVICES"]; !ok {
+					t.Fatal("Expected map to contain VCAP_SERVICES")
+				}
+			},
+		},
 		"lookup failure": {
 			setup: func(app *v1alpha1.App, fake *fakebindings.FakeClientInterface) {
 				app.Name = "foo"
@@ -0,0 +1,153 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	cv1alpha1 "github.com/google/kf/pkg/client/clientset/versioned/typed/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// ClientExtension holds additional functions that should be exposed by client.
+type ClientExtension interface {
+	// Terminate stops a running Task.
+	Terminate(namespace, name string) error
+
+	// WaitForCompletion polls the Task until it has finished running and
+	// returns its final state.
+	WaitForCompletion(ctx context.Context, namespace, name string, interval time.Duration) (*v1alpha1.Task, error)
+
+	// Tail streams the logs of the Task to the writer until the Task's process
+	// exits.
+	Tail(ctx context.Context, namespace, name string, out io.Writer) error
+}
+
+type tasksClient struct {
+	coreClient
+
+	k8sClient v1.CoreV1Interface
+}
+
+// NewClient creates a new task client.
+func NewClient(kclient cv1alpha1.TasksGetter, k8sClient v1.CoreV1Interface) Client {
+	return &tasksClient{
+		coreClient: coreClient{
+			kclient:             kclient,
+			upsertMutate:        MutatorList{},
+			membershipValidator: AllPredicate(),
+		},
+		k8sClient: k8sClient,
+	}
+}
+
+// Terminate marks the Task as terminated, the controller will stop the
+// process if it's still running.
+func (c *tasksClient) Terminate(namespace, name string) error {
+	return c.Transform(namespace, name, func(task *v1alpha1.Task) error {
+		task.Spec.Terminated = true
+		return nil
+	})
+}
+
+// WaitForCompletion polls the Task until it has finished running and
+// returns its final state.
+func (c *tasksClient) WaitForCompletion(ctx context.Context, namespace, name string, interval time.Duration) (*v1alpha1.Task, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		task, err := c.Get(namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if task.Status.IsDone() {
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tail streams the logs of the Task to the writer until the Task's process
+// exits.
+func (c *tasksClient) Tail(ctx context.Context, namespace, name string, out io.Writer) error {
+	w, err := c.k8sClient.Pods(namespace).Watch(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", v1alpha1.TaskNameLabel, name),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch pods: %s", err)
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case e, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+
+			if e.Type != watch.Added && e.Type != watch.Modified {
+				continue
+			}
+
+			pod, ok := e.Object.(*corev1.Pod)
+			if !ok || pod.Status.Phase == corev1.PodPending {
+				// Logs can't be read until the container has started.
+				continue
+			}
+
+			return c.readLogs(ctx, namespace, pod.Name, out)
+		}
+	}
+}
+
+func (c *tasksClient) readLogs(ctx context.Context, namespace, podName string, out io.Writer) error {
+	// XXX: This is not tested at a unit level and instead defers to
+	// integration tests.
+	stream, err := c.k8sClient.
+		Pods(namespace).
+		GetLogs(podName, &corev1.PodLogOptions{
+			Container: "user-container",
+			Follow:    true,
+		}).
+		Context(ctx).
+		Stream()
+	if err != nil {
+		return fmt.Errorf("failed to read stream: %s", err)
+	}
+	defer stream.Close()
+
+	if _, err := io.Copy(out, stream); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
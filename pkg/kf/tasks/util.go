@@ -0,0 +1,61 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TaskStatus gets the status of the given Task.
+// Finished will be set to true if the Task has completed.
+// Error will be set if the Task completed with an error.
+// A successful result is one that completed and error is nil.
+func TaskStatus(task v1alpha1.Task) (finished bool, err error) {
+	condition := task.Status.GetCondition(v1alpha1.TaskConditionSucceeded)
+	if condition == nil {
+		// no success condition means the Task hasn't propagated yet
+		return false, nil
+	}
+
+	switch condition.Status {
+	case corev1.ConditionTrue:
+		return true, nil
+
+	case corev1.ConditionFalse:
+		return true, fmt.Errorf("task failed for reason: %s with message: %s", condition.Reason, condition.Message)
+
+	default: // the Task is still running
+		return false, nil
+	}
+}
+
+// ExitCode formats the exit code of the Task, it's blank if the Task hasn't
+// exited.
+func ExitCode(task v1alpha1.Task) string {
+	if task.Status.ExitCode == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d", *task.Status.ExitCode)
+}
+
+// Command formats the process the Task runs.
+func Command(task v1alpha1.Task) string {
+	return strings.Join(append(task.Spec.Command, task.Spec.Args...), " ")
+}
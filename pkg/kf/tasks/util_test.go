@@ -0,0 +1,108 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	duck "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+func TestTaskStatus(t *testing.T) {
+	cases := map[string]struct {
+		task           v1alpha1.Task
+		expectFinished bool
+		expectErr      error
+	}{
+		"incomplete": {
+			task:           v1alpha1.Task{},
+			expectFinished: false,
+			expectErr:      nil,
+		},
+		"failed": {
+			task: v1alpha1.Task{
+				Status: v1alpha1.TaskStatus{
+					Status: duck.Status{
+						Conditions: duck.Conditions{
+							{Type: v1alpha1.TaskConditionSucceeded, Status: "False", Reason: "fail-reason", Message: "fail-message"},
+						},
+					},
+				},
+			},
+			expectFinished: true,
+			expectErr:      errors.New("task failed for reason: fail-reason with message: fail-message"),
+		},
+		"succeeded": {
+			task: v1alpha1.Task{
+				Status: v1alpha1.TaskStatus{
+					Status: duck.Status{
+						Conditions: duck.Conditions{
+							{Type: v1alpha1.TaskConditionSucceeded, Status: corev1.ConditionTrue},
+						},
+					},
+				},
+			},
+			expectFinished: true,
+			expectErr:      nil,
+		},
+		"still running": {
+			task: v1alpha1.Task{
+				Status: v1alpha1.TaskStatus{
+					Status: duck.Status{
+						Conditions: duck.Conditions{
+							{Type: v1alpha1.TaskConditionSucceeded, Status: corev1.ConditionUnknown, Reason: "Running"},
+						},
+					},
+				},
+			},
+			expectFinished: false,
+			expectErr:      nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			finished, err := tasks.TaskStatus(tc.task)
+
+			testutil.AssertEqual(t, "finished", tc.expectFinished, finished)
+			testutil.AssertErrorsEqual(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	exitCode := int32(3)
+
+	testutil.AssertEqual(t, "not exited", "", tasks.ExitCode(v1alpha1.Task{}))
+	testutil.AssertEqual(t, "exited", "3", tasks.ExitCode(v1alpha1.Task{
+		Status: v1alpha1.TaskStatus{ExitCode: &exitCode},
+	}))
+}
+
+func TestCommand(t *testing.T) {
+	task := v1alpha1.Task{
+		Spec: v1alpha1.TaskSpec{
+			Command: []string{"/bin/sh", "-c"},
+			Args:    []string{"echo hello"},
+		},
+	}
+
+	testutil.AssertEqual(t, "command", "/bin/sh -c echo hello", tasks.Command(task))
+}
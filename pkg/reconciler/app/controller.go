@@ -19,19 +19,15 @@ import (
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	appinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/app"
+	domaininformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/domain"
 	routeinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/route"
 	sourceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/source"
 	spaceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/space"
+	pvcinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/persistentvolumeclaim"
 	servicebindinginformer "github.com/google/kf/pkg/client/servicecatalog/injection/informers/servicecatalog/v1beta1/servicebinding"
-	"github.com/google/kf/pkg/kf/secrets"
-	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
-	"github.com/google/kf/pkg/kf/systemenvinjector"
 	"github.com/google/kf/pkg/reconciler"
 	krevisioninformer "github.com/knative/serving/pkg/client/injection/informers/serving/v1alpha1/revision"
 	kserviceinformer "github.com/knative/serving/pkg/client/injection/informers/serving/v1alpha1/service"
-	svccatcv1beta1 "github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -49,26 +45,9 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	appInformer := appinformer.Get(ctx)
 	spaceInformer := spaceinformer.Get(ctx)
 	routeInformer := routeinformer.Get(ctx)
+	domainInformer := domaininformer.Get(ctx)
 	serviceBindingInformer := servicebindinginformer.Get(ctx)
-
-	// TODO(#397): replace all of this code which eventually gets the
-	// systemEnvInjector with informers once service-binding creation is server
-	// side.
-	config, err := rest.InClusterConfig()
-	if err != nil {
-		logger.Fatalf("Error getting config: %s", err.Error())
-	}
-	kubeClient, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		logger.Fatalf("Error building kubernetes clientset: %s", err.Error())
-	}
-	svccatClient, err := svccatcv1beta1.NewForConfig(config)
-	if err != nil {
-		logger.Fatalf("Error building service-catalog client: %s", err.Error())
-	}
-	secretsClient := secrets.NewClient(kubeClient)
-	bindingsClient := servicebindings.NewClient(svccatClient, secretsClient)
-	systemEnvInjector := systemenvinjector.NewSystemEnvInjector(bindingsClient)
+	pvcInformer := pvcinformer.Get(ctx)
 
 	// Create reconciler
 	c := &Reconciler{
@@ -78,8 +57,11 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		sourceLister:          sourceInformer.Lister(),
 		appLister:             appInformer.Lister(),
 		spaceLister:           spaceInformer.Lister(),
-		systemEnvInjector:     systemEnvInjector,
+		systemEnvInjector:     reconciler.NewSystemEnvInjector(logger),
 		routeLister:           routeInformer.Lister(),
+		domainLister:          domainInformer.Lister(),
+		pvcLister:             pvcInformer.Lister(),
+		imageCollector:        newImageCollector(deleteRemoteImage, logger),
 	}
 
 	impl := controller.NewImpl(c, logger, "Apps")
@@ -94,6 +76,22 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	// Staged Sources aren't controlled by their App so the App is looked up
+	// by name to adopt and garbage collect them.
+	sourceInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		source, ok := obj.(*v1alpha1.Source)
+		if !ok || source.Labels[v1alpha1.ComponentLabel] != v1alpha1.StageComponent {
+			return
+		}
+
+		app, err := c.appLister.Apps(source.Namespace).Get(source.Labels[v1alpha1.NameLabel])
+		if err != nil {
+			return
+		}
+
+		impl.Enqueue(app)
+	}))
+
 	knativeServiceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("App")),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
@@ -104,5 +102,28 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	pvcInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("App")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Routes aren't owned by the Apps bound to them so the Apps are looked up
+	// by name when a Route's status changes.
+	routeInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		route, ok := obj.(*v1alpha1.Route)
+		if !ok {
+			return
+		}
+
+		for _, appName := range route.Spec.AppNames {
+			app, err := c.appLister.Apps(route.Namespace).Get(appName)
+			if err != nil {
+				continue
+			}
+
+			impl.Enqueue(app)
+		}
+	}))
+
 	return impl
 }
@@ -0,0 +1,171 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ImageDeleter removes an image from its container registry unless the
+// manifest is still referenced by one of the in use images.
+type ImageDeleter func(image string, inUse sets.String) error
+
+// deleteRemoteImage removes the image from its container registry using the
+// credentials available to the controller. Tagged images have their tag
+// removed before the manifest so registries that refuse to delete tagged
+// manifests can still clean them up. The manifest is kept if an in use image
+// refers to it by digest, such as a deployed or rolled back image.
+func deleteRemoteImage(image string, inUse sets.String) error {
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	auth, err := authn.DefaultKeychain.Resolve(ref.Context().Registry)
+	if err != nil {
+		return err
+	}
+
+	tag, ok := ref.(name.Tag)
+	if !ok {
+		if digestInUse(ref.Name(), inUse) {
+			return nil
+		}
+
+		return remote.Delete(ref, auth, http.DefaultTransport)
+	}
+
+	img, err := remote.Image(tag, remote.WithAuth(auth))
+	if err != nil {
+		return err
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return err
+	}
+
+	digestRef, err := name.NewDigest(fmt.Sprintf("%s@%s", tag.Context(), digest), name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	if err := remote.Delete(tag, auth, http.DefaultTransport); err != nil {
+		return err
+	}
+
+	if digestInUse(digestRef.Name(), inUse) {
+		return nil
+	}
+
+	return remote.Delete(digestRef, auth, http.DefaultTransport)
+}
+
+// digestInUse returns true if one of the in use images refers to the digest
+// reference. References are parsed so equivalent names compare equal.
+func digestInUse(digestName string, inUse sets.String) bool {
+	for _, image := range inUse.List() {
+		ref, err := name.NewDigest(image, name.WeakValidation)
+		if err != nil {
+			// not referenced by digest
+			continue
+		}
+
+		if ref.Name() == digestName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isImageNotFound returns true if the registry reported that the image
+// doesn't exist.
+func isImageNotFound(err error) bool {
+	terr, ok := err.(*transport.Error)
+	if !ok {
+		return false
+	}
+
+	for _, diagnostic := range terr.Errors {
+		switch diagnostic.Code {
+		case transport.ManifestUnknownErrorCode, transport.NameUnknownErrorCode:
+			return true
+		}
+	}
+
+	return false
+}
+
+// imageCollector deletes images from container registries in the background
+// so slow registries don't block reconciliation.
+type imageCollector struct {
+	deleteImage ImageDeleter
+	logger      *zap.SugaredLogger
+
+	mu       sync.Mutex
+	inFlight sets.String
+}
+
+func newImageCollector(deleteImage ImageDeleter, logger *zap.SugaredLogger) *imageCollector {
+	return &imageCollector{
+		deleteImage: deleteImage,
+		logger:      logger,
+		inFlight:    sets.NewString(),
+	}
+}
+
+// Collect deletes the images in the background and calls done once they're
+// all gone. Manifests referenced by the in use images are kept. done isn't
+// called if any image fails to be deleted so the caller can keep the objects
+// referencing the images and retry later. Only one collection runs per key at
+// a time, calls made while one is running are ignored.
+func (c *imageCollector) Collect(key string, images, inUse []string, done func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.inFlight.Has(key) {
+		return
+	}
+	c.inFlight.Insert(key)
+
+	inUseSet := sets.NewString(inUse...)
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.inFlight.Delete(key)
+		}()
+
+		for _, image := range images {
+			c.logger.Infof("Garbage collecting image %s...", image)
+			if err := c.deleteImage(image, inUseSet); err != nil && !isImageNotFound(err) {
+				c.logger.Warnf("Failed to delete image %s: %s", image, err)
+				return
+			}
+		}
+
+		done()
+	}()
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	kflisters "github.com/google/kf/pkg/client/listers/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/algorithms"
 	"github.com/google/kf/pkg/kf/systemenvinjector"
 	"github.com/google/kf/pkg/reconciler"
 	"github.com/google/kf/pkg/reconciler/app/resources"
@@ -34,6 +35,8 @@ import (
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmp"
@@ -49,7 +52,10 @@ type Reconciler struct {
 	appLister             kflisters.AppLister
 	spaceLister           kflisters.SpaceLister
 	routeLister           kflisters.RouteLister
+	domainLister          kflisters.DomainLister
+	pvcLister             corev1listers.PersistentVolumeClaimLister
 	systemEnvInjector     systemenvinjector.SystemEnvInjectorInterface
+	imageCollector        *imageCollector
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -116,6 +122,32 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 	}
 	app.Status.MarkSpaceHealthy()
 
+	// reconcile build cache
+	if app.Spec.BuildCache != nil {
+		r.Logger.Info("reconciling build cache")
+		condition := app.Status.BuildCacheCondition()
+		desired, err := resources.MakeBuildCache(app)
+		if err != nil {
+			return condition.MarkTemplateError(err)
+		}
+
+		actual, err := r.pvcLister.PersistentVolumeClaims(desired.Namespace).Get(desired.Name)
+		if apierrs.IsNotFound(err) {
+			// The cache doesn't exist or was cleared, make a new one.
+			if _, err := r.KubeClientSet.CoreV1().PersistentVolumeClaims(desired.Namespace).Create(desired); err != nil {
+				return condition.MarkReconciliationError("creating", err)
+			}
+		} else if err != nil {
+			return condition.MarkReconciliationError("getting latest", err)
+		} else if !metav1.IsControlledBy(actual, app) {
+			return condition.MarkChildNotOwned(desired.Name)
+		} else if actual.GetDeletionTimestamp() != nil {
+			// The cache is being cleared, it can't be used until it's
+			// recreated.
+			return condition.MarkReconciliationError("clearing", fmt.Errorf("%s is still being deleted", desired.Name))
+		}
+	}
+
 	// reconcile source
 	{
 		r.Logger.Info("reconciling Source")
@@ -140,6 +172,8 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 
 		app.Status.PropagateSourceStatus(actual)
 
+		r.gcSources(app, space)
+
 		if condition.IsPending() {
 			r.Logger.Info("Waiting for source; exiting early")
 			return nil
@@ -187,6 +221,34 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 		}
 
 		app.Status.PropagateKnativeServiceStatus(actual)
+
+		// Record the deployment in the App's history once Knative has rolled
+		// out the latest template.
+		if !app.Spec.Instances.Stopped &&
+			actual.Status.ObservedGeneration == actual.Generation &&
+			actual.Status.LatestReadyRevisionName != "" &&
+			actual.Status.LatestReadyRevisionName == actual.Status.LatestCreatedRevisionName &&
+			actual.Status.IsReady() {
+			// Knative resolves the image to a digest when it creates a
+			// Revision, that's what's running. The deployment is recorded
+			// once the informer has seen the Revision so the history is
+			// consistent.
+			knativeRevision, err := r.knativeRevisionLister.
+				Revisions(app.Namespace).
+				Get(actual.Status.LatestReadyRevisionName)
+			switch {
+			case apierrs.IsNotFound(err):
+			case err != nil:
+				return condition.MarkReconciliationError("getting latest revision", err)
+			default:
+				revision, err := resources.MakeAppRevision(app, knativeRevision.Status.ImageDigest, metav1.Now())
+				if err != nil {
+					return condition.MarkTemplateError(err)
+				}
+
+				app.Status.RecordRevision(revision)
+			}
+		}
 	}
 
 	// Route Reconciler
@@ -215,6 +277,26 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 
 			actualRoutes = append(actualRoutes, actual)
 		}
+
+		app.Status.PropagateRouteStatus(app.Name, actualRoutes)
+
+		// Point the URL at the App's first public route so it reflects the
+		// scheme the route is served with. Internal routes can't be reached
+		// from outside the cluster so they're skipped.
+		for _, actual := range actualRoutes {
+			route := actual.Spec.RouteSpecFields
+			if space.Spec.Execution.IsInternalDomain(route.Domain) {
+				continue
+			}
+
+			https, err := r.servesHTTPS(space, route.Domain)
+			if err != nil {
+				return condition.MarkReconciliationError("getting domain", err)
+			}
+
+			app.Status.PropagateRouteURL(route, https)
+			break
+		}
 	}
 
 	// Making it to the bottom of the reconciler means we've synchronized.
@@ -223,7 +305,26 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 	return r.gcRevisions(ctx, app)
 }
 
-func (r *Reconciler) latestSource(app *v1alpha1.App) (*v1alpha1.Source, error) {
+// servesHTTPS returns true if the space or the cluster has TLS configured for
+// the domain.
+func (r *Reconciler) servesHTTPS(space *v1alpha1.Space, domain string) (bool, error) {
+	if space.Spec.Execution.DomainTLS(domain) != nil {
+		return true, nil
+	}
+
+	clusterDomain, err := r.domainLister.Get(domain)
+	switch {
+	case apierrs.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	default:
+		return clusterDomain.Spec.TLS != nil, nil
+	}
+}
+
+// listSources returns all the Sources created for the App.
+func (r *Reconciler) listSources(app *v1alpha1.App) ([]v1alpha1.Source, error) {
 	// NOTE: this code polls the Kubernetes cluster directly rather than the
 	// cache to prevent multiple builds from kicking off.
 	selector := resources.MakeSourceLabels(app)
@@ -233,7 +334,39 @@ func (r *Reconciler) latestSource(app *v1alpha1.App) (*v1alpha1.Source, error) {
 		return nil, err
 	}
 
-	items := list.Items
+	return list.Items, nil
+}
+
+// listStagedSources returns the Sources created to stage the App, they're
+// adopted by the App if they haven't been already.
+func (r *Reconciler) listStagedSources(app *v1alpha1.App) ([]v1alpha1.Source, error) {
+	selector := resources.MakeStagedSourceLabels(app)
+	listOps := metav1.ListOptions{LabelSelector: labels.Set(selector).String()}
+	sourceClient := r.KfClientSet.KfV1alpha1().Sources(app.Namespace)
+	list, err := sourceClient.List(listOps)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list.Items {
+		source := &list.Items[i]
+		if !resources.AdoptStagedSource(app, source) {
+			continue
+		}
+
+		if _, err := sourceClient.Update(source); err != nil {
+			r.Logger.Warnf("Failed to adopt staged Source %s: %s", source.Name, err)
+		}
+	}
+
+	return list.Items, nil
+}
+
+func (r *Reconciler) latestSource(app *v1alpha1.App) (*v1alpha1.Source, error) {
+	items, err := r.listSources(app)
+	if err != nil {
+		return nil, err
+	}
 
 	// sort descending
 	sort.Slice(items, func(i int, j int) bool {
@@ -247,6 +380,77 @@ func (r *Reconciler) latestSource(app *v1alpha1.App) (*v1alpha1.Source, error) {
 	return nil, apierrs.NewNotFound(v1alpha1.Resource("sources"), fmt.Sprintf("source for %s", app.Name))
 }
 
+// gcSources deletes the App's Sources that fall outside of the space's
+// retention policy, the Builds they own are deleted with them. Sources that
+// stage the App are collected separately from the ones that deploy it. If the
+// policy allows it, the images built for the Sources are removed from the
+// registry in the background and the Sources are only deleted once their
+// images are gone so failed deletions can be retried. Failures are logged rather than
+// returned so they don't block the App from being deployed, collection is
+// retried the next time the App is reconciled.
+func (r *Reconciler) gcSources(app *v1alpha1.App, space *v1alpha1.Space) {
+	retention := space.Spec.BuildpackBuild.Retention
+
+	sources, err := r.listSources(app)
+	if err != nil {
+		r.Logger.Warnf("Failed to list Sources for garbage collection: %s", err)
+		return
+	}
+
+	staged, err := r.listStagedSources(app)
+	if err != nil {
+		r.Logger.Warnf("Failed to list staged Sources for garbage collection: %s", err)
+		return
+	}
+
+	expired := append(
+		resources.ExpiredSources(sources, retention),
+		resources.ExpiredSources(staged, retention)...,
+	)
+	if len(expired) == 0 {
+		return
+	}
+
+	if !retention.DeleteImages {
+		r.deleteSources(app.Namespace, expired)
+		return
+	}
+
+	expiredNames := sets.NewString()
+	for _, source := range expired {
+		expiredNames.Insert(source.Name)
+	}
+
+	var kept []v1alpha1.Source
+	for _, source := range append(sources, staged...) {
+		if !expiredNames.Has(source.Name) {
+			kept = append(kept, source)
+		}
+	}
+
+	images := resources.ExpiredImages(app, expired, kept)
+	if len(images) == 0 {
+		r.deleteSources(app.Namespace, expired)
+		return
+	}
+
+	namespace := app.Namespace
+	inUse := resources.InUseImages(app, kept)
+	r.imageCollector.Collect(namespace+"/"+app.Name, images, inUse, func() {
+		r.deleteSources(namespace, expired)
+	})
+}
+
+func (r *Reconciler) deleteSources(namespace string, sources []v1alpha1.Source) {
+	sourceClient := r.KfClientSet.KfV1alpha1().Sources(namespace)
+	for _, source := range sources {
+		r.Logger.Infof("Garbage collecting Source %s...", source.Name)
+		if err := sourceClient.Delete(source.Name, &metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			r.Logger.Warnf("Failed to delete Source %s: %s", source.Name, err)
+		}
+	}
+}
+
 func (*Reconciler) sourcesAreSemanticallyEqual(desired, actual *v1alpha1.Source) bool {
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
 	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
@@ -277,6 +481,17 @@ func (r *Reconciler) reconcileKnativeService(desired, actual *serving.Service) (
 }
 
 func (r *Reconciler) reconcileRoute(desired, actual *v1alpha1.Route) (*v1alpha1.Route, error) {
+	// Other Apps may be bound to the same Route, keep them, the traffic
+	// weights they were given and the Route's redirect setting.
+	desired = desired.DeepCopy()
+	desired.Spec.AppNames = []string(algorithms.Dedupe(
+		algorithms.Strings(actual.Spec.AppNames).Clone().Append(
+			algorithms.Strings(desired.Spec.AppNames),
+		),
+	).(algorithms.Strings))
+	desired.Spec.AppWeights = actual.Spec.AppWeights
+	desired.Spec.HTTPSRedirect = actual.Spec.HTTPSRedirect
+
 	// Check for differences, if none we don't need to reconcile.
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
 	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
@@ -0,0 +1,59 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// MakeBuildCacheLabels creates labels that can be used to tie a build cache to
+// an App.
+func MakeBuildCacheLabels(app *v1alpha1.App) map[string]string {
+	return app.ComponentLabels("build-cache")
+}
+
+// MakeBuildCache creates the PersistentVolumeClaim that caches the buildpack
+// layers of an App between builds.
+func MakeBuildCache(app *v1alpha1.App) (*corev1.PersistentVolumeClaim, error) {
+	cache := app.Spec.BuildCache.DeepCopy()
+	cache.SetDefaults(context.Background())
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.BuildCacheName(),
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(app),
+			},
+			Labels: resources.UnionMaps(app.GetLabels(), MakeBuildCacheLabels(app)),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: cache.Size,
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMakeBuildCache(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.Namespace = "my-namespace"
+	app.Spec.BuildCache = &v1alpha1.AppSpecBuildCache{}
+
+	claim, err := MakeBuildCache(app)
+	testutil.AssertNil(t, "err", err)
+
+	testutil.AssertEqual(t, "name", "my-app-build-cache", claim.Name)
+	testutil.AssertEqual(t, "namespace", "my-namespace", claim.Namespace)
+	testutil.AssertEqual(t, "labels", MakeBuildCacheLabels(app), claim.Labels)
+	testutil.AssertEqual(t, "controlled by app", true, metav1.IsControlledBy(claim, app))
+	testutil.AssertEqual(t, "access modes", []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, claim.Spec.AccessModes)
+
+	size := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	testutil.AssertEqual(t, "size", v1alpha1.DefaultBuildCacheSize.String(), size.String())
+}
+
+func TestMakeSource_buildCache(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		cache      *v1alpha1.AppSpecBuildCache
+		dockerfile bool
+		wantClaim  string
+	}{
+		"no cache": {},
+		"cache": {
+			cache:     &v1alpha1.AppSpecBuildCache{Size: resource.MustParse("1Gi")},
+			wantClaim: "my-app-build-cache",
+		},
+		"cache skipped": {
+			cache: &v1alpha1.AppSpecBuildCache{Size: resource.MustParse("1Gi"), Skip: true},
+		},
+		"dockerfile build": {
+			cache:      &v1alpha1.AppSpecBuildCache{Size: resource.MustParse("1Gi")},
+			dockerfile: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			app := &v1alpha1.App{}
+			app.Name = "my-app"
+			app.Spec.BuildCache = tc.cache
+			if tc.dockerfile {
+				app.Spec.Source.Dockerfile.Source = "some-source"
+			} else {
+				app.Spec.Source.BuildpackBuild.Source = "some-source"
+			}
+
+			space := &v1alpha1.Space{}
+			source, err := MakeSource(app, space)
+			testutil.AssertNil(t, "err", err)
+
+			testutil.AssertEqual(t, "cache claim", tc.wantClaim, source.Spec.BuildpackBuild.CacheClaimName)
+		})
+	}
+}
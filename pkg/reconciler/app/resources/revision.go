@@ -0,0 +1,59 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MakeAppRevision creates a record of the App's current deployment. The
+// imageDigest is the image resolved by Knative when the deployment was
+// created. It's recorded instead of the App's image so rolling back deploys
+// the same bits even if the App's image tag has moved since.
+func MakeAppRevision(app *v1alpha1.App, imageDigest string, deployedAt metav1.Time) (v1alpha1.AppRevision, error) {
+	templateHash, err := TemplateHash(app)
+	if err != nil {
+		return v1alpha1.AppRevision{}, err
+	}
+
+	image := imageDigest
+	if image == "" {
+		image = app.Status.Image
+	}
+
+	return v1alpha1.AppRevision{
+		SourceName:   app.Status.LatestReadySourceName,
+		Image:        image,
+		TemplateHash: templateHash,
+		DeployedAt:   deployedAt,
+		DeployedBy:   app.Annotations[v1alpha1.LastModifierAnnotation],
+	}, nil
+}
+
+// TemplateHash creates a hash of the App's template, including its
+// environment variables, so changes between deployments can be detected.
+func TemplateHash(app *v1alpha1.App) (string, error) {
+	template, err := json.Marshal(app.Spec.Template.Spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash template: %s", err)
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(template))[:16], nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMakeAppRevision(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{}
+	app.Annotations = map[string]string{v1alpha1.LastModifierAnnotation: "user@example.com"}
+	app.Status.LatestReadySourceName = "some-source"
+	app.Status.Image = "some-image"
+
+	deployedAt := metav1.NewTime(time.Unix(1234, 0))
+	revision, err := MakeAppRevision(app, "some-image@sha256:abc", deployedAt)
+	testutil.AssertNil(t, "err", err)
+
+	expectedHash, err := TemplateHash(app)
+	testutil.AssertNil(t, "err", err)
+
+	testutil.AssertEqual(t, "revision", v1alpha1.AppRevision{
+		SourceName:   "some-source",
+		Image:        "some-image@sha256:abc",
+		TemplateHash: expectedHash,
+		DeployedAt:   deployedAt,
+		DeployedBy:   "user@example.com",
+	}, revision)
+
+	// The App's image is used if Knative hasn't resolved a digest.
+	revision, err = MakeAppRevision(app, "", deployedAt)
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "image", "some-image", revision.Image)
+}
+
+func TestTemplateHash(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{}
+	app.Spec.Template.Spec.Containers = []corev1.Container{{}}
+
+	original, err := TemplateHash(app)
+	testutil.AssertNil(t, "err", err)
+
+	same, err := TemplateHash(app.DeepCopy())
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "stable", original, same)
+
+	app.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "FOO", Value: "bar"}}
+	changed, err := TemplateHash(app)
+	testutil.AssertNil(t, "err", err)
+	if changed == original {
+		t.Fatal("expected changing the environment to change the hash")
+	}
+}
@@ -28,12 +28,38 @@ func MakeSourceLabels(app *v1alpha1.App) map[string]string {
 	return app.ComponentLabels("build")
 }
 
+// MakeStagedSourceLabels creates labels that select the Sources that stage the
+// App without deploying it.
+func MakeStagedSourceLabels(app *v1alpha1.App) map[string]string {
+	return app.ComponentLabels(v1alpha1.StageComponent)
+}
+
+// AdoptStagedSource adds an owner reference to the App to a staged Source so
+// it's deleted with the App. The App isn't made the Source's controller so
+// the Source isn't deployed. It returns true if the Source was changed.
+func AdoptStagedSource(app *v1alpha1.App, source *v1alpha1.Source) bool {
+	for _, ref := range source.OwnerReferences {
+		if ref.UID == app.UID {
+			return false
+		}
+	}
+
+	ref := *kmeta.NewControllerRef(app)
+	ref.Controller = nil
+	source.OwnerReferences = append(source.OwnerReferences, ref)
+	return true
+}
+
 // MakeSource creates a source for the given application.
 func MakeSource(app *v1alpha1.App, space *v1alpha1.Space) (*v1alpha1.Source, error) {
 	source := app.Spec.Source.DeepCopy()
 
 	source.SetSpaceDefaults(space)
 
+	if cache := app.Spec.BuildCache; cache != nil && !cache.Skip && source.IsBuildpackBuild() {
+		source.BuildpackBuild.CacheClaimName = app.BuildCacheName()
+	}
+
 	return &v1alpha1.Source{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: fmt.Sprintf("%s-", app.Name),
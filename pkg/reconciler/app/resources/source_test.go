@@ -0,0 +1,42 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAdoptStagedSource(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.UID = "my-app-uid"
+
+	source := &v1alpha1.Source{}
+	source.Labels = MakeStagedSourceLabels(app)
+
+	testutil.AssertEqual(t, "adopted", true, AdoptStagedSource(app, source))
+	testutil.AssertEqual(t, "owner refs", 1, len(source.OwnerReferences))
+	testutil.AssertEqual(t, "owner uid", app.UID, source.OwnerReferences[0].UID)
+	testutil.AssertEqual(t, "controlled by app", false, metav1.IsControlledBy(source, app))
+
+	testutil.AssertEqual(t, "adopted again", false, AdoptStagedSource(app, source))
+	testutil.AssertEqual(t, "owner refs after second adoption", 1, len(source.OwnerReferences))
+}
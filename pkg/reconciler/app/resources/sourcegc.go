@@ -0,0 +1,153 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ExpiredSources returns the Sources of an App that fall outside of the
+// retention policy, newest first. The latest Source and Sources that are
+// still building are always kept.
+func ExpiredSources(sources []v1alpha1.Source, retention v1alpha1.SpaceSpecBuildRetention) []v1alpha1.Source {
+	retention.SetDefaults(context.Background())
+	keepSucceeded := int(*retention.Succeeded)
+	keepFailed := int(*retention.Failed)
+
+	sorted := make([]v1alpha1.Source, len(sources))
+	copy(sorted, sources)
+
+	// sort descending
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[j].CreationTimestamp.Before(&sorted[i].CreationTimestamp)
+	})
+
+	var expired []v1alpha1.Source
+	succeeded, failed := 0, 0
+	for i, source := range sorted {
+		if source.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		cond := source.Status.GetCondition(v1alpha1.SourceConditionBuildSucceeded)
+		switch {
+		case cond == nil || cond.Status == corev1.ConditionUnknown:
+			continue
+		case cond.Status == corev1.ConditionTrue:
+			succeeded++
+			if i == 0 || succeeded <= keepSucceeded {
+				continue
+			}
+		default:
+			failed++
+			if i == 0 || failed <= keepFailed {
+				continue
+			}
+		}
+
+		expired = append(expired, source)
+	}
+
+	return expired
+}
+
+// ExpiredImages returns the source and App images built for the expired
+// Sources that can be removed from the container registry. Images still used
+// by the App, its deployment history or the kept Sources are left out, as are
+// the images of the Sources the App was deployed from.
+func ExpiredImages(app *v1alpha1.App, expired, kept []v1alpha1.Source) []string {
+	inUse := make(map[string]bool)
+	for _, image := range InUseImages(app, kept) {
+		inUse[image] = true
+	}
+
+	// Deployed images are recorded by digest, which never matches the tags
+	// the Sources record, so the Sources they came from are kept by name.
+	deployed := map[string]bool{
+		app.Status.LatestCreatedSourceName: true,
+		app.Status.LatestReadySourceName:   true,
+	}
+	for _, revision := range app.Status.Revisions {
+		deployed[revision.SourceName] = true
+	}
+	for _, source := range expired {
+		if !deployed[source.Name] {
+			continue
+		}
+
+		for _, image := range sourceImages(&source) {
+			inUse[image] = true
+		}
+	}
+
+	var images []string
+	seen := make(map[string]bool)
+	for _, source := range expired {
+		for _, image := range sourceImages(&source) {
+			if image == "" || inUse[image] || seen[image] {
+				continue
+			}
+
+			seen[image] = true
+			images = append(images, image)
+		}
+	}
+
+	return images
+}
+
+// InUseImages returns the images referenced by the App, its deployment
+// history and the kept Sources. Deployed images are recorded by digest so
+// tagged images must be resolved before they're compared against these.
+func InUseImages(app *v1alpha1.App, kept []v1alpha1.Source) []string {
+	images := []string{app.Status.Image}
+	for _, revision := range app.Status.Revisions {
+		images = append(images, revision.Image)
+	}
+	for _, source := range kept {
+		images = append(images, source.Spec.ContainerImage.Image, source.Spec.Rebase.Image)
+		if original := source.Spec.OriginalBuildpackBuild(); original != nil {
+			images = append(images, original.Source)
+		}
+		images = append(images, sourceImages(&source)...)
+	}
+
+	var out []string
+	for _, image := range images {
+		if image != "" {
+			out = append(out, image)
+		}
+	}
+
+	return out
+}
+
+// sourceImages returns the images Kf built or uploaded for the Source.
+// Container images are provided by the user so they're never included.
+func sourceImages(source *v1alpha1.Source) []string {
+	if source.Spec.IsContainerBuild() {
+		return nil
+	}
+
+	return []string{
+		source.Spec.BuildpackBuild.Source,
+		source.Spec.Dockerfile.Source,
+		source.Status.Image,
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func makeGCSource(name string, age int, status corev1.ConditionStatus) v1alpha1.Source {
+	source := v1alpha1.Source{}
+	source.Name = name
+	source.CreationTimestamp = metav1.NewTime(time.Unix(0, 0).Add(-time.Duration(age) * time.Minute))
+	source.Spec.BuildpackBuild.Source = "src-" + name
+	source.Status.Image = "app-" + name
+	source.Status.Conditions = []apis.Condition{
+		{Type: v1alpha1.SourceConditionBuildSucceeded, Status: status},
+	}
+	return source
+}
+
+func sourceNames(sources []v1alpha1.Source) []string {
+	var names []string
+	for _, source := range sources {
+		names = append(names, source.Name)
+	}
+	return names
+}
+
+func TestExpiredSources(t *testing.T) {
+	t.Parallel()
+
+	int32Ptr := func(i int32) *int32 { return &i }
+
+	cases := map[string]struct {
+		sources   []v1alpha1.Source
+		retention v1alpha1.SpaceSpecBuildRetention
+		want      []string
+	}{
+		"no sources": {},
+		"within limits": {
+			sources: []v1alpha1.Source{
+				makeGCSource("a", 3, corev1.ConditionTrue),
+				makeGCSource("b", 2, corev1.ConditionFalse),
+				makeGCSource("c", 1, corev1.ConditionTrue),
+			},
+		},
+		"defaults": {
+			sources: []v1alpha1.Source{
+				makeGCSource("s1", 10, corev1.ConditionTrue),
+				makeGCSource("f1", 9, corev1.ConditionFalse),
+				makeGCSource("s2", 8, corev1.ConditionTrue),
+				makeGCSource("f2", 7, corev1.ConditionFalse),
+				makeGCSource("s3", 6, corev1.ConditionTrue),
+				makeGCSource("f3", 5, corev1.ConditionFalse),
+				makeGCSource("s4", 4, corev1.ConditionTrue),
+				makeGCSource("s5", 3, corev1.ConditionTrue),
+				makeGCSource("s6", 2, corev1.ConditionTrue),
+				makeGCSource("s7", 1, corev1.ConditionTrue),
+			},
+			want: []string{"s2", "f1", "s1"},
+		},
+		"keep none": {
+			retention: v1alpha1.SpaceSpecBuildRetention{
+				Succeeded: int32Ptr(0),
+				Failed:    int32Ptr(0),
+			},
+			sources: []v1alpha1.Source{
+				makeGCSource("old", 3, corev1.ConditionTrue),
+				makeGCSource("failed", 2, corev1.ConditionFalse),
+				makeGCSource("latest", 1, corev1.ConditionTrue),
+			},
+			want: []string{"failed", "old"},
+		},
+		"latest is always kept": {
+			retention: v1alpha1.SpaceSpecBuildRetention{
+				Succeeded: int32Ptr(0),
+				Failed:    int32Ptr(0),
+			},
+			sources: []v1alpha1.Source{
+				makeGCSource("latest", 1, corev1.ConditionFalse),
+			},
+		},
+		"building sources are kept": {
+			retention: v1alpha1.SpaceSpecBuildRetention{
+				Succeeded: int32Ptr(1),
+				Failed:    int32Ptr(0),
+			},
+			sources: []v1alpha1.Source{
+				makeGCSource("building", 3, corev1.ConditionUnknown),
+				makeGCSource("old", 2, corev1.ConditionTrue),
+				makeGCSource("latest", 1, corev1.ConditionTrue),
+			},
+			want: []string{"old"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			expired := ExpiredSources(tc.sources, tc.retention)
+			testutil.AssertEqual(t, "expired", tc.want, sourceNames(expired))
+		})
+	}
+}
+
+func TestExpiredImages(t *testing.T) {
+	t.Parallel()
+
+	expired := []v1alpha1.Source{
+		makeGCSource("old", 3, corev1.ConditionTrue),
+		makeGCSource("deployed", 2, corev1.ConditionTrue),
+		makeGCSource("restaged", 4, corev1.ConditionTrue),
+		makeGCSource("container", 5, corev1.ConditionTrue),
+	}
+	// restaging reuses the source image of the Source it copies
+	expired[2].Spec.BuildpackBuild.Source = "src-latest"
+	// container images belong to the user and are never deleted
+	expired[3].Spec.BuildpackBuild = v1alpha1.SourceSpecBuildpackBuild{}
+	expired[3].Spec.ContainerImage.Image = "gcr.io/user/container"
+	expired[3].Status.Image = "gcr.io/user/container"
+
+	kept := []v1alpha1.Source{
+		makeGCSource("latest", 1, corev1.ConditionTrue),
+	}
+
+	app := &v1alpha1.App{}
+	app.Status.Image = "app-latest"
+	app.Status.Revisions = []v1alpha1.AppRevision{
+		{Image: "app-deployed"},
+		{Image: "app-latest"},
+	}
+
+	images := ExpiredImages(app, expired, kept)
+	testutil.AssertEqual(t, "images", []string{"src-old", "app-old", "src-deployed", "app-restaged"}, images)
+}
+
+func TestExpiredImages_rebase(t *testing.T) {
+	t.Parallel()
+
+	expired := []v1alpha1.Source{
+		makeGCSource("built", 2, corev1.ConditionTrue),
+	}
+
+	// the rebase keeps the original build so it can be restaged
+	rebased := makeGCSource("rebased", 1, corev1.ConditionTrue)
+	rebased.Spec.BuildpackBuild = v1alpha1.SourceSpecBuildpackBuild{}
+	rebased.Spec.Rebase = v1alpha1.SourceSpecRebase{
+		Image:          "app-built",
+		BuildpackBuild: expired[0].Spec.BuildpackBuild.DeepCopy(),
+	}
+
+	app := &v1alpha1.App{}
+	app.Status.Image = "app-rebased"
+
+	images := ExpiredImages(app, expired, []v1alpha1.Source{rebased})
+	testutil.AssertEqual(t, "images", []string(nil), images)
+}
+
+func TestExpiredImages_deployedByDigest(t *testing.T) {
+	t.Parallel()
+
+	const digest = "sha256:4bcdf0a5e8b7b2a7bafee1b4d3b1d7b5c3b9bd8a4c2ef6a1a4c0b5c7e8f9d0a1"
+
+	expired := []v1alpha1.Source{
+		makeGCSource("old", 4, corev1.ConditionTrue),
+		makeGCSource("rollback-target", 3, corev1.ConditionTrue),
+		makeGCSource("running", 2, corev1.ConditionTrue),
+	}
+	for i := range expired {
+		expired[i].Status.Image = "gcr.io/my-project/app-" + expired[i].Name
+	}
+
+	kept := []v1alpha1.Source{
+		makeGCSource("latest", 1, corev1.ConditionTrue),
+	}
+
+	// Knative records the images it deployed by digest, so they never match
+	// the tags on the Sources.
+	app := &v1alpha1.App{}
+	app.Status.Image = "gcr.io/my-project/app-running@" + digest
+	app.Status.LatestReadySourceName = "running"
+	app.Status.Revisions = []v1alpha1.AppRevision{
+		{SourceName: "rollback-target", Image: "gcr.io/my-project/app-rollback-target@" + digest},
+		{SourceName: "running", Image: "gcr.io/my-project/app-running@" + digest},
+	}
+
+	images := ExpiredImages(app, expired, kept)
+	testutil.AssertEqual(t, "images", []string{"src-old", "gcr.io/my-project/app-old"}, images)
+}
+
+func TestInUseImages(t *testing.T) {
+	t.Parallel()
+
+	const digest = "sha256:4bcdf0a5e8b7b2a7bafee1b4d3b1d7b5c3b9bd8a4c2ef6a1a4c0b5c7e8f9d0a1"
+
+	// a rollback deploys the digest of an earlier build
+	rollback := makeGCSource("rollback", 1, corev1.ConditionTrue)
+	rollback.Spec.BuildpackBuild = v1alpha1.SourceSpecBuildpackBuild{}
+	rollback.Spec.ContainerImage.Image = "gcr.io/my-project/app-built@" + digest
+	rollback.Status.Image = "gcr.io/my-project/app-built@" + digest
+
+	app := &v1alpha1.App{}
+	app.Status.Image = "gcr.io/my-project/app-built@" + digest
+	app.Status.Revisions = []v1alpha1.AppRevision{
+		{SourceName: "built", Image: "gcr.io/my-project/app-built@" + digest},
+	}
+
+	images := InUseImages(app, []v1alpha1.Source{rollback})
+	testutil.AssertEqual(t, "images", []string{
+		"gcr.io/my-project/app-built@" + digest,
+		"gcr.io/my-project/app-built@" + digest,
+		"gcr.io/my-project/app-built@" + digest,
+	}, images)
+}
@@ -20,10 +20,14 @@ import (
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	appinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/app"
+	domaininformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/domain"
 	routeinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/route"
+	spaceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/space"
 	"github.com/google/kf/pkg/reconciler"
+	gatewayinformer "knative.dev/pkg/client/injection/informers/istio/v1alpha3/gateway"
 	virtualserviceinformer "knative.dev/pkg/client/injection/informers/istio/v1alpha3/virtualservice"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 
 	"knative.dev/pkg/configmap"
@@ -39,13 +43,19 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	vsInformer := virtualserviceinformer.Get(ctx)
 	routeInformer := routeinformer.Get(ctx)
 	appInformer := appinformer.Get(ctx)
+	spaceInformer := spaceinformer.Get(ctx)
+	domainInformer := domaininformer.Get(ctx)
+	gatewayInformer := gatewayinformer.Get(ctx)
 
 	// Create reconciler
 	c := &Reconciler{
 		Base:                 reconciler.NewBase(ctx, "route-controller", cmw),
 		routeLister:          routeInformer.Lister(),
 		appLister:            appInformer.Lister(),
+		spaceLister:          spaceInformer.Lister(),
+		domainLister:         domainInformer.Lister(),
 		virtualServiceLister: vsInformer.Lister(),
+		gatewayLister:        gatewayInformer.Lister(),
 	}
 
 	impl := controller.NewImpl(c, logger, "Routes")
@@ -60,7 +70,76 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	gatewayInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Route")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Domains can switch between internal and public so resync the routes in
+	// a space when it changes.
+	spaceInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		space, ok := obj.(*v1alpha1.Space)
+		if !ok {
+			return
+		}
+
+		routes, err := c.routeLister.Routes(space.Name).List(labels.Everything())
+		if err != nil {
+			c.Logger.Warnf("failed to list routes in space %s: %s", space.Name, err)
+			return
+		}
+
+		for _, route := range routes {
+			impl.Enqueue(route)
+		}
+	}))
+
+	// TLS can be enabled or disabled for a Domain so resync the routes using
+	// it when it changes.
+	domainInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		domain, ok := obj.(*v1alpha1.Domain)
+		if !ok {
+			return
+		}
+
+		routes, err := c.routeLister.List(labels.Everything())
+		if err != nil {
+			c.Logger.Warnf("failed to list routes for domain %s: %s", domain.Name, err)
+			return
+		}
+
+		for _, route := range routes {
+			if route.Spec.Domain == domain.Name {
+				impl.Enqueue(route)
+			}
+		}
+	}))
+
+	// Route readiness depends on the Apps bound to it.
+	enqueueAppRoutes := func(obj interface{}) {
+		app, ok := obj.(*v1alpha1.App)
+		if !ok {
+			return
+		}
+
+		routes, err := c.routeLister.Routes(app.Namespace).List(labels.Everything())
+		if err != nil {
+			c.Logger.Warnf("failed to list routes in space %s: %s", app.Namespace, err)
+			return
+		}
+
+		for _, route := range routes {
+			if route.Spec.HasApp(app.Name) {
+				impl.Enqueue(route)
+			}
+		}
+	}
+
 	appInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueueAppRoutes,
+		UpdateFunc: func(old, new interface{}) {
+			enqueueAppRoutes(new)
+		},
 		DeleteFunc: func(obj interface{}) {
 			start := time.Now()
 			app := obj.(*v1alpha1.App)
@@ -17,6 +17,7 @@ package route
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sort"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 	networking "knative.dev/pkg/apis/istio/v1alpha3"
 	istiolisters "knative.dev/pkg/client/listers/istio/v1alpha3"
@@ -43,7 +45,10 @@ type Reconciler struct {
 	// listers index properties about resources
 	routeLister          kflisters.RouteLister
 	appLister            kflisters.AppLister
+	spaceLister          kflisters.SpaceLister
+	domainLister         kflisters.DomainLister
 	virtualServiceLister istiolisters.VirtualServiceLister
+	gatewayLister        istiolisters.GatewayLister
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -79,8 +84,21 @@ func (r *Reconciler) reconcileRoute(ctx context.Context, namespace, name string,
 	// Don't modify the informers copy
 	toReconcile := original.DeepCopy()
 
-	// Reconcile this copy of the route.
-	return r.ApplyChanges(ctx, toReconcile, deleted, logger)
+	// Reconcile this copy of the route and then write back any status
+	// updates regardless of whether the reconciliation errored out.
+	reconcileErr := r.ApplyChanges(ctx, toReconcile, deleted, logger)
+	if deleted || equality.Semantic.DeepEqual(original.Status, toReconcile.Status) {
+		// If we didn't change anything then don't call updateStatus.
+		// This is important because the copy we loaded from the informer's
+		// cache may be stale and we don't want to overwrite a prior update
+		// to status with this stale state.
+
+	} else if _, uErr := r.updateStatus(toReconcile); uErr != nil {
+		logger.Warnw("Failed to update Route status", zap.Error(uErr))
+		return uErr
+	}
+
+	return reconcileErr
 }
 
 func (r *Reconciler) ReconcileAppDeletion(ctx context.Context, app *v1alpha1.App) error {
@@ -100,6 +118,7 @@ func (r *Reconciler) ReconcileAppDeletion(ctx context.Context, app *v1alpha1.App
 			algorithms.Strings(toReconcile.Spec.AppNames),
 			algorithms.Strings{app.Name},
 		)).(algorithms.Strings))
+		delete(toReconcile.Spec.AppWeights, app.Name)
 
 		// Update Route to not reference service
 		if _, err := r.KfClientSet.
@@ -117,12 +136,38 @@ func (r *Reconciler) ReconcileAppDeletion(ctx context.Context, app *v1alpha1.App
 // status of the Route .
 func (r *Reconciler) ApplyChanges(ctx context.Context, route *v1alpha1.Route, deleted bool, logger *zap.SugaredLogger) error {
 	route.SetDefaults(ctx)
+	route.Status.InitializeConditions()
+
+	exposure, err := r.exposure(route)
+	if err != nil {
+		return err
+	}
+
+	// Check Apps
+	{
+		var apps []*v1alpha1.App
+		var missing []string
+		for _, appName := range route.Spec.AppNames {
+			app, err := r.appLister.Apps(route.GetNamespace()).Get(appName)
+			switch {
+			case apierrs.IsNotFound(err):
+				missing = append(missing, appName)
+			case err != nil:
+				return err
+			default:
+				apps = append(apps, app)
+			}
+		}
+
+		route.Status.PropagateAppsStatus(apps, missing)
+	}
 
 	// Sync VirtualService
 	{
-		desired, err := resources.MakeVirtualService(route)
+		condition := route.Status.VirtualServiceCondition()
+		desired, err := resources.MakeVirtualService(route, exposure)
 		if err != nil {
-			return err
+			return condition.MarkTemplateError(err)
 		}
 
 		actual, err := r.virtualServiceLister.VirtualServices(desired.GetNamespace()).Get(desired.Name)
@@ -130,18 +175,120 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, route *v1alpha1.Route, de
 			// VirtualService doesn't exist, make one.
 			actual, err = r.SharedClientSet.Networking().VirtualServices(desired.GetNamespace()).Create(desired)
 			if err != nil {
-				return err
+				return condition.MarkReconciliationError("creating", err)
 			}
 		} else if err != nil {
-			return err
+			return condition.MarkReconciliationError("getting latest", err)
+		} else if space := actual.Annotations["space"]; space != route.GetNamespace() {
+			// The hostname and domain are already in use by Routes in
+			// another space.
+			route.Status.MarkHostnameReservedByOtherSpace(route.Spec.Hostname, route.Spec.Domain, space)
+			return fmt.Errorf("the host %s and domain %s are reserved for space %q", route.Spec.Hostname, route.Spec.Domain, space)
 		} else if actual, err = r.reconcile(desired, actual, deleted, logger); err != nil {
-			return err
+			return condition.MarkReconciliationError("updating existing", err)
 		}
+
+		route.Status.MarkHostnameReserved()
+		route.Status.MarkVirtualServiceReady()
 	}
 
+	// Sync Gateway
+	{
+		name := v1alpha1.GenerateName(route.Spec.Hostname, route.Spec.Domain)
+		actual, getErr := r.gatewayLister.Gateways(v1alpha1.KfNamespace).Get(name)
+		switch {
+		case exposure.TLS == nil && errors.IsNotFound(getErr):
+			// HTTPS isn't enabled for the domain, nothing to do.
+
+		case getErr != nil && !errors.IsNotFound(getErr):
+			return getErr
+
+		case exposure.TLS == nil:
+			// HTTPS was disabled for the domain, clean up the Gateway.
+			logger.Infof("Deleting Gateway %s because the domain no longer has TLS", name)
+			if err := r.SharedClientSet.Networking().Gateways(v1alpha1.KfNamespace).Delete(name, nil); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+
+		default:
+			desired, err := resources.MakeGateway(route, exposure)
+			if err != nil {
+				return err
+			}
+
+			if errors.IsNotFound(getErr) {
+				if _, err := r.SharedClientSet.Networking().Gateways(desired.GetNamespace()).Create(desired); err != nil {
+					return err
+				}
+			} else if _, err := r.reconcileGateway(desired, actual, deleted); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Making it to the bottom of the reconciler means we've synchronized.
+	route.Status.ObservedGeneration = route.Generation
+
 	return nil
 }
 
+// exposure determines how the Route is reachable based on the configuration
+// of its space and domain.
+func (r *Reconciler) exposure(route *v1alpha1.Route) (resources.Exposure, error) {
+	var exposure resources.Exposure
+
+	space, err := r.spaceLister.Get(route.GetNamespace())
+	switch {
+	case apierrs.IsNotFound(err):
+		// Namespaces that aren't spaces don't have domain configuration.
+	case err != nil:
+		return exposure, err
+	default:
+		exposure.Internal = space.Spec.Execution.IsInternalDomain(route.Spec.Domain)
+		exposure.TLS = space.Spec.Execution.DomainTLS(route.Spec.Domain)
+	}
+
+	if exposure.Internal {
+		// Internal routes never leave the mesh so TLS doesn't apply.
+		exposure.TLS = nil
+		return exposure, nil
+	}
+
+	// The space's configuration takes precedence over the cluster's.
+	if exposure.TLS == nil {
+		domain, err := r.domainLister.Get(route.Spec.Domain)
+		switch {
+		case apierrs.IsNotFound(err):
+			// Domains don't need to be registered with the cluster.
+		case err != nil:
+			return exposure, err
+		default:
+			exposure.TLS = domain.Spec.TLS
+		}
+	}
+
+	if exposure.TLS == nil {
+		return exposure, nil
+	}
+
+	// Routes with different paths share the host, so it's redirected if any
+	// of them asks for it.
+	routes, err := r.routeLister.Routes(route.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return exposure, err
+	}
+
+	for _, other := range routes {
+		if other.Spec.Hostname == route.Spec.Hostname &&
+			other.Spec.Domain == route.Spec.Domain &&
+			other.Spec.HTTPSRedirect {
+			exposure.HTTPSRedirect = true
+		}
+	}
+
+	return exposure, nil
+}
+
 func (r *Reconciler) reconcile(desired, actual *networking.VirtualService, deleted bool, logger *zap.SugaredLogger) (*networking.VirtualService, error) {
 	// Check for differences, if none we don't need to reconcile.
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
@@ -162,6 +309,10 @@ func (r *Reconciler) reconcile(desired, actual *networking.VirtualService, delet
 	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
 	existing.ObjectMeta.Annotations = desired.ObjectMeta.Annotations
 
+	// Routes sharing a VirtualService have the same domain so they agree on
+	// the gateways.
+	existing.Spec.Gateways = desired.Spec.Gateways
+
 	if deleted {
 		existing.OwnerReferences = algorithms.Delete(
 			v1alpha1.OwnerReferences(existing.OwnerReferences),
@@ -192,3 +343,59 @@ func (r *Reconciler) reconcile(desired, actual *networking.VirtualService, delet
 		VirtualServices(existing.GetNamespace()).
 		Update(existing)
 }
+
+func (r *Reconciler) reconcileGateway(desired, actual *networking.Gateway, deleted bool) (*networking.Gateway, error) {
+	existing := actual.DeepCopy()
+
+	if deleted {
+		existing.OwnerReferences = algorithms.Delete(
+			v1alpha1.OwnerReferences(existing.OwnerReferences),
+			v1alpha1.OwnerReferences(desired.OwnerReferences),
+		).(v1alpha1.OwnerReferences)
+	} else {
+		existing.OwnerReferences = algorithms.Merge(
+			v1alpha1.OwnerReferences(existing.OwnerReferences),
+			v1alpha1.OwnerReferences(desired.OwnerReferences),
+		).(v1alpha1.OwnerReferences)
+	}
+
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(existing.OwnerReferences, actual.OwnerReferences)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	if _, err := kmp.SafeDiff(desired.Spec, actual.Spec); err != nil {
+		return nil, fmt.Errorf("failed to diff Gateway: %v", err)
+	}
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.ObjectMeta.Annotations = desired.ObjectMeta.Annotations
+	existing.Spec = desired.Spec
+
+	return r.SharedClientSet.
+		Networking().
+		Gateways(existing.GetNamespace()).
+		Update(existing)
+}
+
+func (r *Reconciler) updateStatus(desired *v1alpha1.Route) (*v1alpha1.Route, error) {
+	actual, err := r.routeLister.Routes(desired.GetNamespace()).Get(desired.Name)
+	if err != nil {
+		return nil, err
+	}
+	// If there's nothing to update, just return.
+	if reflect.DeepEqual(actual.Status, desired.Status) {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Status = desired.Status
+
+	return r.KfClientSet.KfV1alpha1().Routes(existing.GetNamespace()).UpdateStatus(existing)
+}
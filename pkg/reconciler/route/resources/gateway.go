@@ -0,0 +1,82 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
+)
+
+// GatewayFQDN returns the fully qualified name of the Gateway that serves a
+// host and domain over HTTPS. VirtualServices in other namespaces can refer
+// to it by this name.
+func GatewayFQDN(hostname, domain string) string {
+	return v1alpha1.GenerateName(hostname, domain) + "." + v1alpha1.KfNamespace + ".svc.cluster.local"
+}
+
+// MakeGateway creates a Gateway that terminates TLS for a Route's host on the
+// Istio ingress gateway. The Gateway has the same name and owners as the
+// Route's VirtualService. The TLS secret is referenced using SDS, so it must
+// live in the ingress gateway's namespace.
+func MakeGateway(route *v1alpha1.Route, exposure Exposure) (*networking.Gateway, error) {
+	vs, err := MakeVirtualService(route, exposure)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := []networking.Server{
+		{
+			Port: networking.Port{
+				Number:   443,
+				Name:     "https",
+				Protocol: networking.ProtocolHTTPS,
+			},
+			Hosts: vs.Spec.Hosts,
+			TLS: &networking.TLSOptions{
+				Mode:           networking.TLSModeSimple,
+				CredentialName: exposure.TLS.SecretName,
+			},
+		},
+	}
+
+	if exposure.HTTPSRedirect {
+		servers = append(servers, networking.Server{
+			Port: networking.Port{
+				Number:   80,
+				Name:     "http",
+				Protocol: networking.ProtocolHTTP,
+			},
+			Hosts: vs.Spec.Hosts,
+			TLS: &networking.TLSOptions{
+				HTTPSRedirect: true,
+			},
+		})
+	}
+
+	return &networking.Gateway{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "Gateway",
+		},
+		ObjectMeta: vs.ObjectMeta,
+		Spec: networking.GatewaySpec{
+			Selector: map[string]string{
+				"istio": "ingressgateway",
+			},
+			Servers: servers,
+		},
+	}, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/google/kf/pkg/reconciler/route/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
+)
+
+func TestMakeGateway(t *testing.T) {
+	t.Parallel()
+
+	route := &v1alpha1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "some-namespace",
+		},
+		Spec: v1alpha1.RouteSpec{
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Hostname: "some-host",
+				Domain:   "example.com",
+			},
+		},
+	}
+
+	httpsServer := networking.Server{
+		Port: networking.Port{
+			Number:   443,
+			Name:     "https",
+			Protocol: networking.ProtocolHTTPS,
+		},
+		Hosts: []string{"some-host.example.com"},
+		TLS: &networking.TLSOptions{
+			Mode:           networking.TLSModeSimple,
+			CredentialName: "example-com-tls",
+		},
+	}
+
+	for tn, tc := range map[string]struct {
+		Exposure resources.Exposure
+		Assert   func(t *testing.T, g *networking.Gateway, err error)
+	}{
+		"proper Meta": {
+			Exposure: resources.Exposure{
+				TLS: &v1alpha1.DomainTLS{SecretName: "example-com-tls"},
+			},
+			Assert: func(t *testing.T, g *networking.Gateway, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "TypeMeta", metav1.TypeMeta{
+					APIVersion: "networking.istio.io/v1alpha3",
+					Kind:       "Gateway",
+				}, g.TypeMeta)
+				testutil.AssertEqual(t, "Name", v1alpha1.GenerateName("some-host", "example.com"), g.Name)
+				testutil.AssertEqual(t, "Namespace", v1alpha1.KfNamespace, g.Namespace)
+				testutil.AssertEqual(t, "space annotation", "some-namespace", g.Annotations["space"])
+				testutil.AssertEqual(t, "Selector", map[string]string{"istio": "ingressgateway"}, g.Spec.Selector)
+			},
+		},
+		"HTTPS only": {
+			Exposure: resources.Exposure{
+				TLS: &v1alpha1.DomainTLS{SecretName: "example-com-tls"},
+			},
+			Assert: func(t *testing.T, g *networking.Gateway, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Servers", []networking.Server{httpsServer}, g.Spec.Servers)
+			},
+		},
+		"HTTPS redirect": {
+			Exposure: resources.Exposure{
+				TLS:           &v1alpha1.DomainTLS{SecretName: "example-com-tls"},
+				HTTPSRedirect: true,
+			},
+			Assert: func(t *testing.T, g *networking.Gateway, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Servers", []networking.Server{
+					httpsServer,
+					{
+						Port: networking.Port{
+							Number:   80,
+							Name:     "http",
+							Protocol: networking.ProtocolHTTP,
+						},
+						Hosts: []string{"some-host.example.com"},
+						TLS: &networking.TLSOptions{
+							HTTPSRedirect: true,
+						},
+					},
+				}, g.Spec.Servers)
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			g, err := resources.MakeGateway(route, tc.Exposure)
+			tc.Assert(t, g, err)
+		})
+	}
+}
@@ -33,16 +33,36 @@ const (
 	ManagedByLabel        = "app.kubernetes.io/managed-by"
 	KnativeIngressGateway = "knative-ingress-gateway.knative-serving.svc.cluster.local"
 	GatewayHost           = "istio-ingressgateway.istio-system.svc.cluster.local"
+
+	// MeshGateway is the reserved Istio gateway name that applies a
+	// VirtualService to the sidecars in the mesh rather than a gateway.
+	MeshGateway = "mesh"
 )
 
-// MakeVirtualService creates a VirtualService from a Route object.
-func MakeVirtualService(route *v1alpha1.Route) (*networking.VirtualService, error) {
+// Exposure describes how a Route is reachable.
+type Exposure struct {
+	// Internal is set if the Route is only reachable from inside the mesh.
+	Internal bool
+
+	// TLS holds the certificate used to serve the Route over HTTPS. HTTPS is
+	// disabled if it's nil.
+	TLS *v1alpha1.DomainTLS
+
+	// HTTPSRedirect is set if plain HTTP requests should be redirected to
+	// HTTPS. It's ignored if TLS isn't configured.
+	HTTPSRedirect bool
+}
+
+// MakeVirtualService creates a VirtualService from a Route object. Routes on
+// internal domains are only reachable from inside the mesh so they aren't
+// attached to the ingress gateway.
+func MakeVirtualService(route *v1alpha1.Route, exposure Exposure) (*networking.VirtualService, error) {
 	hostDomain := route.Spec.Domain
 	if route.Spec.Hostname != "" {
 		hostDomain = route.Spec.Hostname + "." + route.Spec.Domain
 	}
 
-	httpRoute, err := buildHTTPRoute(route)
+	httpRoute, err := buildHTTPRoute(route, exposure.Internal)
 	if err != nil {
 		return nil, err
 	}
@@ -75,14 +95,35 @@ func MakeVirtualService(route *v1alpha1.Route) (*networking.VirtualService, erro
 			},
 		},
 		Spec: networking.VirtualServiceSpec{
-			Gateways: []string{KnativeIngressGateway},
+			Gateways: buildGateways(route, exposure),
 			Hosts:    []string{hostDomain},
 			HTTP:     httpRoute,
 		},
 	}, nil
 }
 
-func buildHTTPRoute(route *v1alpha1.Route) ([]networking.HTTPRoute, error) {
+// buildGateways returns the gateways the VirtualService is attached to.
+// Routes with TLS are served by their own Gateway which also handles
+// redirects, so the shared ingress gateway that only speaks plain HTTP is
+// dropped when HTTPS is enforced.
+func buildGateways(route *v1alpha1.Route, exposure Exposure) []string {
+	if exposure.Internal {
+		return []string{MeshGateway}
+	}
+
+	if exposure.TLS == nil {
+		return []string{KnativeIngressGateway}
+	}
+
+	routeGateway := GatewayFQDN(route.Spec.Hostname, route.Spec.Domain)
+	if exposure.HTTPSRedirect {
+		return []string{routeGateway}
+	}
+
+	return []string{KnativeIngressGateway, routeGateway}
+}
+
+func buildHTTPRoute(route *v1alpha1.Route, internal bool) ([]networking.HTTPRoute, error) {
 	var pathMatchers []networking.HTTPMatchRequest
 
 	urlPath := path.Join("/", route.Spec.Path, "/")
@@ -101,14 +142,29 @@ func buildHTTPRoute(route *v1alpha1.Route) ([]networking.HTTPRoute, error) {
 
 	var httpRoutes []networking.HTTPRoute
 
-	for _, ksvcName := range route.Spec.AppNames {
+	switch len(route.Spec.AppNames) {
+	case 0:
+	case 1:
+		// A single App gets all the traffic so the gateway can route to it
+		// directly by rewriting the authority.
+		appHost := network.GetServiceHostname(route.Spec.AppNames[0], route.GetNamespace())
 		httpRoutes = append(httpRoutes, networking.HTTPRoute{
 			Match: pathMatchers,
-			Route: buildRouteDestination(),
+			Route: []networking.HTTPRouteDestination{
+				{
+					Destination: buildAppDestination(appHost, internal),
+					Weight:      100,
+				},
+			},
 			Rewrite: &networking.HTTPRewrite{
-				Authority: network.GetServiceHostname(ksvcName, route.GetNamespace()),
+				Authority: appHost,
 			},
 		})
+	default:
+		httpRoutes = append(httpRoutes, networking.HTTPRoute{
+			Match: pathMatchers,
+			Route: buildWeightedRouteDestinations(route, internal),
+		})
 	}
 
 	// If there aren't any services bound to the route, we just want to
@@ -149,3 +205,43 @@ func buildRouteDestination() []networking.HTTPRouteDestination {
 		},
 	}
 }
+
+// buildAppDestination sends the traffic for an App through the Knative
+// ingress gateway, which routes it by its authority. Internal routes have no
+// gateway in the way so the traffic goes straight to the App.
+func buildAppDestination(appHost string, internal bool) networking.Destination {
+	if internal {
+		return networking.Destination{Host: appHost}
+	}
+
+	return networking.Destination{Host: GatewayHost}
+}
+
+// buildWeightedRouteDestinations splits the traffic between the Apps bound to
+// the route. The authority can only be rewritten for a whole HTTPRoute, so
+// each destination sets the host header of its App instead.
+func buildWeightedRouteDestinations(route *v1alpha1.Route, internal bool) []networking.HTTPRouteDestination {
+	percents := route.Spec.TrafficPercents()
+
+	var destinations []networking.HTTPRouteDestination
+	for _, appName := range route.Spec.AppNames {
+		if percents[appName] == 0 {
+			continue
+		}
+
+		host := network.GetServiceHostname(appName, route.GetNamespace())
+		destinations = append(destinations, networking.HTTPRouteDestination{
+			Destination: buildAppDestination(host, internal),
+			Weight:      percents[appName],
+			Headers: &networking.Headers{
+				Request: &networking.HeaderOperations{
+					Set: map[string]string{
+						"Host": host,
+					},
+				},
+			},
+		})
+	}
+
+	return destinations
+}
@@ -35,8 +35,9 @@ func TestMakeVirtualService(t *testing.T) {
 	t.Parallel()
 
 	for tn, tc := range map[string]struct {
-		Route  *v1alpha1.Route
-		Assert func(t *testing.T, v *networking.VirtualService, err error)
+		Route    *v1alpha1.Route
+		Exposure resources.Exposure
+		Assert   func(t *testing.T, v *networking.VirtualService, err error)
 	}{
 		"proper Meta": {
 			Route: &v1alpha1.Route{
@@ -176,6 +177,173 @@ func TestMakeVirtualService(t *testing.T) {
 				testutil.AssertEqual(t, "HTTP Match", "^/some-path(/.*)?", v.Spec.HTTP[0].Match[0].URI.Regex)
 			},
 		},
+		"public routes use the ingress gateway": {
+			Route: &v1alpha1.Route{
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "example.com",
+					},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{resources.KnativeIngressGateway}, v.Spec.Gateways)
+			},
+		},
+		"TLS routes use the ingress and route gateways": {
+			Route: &v1alpha1.Route{
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "example.com",
+					},
+				},
+			},
+			Exposure: resources.Exposure{
+				TLS: &v1alpha1.DomainTLS{SecretName: "example-com-tls"},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{
+					resources.KnativeIngressGateway,
+					resources.GatewayFQDN("some-host", "example.com"),
+				}, v.Spec.Gateways)
+			},
+		},
+		"HTTPS redirect routes only use the route gateway": {
+			Route: &v1alpha1.Route{
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "example.com",
+					},
+				},
+			},
+			Exposure: resources.Exposure{
+				TLS:           &v1alpha1.DomainTLS{SecretName: "example-com-tls"},
+				HTTPSRedirect: true,
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{
+					resources.GatewayFQDN("some-host", "example.com"),
+				}, v.Spec.Gateways)
+			},
+		},
+		"HTTPS redirect without TLS is ignored": {
+			Route: &v1alpha1.Route{
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "example.com",
+					},
+				},
+			},
+			Exposure: resources.Exposure{HTTPSRedirect: true},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{resources.KnativeIngressGateway}, v.Spec.Gateways)
+			},
+		},
+		"internal routes only use the mesh": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "apps.internal",
+					},
+					AppNames: []string{"ksvc-1"},
+				},
+			},
+			Exposure: resources.Exposure{Internal: true},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{resources.MeshGateway}, v.Spec.Gateways)
+				testutil.AssertEqual(t, "Hosts", []string{"some-host.apps.internal"}, v.Spec.Hosts)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "HTTP Route", []networking.HTTPRouteDestination{
+					{
+						Destination: networking.Destination{
+							Host: network.GetServiceHostname("ksvc-1", "some-namespace"),
+						},
+						Weight: 100,
+					},
+				}, v.Spec.HTTP[0].Route)
+			},
+		},
+		"split traffic between bound services": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "example.com",
+						Path:     "/some-path",
+					},
+					AppNames:   []string{"ksvc-1", "ksvc-2", "ksvc-3"},
+					AppWeights: map[string]int{"ksvc-2": 20, "ksvc-3": 0},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "HTTP Rewrite", (*networking.HTTPRewrite)(nil), v.Spec.HTTP[0].Rewrite)
+				testutil.AssertEqual(t, "HTTP Match", "^/some-path(/.*)?", v.Spec.HTTP[0].Match[0].URI.Regex)
+
+				destination := func(ksvcName string, weight int) networking.HTTPRouteDestination {
+					host := network.GetServiceHostname(ksvcName, "some-namespace")
+					return networking.HTTPRouteDestination{
+						Destination: networking.Destination{
+							Host: resources.GatewayHost,
+						},
+						Weight: weight,
+						Headers: &networking.Headers{
+							Request: &networking.HeaderOperations{
+								Set: map[string]string{"Host": host},
+							},
+						},
+					}
+				}
+
+				testutil.AssertEqual(t, "HTTP Route", []networking.HTTPRouteDestination{
+					destination("ksvc-1", 80),
+					destination("ksvc-2", 20),
+				}, v.Spec.HTTP[0].Route)
+			},
+		},
+		"internal split traffic goes straight to the apps": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "apps.internal",
+					},
+					AppNames: []string{"ksvc-1", "ksvc-2"},
+				},
+			},
+			Exposure: resources.Exposure{Internal: true},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+				for i, ksvcName := range []string{"ksvc-1", "ksvc-2"} {
+					testutil.AssertEqual(
+						t,
+						"destination host",
+						network.GetServiceHostname(ksvcName, "some-namespace"),
+						v.Spec.HTTP[0].Route[i].Destination.Host,
+					)
+				}
+			},
+		},
 		"Hosts with subdomain": {
 			Route: &v1alpha1.Route{
 				Spec: v1alpha1.RouteSpec{
@@ -210,7 +378,7 @@ func TestMakeVirtualService(t *testing.T) {
 		},
 	} {
 		t.Run(tn, func(t *testing.T) {
-			s, err := resources.MakeVirtualService(tc.Route)
+			s, err := resources.MakeVirtualService(tc.Route, tc.Exposure)
 			tc.Assert(t, s, err)
 		})
 	}
@@ -225,7 +393,7 @@ func ExampleMakeVirtualService() {
 				Path:     "/some-path-1",
 			},
 		},
-	})
+	}, resources.Exposure{})
 	if err != nil {
 		panic(err)
 	}
@@ -238,7 +406,7 @@ func ExampleMakeVirtualService() {
 				Path:     "/some-path-2",
 			},
 		},
-	})
+	}, resources.Exposure{})
 	if err != nil {
 		panic(err)
 	}
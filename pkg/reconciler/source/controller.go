@@ -16,18 +16,35 @@ package source
 
 import (
 	"context"
+	"os"
+	"strings"
+	"time"
 
 	kfv1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
 	buildclient "github.com/google/kf/pkg/client/build/injection/client"
 	buildinformer "github.com/google/kf/pkg/client/build/injection/informers/build/v1alpha1/build"
+	"github.com/google/kf/pkg/client/injection/dynamicclient"
 	sourceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/source"
+	"github.com/google/kf/pkg/kf/buildbackends"
 	"github.com/google/kf/pkg/reconciler"
+	"github.com/google/kf/pkg/reconciler/source/resources"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/configmap"
 	controller "knative.dev/pkg/controller"
 	logging "knative.dev/pkg/logging"
 )
 
+const (
+	// buildBackendsEnv holds a comma separated list of the build backends
+	// Sources can use. The first is the default for Sources that don't
+	// select one.
+	buildBackendsEnv = "BUILD_BACKENDS"
+
+	// taskRunResyncPeriod is how often all TaskRuns are reconciled again.
+	taskRunResyncPeriod = 10 * time.Hour
+)
+
 // NewController creates a new controller capable of reconciling Kf sources.
 func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 	logger := logging.FromContext(ctx)
@@ -37,12 +54,20 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	buildInformer := buildinformer.Get(ctx)
 	buildClient := buildclient.Get(ctx)
 
+	backendNames := buildBackendNames()
+	backends := buildbackends.NewRegistry(buildbackends.NewKnativeBuild(
+		buildClient.BuildV1alpha1(),
+		buildInformer.Lister(),
+		resources.MakeBuild,
+		nil,
+	))
+
 	// Create reconciler
 	c := &Reconciler{
-		Base:         reconciler.NewBase(ctx, "source-controller", cmw),
-		sourceLister: sourceInformer.Lister(),
-		buildLister:  buildInformer.Lister(),
-		buildClient:  buildClient.BuildV1alpha1(),
+		Base:           reconciler.NewBase(ctx, "source-controller", cmw),
+		sourceLister:   sourceInformer.Lister(),
+		backends:       backends,
+		defaultBackend: backendNames[0],
 	}
 
 	impl := controller.NewImpl(c, logger, "sources")
@@ -57,5 +82,52 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	for _, name := range backendNames {
+		if name != kfv1alpha1.BuildBackendTekton {
+			continue
+		}
+
+		// TaskRuns are only watched if the backend is enabled because the
+		// informer can't sync on clusters without Tekton Pipelines, so it's
+		// started here rather than being injected.
+		dynamicClient := dynamicclient.Get(ctx)
+		factory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, taskRunResyncPeriod)
+		taskRunInformer := factory.ForResource(buildbackends.TaskRunResource)
+
+		backends[name] = buildbackends.NewTekton(
+			dynamicClient,
+			taskRunInformer.Lister(),
+			resources.MakeTaskRun,
+			nil,
+		)
+
+		taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.Filter(kfv1alpha1.SchemeGroupVersion.WithKind("Source")),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		})
+
+		logger.Info("Waiting for the TaskRun cache to sync")
+		if err := controller.StartInformers(ctx.Done(), taskRunInformer.Informer()); err != nil {
+			logger.Fatalf("Failed to start the TaskRun informer: %s", err)
+		}
+	}
+
 	return impl
 }
+
+// buildBackendNames gets the names of the build backends enabled on the
+// cluster. Knative Build is the only one if none are configured.
+func buildBackendNames() []string {
+	var names []string
+	for _, name := range strings.Split(os.Getenv(buildBackendsEnv), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return []string{kfv1alpha1.BuildBackendKnativeBuild}
+	}
+
+	return names
+}
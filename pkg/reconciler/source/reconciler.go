@@ -20,15 +20,14 @@ import (
 	"reflect"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
-	buildclient "github.com/google/kf/pkg/client/build/clientset/versioned/typed/build/v1alpha1"
-	buildlisters "github.com/google/kf/pkg/client/build/listers/build/v1alpha1"
 	kflisters "github.com/google/kf/pkg/client/listers/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/buildbackends"
 	"github.com/google/kf/pkg/reconciler"
 	"github.com/google/kf/pkg/reconciler/source/resources"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -38,11 +37,13 @@ import (
 type Reconciler struct {
 	*reconciler.Base
 
-	buildClient buildclient.BuildV1alpha1Interface
+	// backends run builds, defaultBackend is used by Sources that don't
+	// select one.
+	backends       buildbackends.Registry
+	defaultBackend string
 
 	// listers index properties about resources
 	sourceLister kflisters.SourceLister
-	buildLister  buildlisters.BuildLister
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -95,28 +96,71 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 func (r *Reconciler) ApplyChanges(ctx context.Context, source *v1alpha1.Source) error {
 	// Sync build
 	{
-		desired, err := resources.MakeBuild(source)
+		backend, err := r.backends.Get(r.backendName(source))
 		if err != nil {
+			source.Status.MarkBuildBackendError(err)
 			return err
 		}
 
-		actual, err := r.buildLister.Builds(source.Namespace).Get(desired.Name)
-		if errors.IsNotFound(err) {
-			actual, err = r.buildClient.Builds(desired.Namespace).Create(desired)
-			if err != nil {
+		result, err := backend.Status(source)
+		switch {
+		case errors.IsNotFound(err):
+			result, err = backend.Create(source)
+			if buildbackends.IsTemplateError(err) {
+				source.Status.MarkBuildBackendError(err)
+				return nil
+			} else if err != nil {
 				return err
 			}
-		} else if !metav1.IsControlledBy(actual, source) {
-			source.Status.MarkBuildNotOwned(desired.Name)
-			return fmt.Errorf("source: %q does not own build: %q", source.Name, desired.Name)
+
+		case err == buildbackends.ErrNotOwned:
+			buildName := resources.BuildName(source)
+			source.Status.MarkBuildNotOwned(buildName)
+			return fmt.Errorf("source: %q does not own build: %q", source.Name, buildName)
+
+		case buildbackends.IsTemplateError(err):
+			// The Source can't be built by its backend, the failure is
+			// terminal so the Source isn't requeued until it changes.
+			source.Status.MarkBuildBackendError(err)
+			return nil
+
+		case err != nil:
+			source.Status.MarkBuildBackendError(err)
+			return err
 		}
 
-		source.Status.PropagateBuildStatus(actual)
+		source.Status.PropagateBuildResult(result)
+
+		// Running builds are cancelled by the controller rather than the
+		// user so only the controller writes the Source's status.
+		if source.CancelRequested() && result.Status == corev1.ConditionUnknown {
+			if err := backend.Cancel(source.Status.BuildName, source.Namespace); err != nil {
+				return err
+			}
+
+			source.Status.MarkBuildCancelled()
+		}
 	}
 
 	return nil
 }
 
+// backendName gets the name of the build backend for the Source. A build
+// that already started keeps its backend so changes to the cluster's default
+// don't start a second build for the same generation.
+func (r *Reconciler) backendName(source *v1alpha1.Source) string {
+	switch {
+	case source.Spec.Backend != "":
+		return source.Spec.Backend
+	case source.Status.BuildName == resources.BuildName(source):
+		// Builds created before backends could be selected have a blank
+		// backend which the registry resolves to Knative Build.
+		return source.Status.BuildBackend
+	default:
+		return r.defaultBackend
+	}
+}
+
 func (r *Reconciler) updateStatus(namespace string, desired *v1alpha1.Source) (*v1alpha1.Source, error) {
 	actual, err := r.sourceLister.Sources(namespace).Get(desired.Name)
 	if err != nil {
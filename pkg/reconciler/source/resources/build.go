@@ -16,6 +16,7 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	build "github.com/knative/build/pkg/apis/build/v1alpha1"
@@ -29,6 +30,9 @@ const (
 	managedByLabel         = "app.kubernetes.io/managed-by"
 	buildpackBuildTemplate = "buildpack"
 	containerImageTemplate = "container"
+	dockerfileTemplate     = "dockerfile"
+	rebaseTemplate         = "rebase"
+	buildCacheVolume       = "build-cache"
 )
 
 // BuildName gets the name of a Build for a Source.
@@ -85,11 +89,7 @@ func makeBuildpackBuild(source *v1alpha1.Source) (*build.Build, error) {
 	appImageName := AppImageName(source)
 	imageDestination := JoinRepositoryImage(source.Spec.BuildpackBuild.Registry, appImageName)
 
-	buildSource := &build.SourceSpec{
-		Custom: &corev1.Container{
-			Image: source.Spec.BuildpackBuild.Source,
-		},
-	}
+	buildSource, volumes := makeBuildSource(source.Spec.BuildpackBuild.Source, source.Spec.BuildpackBuild.Git)
 
 	args := []build.ArgumentSpec{
 		{
@@ -106,6 +106,33 @@ func makeBuildpackBuild(source *v1alpha1.Source) (*build.Build, error) {
 		},
 	}
 
+	// Stacks are the run images the app is exported on top of, the
+	// template's default is used if one isn't set.
+	if stack := source.Spec.BuildpackBuild.Stack; stack != "" {
+		args = append(args, build.ArgumentSpec{
+			Name:  v1alpha1.BuildArgRunImage,
+			Value: stack,
+		})
+	}
+
+	// The template mounts the volume named by the cache argument as the
+	// buildpack layers so they're kept between builds.
+	if claimName := source.Spec.BuildpackBuild.CacheClaimName; claimName != "" {
+		args = append(args, build.ArgumentSpec{
+			Name:  v1alpha1.BuildArgCache,
+			Value: buildCacheVolume,
+		})
+
+		volumes = append(volumes, corev1.Volume{
+			Name: buildCacheVolume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+				},
+			},
+		})
+	}
+
 	return &build.Build{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      buildName,
@@ -122,6 +149,7 @@ func makeBuildpackBuild(source *v1alpha1.Source) (*build.Build, error) {
 		Spec: build.BuildSpec{
 			Source:             buildSource,
 			ServiceAccountName: source.Spec.ServiceAccount,
+			Volumes:            volumes,
 			Template: &build.TemplateInstantiationSpec{
 				Name:      buildpackBuildTemplate,
 				Kind:      "ClusterBuildTemplate",
@@ -132,11 +160,138 @@ func makeBuildpackBuild(source *v1alpha1.Source) (*build.Build, error) {
 	}, nil
 }
 
+func makeDockerfileBuild(source *v1alpha1.Source) (*build.Build, error) {
+	buildName := BuildName(source)
+	appImageName := AppImageName(source)
+	imageDestination := JoinRepositoryImage(source.Spec.Dockerfile.Registry, appImageName)
+
+	buildSource, volumes := makeBuildSource(source.Spec.Dockerfile.Source, source.Spec.Dockerfile.Git)
+
+	// The build args are passed to the template as environment variables and
+	// their names are listed so the template can turn them into flags.
+	var buildArgNames []string
+	for _, arg := range source.Spec.Dockerfile.BuildArgs {
+		buildArgNames = append(buildArgNames, arg.Name)
+	}
+
+	args := []build.ArgumentSpec{
+		{
+			Name:  v1alpha1.BuildArgImage,
+			Value: imageDestination,
+		},
+		{
+			Name:  v1alpha1.BuildArgDockerfile,
+			Value: source.Spec.Dockerfile.Path,
+		},
+		{
+			Name:  v1alpha1.BuildArgBuildArgs,
+			Value: strings.Join(buildArgNames, " "),
+		},
+	}
+
+	return &build.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      buildName,
+			Namespace: source.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(source),
+			},
+			// Copy labels from the parent
+			Labels: resources.UnionMaps(
+				source.GetLabels(), map[string]string{
+					managedByLabel: "kf",
+				}),
+		},
+		Spec: build.BuildSpec{
+			Source:             buildSource,
+			ServiceAccountName: source.Spec.ServiceAccount,
+			Volumes:            volumes,
+			Template: &build.TemplateInstantiationSpec{
+				Name:      dockerfileTemplate,
+				Kind:      "ClusterBuildTemplate",
+				Arguments: args,
+				Env:       source.Spec.Dockerfile.BuildArgs,
+			},
+		},
+	}, nil
+}
+
+func makeRebaseBuild(source *v1alpha1.Source) (*build.Build, error) {
+	buildName := BuildName(source)
+	appImageName := AppImageName(source)
+	imageDestination := JoinRepositoryImage(source.Spec.Rebase.Registry, appImageName)
+
+	args := []build.ArgumentSpec{
+		{
+			Name:  v1alpha1.BuildArgImage,
+			Value: imageDestination,
+		},
+		{
+			Name:  v1alpha1.BuildArgSourceImage,
+			Value: source.Spec.Rebase.Image,
+		},
+		{
+			Name:  v1alpha1.BuildArgBuildpackBuilder,
+			Value: source.Spec.Rebase.BuildpackBuilder,
+		},
+	}
+
+	if stack := source.Spec.Rebase.Stack; stack != "" {
+		args = append(args, build.ArgumentSpec{
+			Name:  v1alpha1.BuildArgRunImage,
+			Value: stack,
+		})
+	}
+
+	return &build.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      buildName,
+			Namespace: source.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(source),
+			},
+			// Copy labels from the parent
+			Labels: resources.UnionMaps(
+				source.GetLabels(), map[string]string{
+					managedByLabel: "kf",
+				}),
+		},
+		Spec: build.BuildSpec{
+			ServiceAccountName: source.Spec.ServiceAccount,
+			Template: &build.TemplateInstantiationSpec{
+				Name:      rebaseTemplate,
+				Kind:      "ClusterBuildTemplate",
+				Arguments: args,
+			},
+		},
+	}, nil
+}
+
 // MakeBuild creates a Build for a Source.
 func MakeBuild(source *v1alpha1.Source) (*build.Build, error) {
-	if source.Spec.IsContainerBuild() {
-		return makeContainerImageBuild(source)
-	} else {
-		return makeBuildpackBuild(source)
+	var (
+		b   *build.Build
+		err error
+	)
+
+	switch {
+	case source.Spec.IsContainerBuild():
+		b, err = makeContainerImageBuild(source)
+	case source.Spec.IsDockerfileBuild():
+		b, err = makeDockerfileBuild(source)
+	case source.Spec.IsRebase():
+		b, err = makeRebaseBuild(source)
+	default:
+		b, err = makeBuildpackBuild(source)
+	}
+
+	if err != nil {
+		return nil, err
 	}
+
+	// Knative Build fails builds that run longer than their timeout, its
+	// default is used if the Source doesn't have one.
+	b.Spec.Timeout = source.Spec.Timeout
+
+	return b, nil
 }
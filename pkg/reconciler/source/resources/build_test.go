@@ -16,9 +16,11 @@ package resources
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func ExampleBuildName() {
@@ -84,3 +86,120 @@ func ExampleMakeBuild() {
 	// Output Image: some-registry/app-my-namespace-my-source:5
 	// Env: some = variable
 }
+
+func ExampleMakeBuild_stack() {
+	source := &v1alpha1.Source{}
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Spec.BuildpackBuild.Stack = "gcr.io/my-registry/run:cflinuxfs3"
+
+	build, err := MakeBuild(source)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Arg Count:", len(build.Spec.Template.Arguments))
+	fmt.Println("Run Image:", v1alpha1.GetBuildArg(build, v1alpha1.BuildArgRunImage))
+
+	// Output: Arg Count: 4
+	// Run Image: gcr.io/my-registry/run:cflinuxfs3
+}
+
+func ExampleMakeBuild_cache() {
+	source := &v1alpha1.Source{}
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Spec.BuildpackBuild.CacheClaimName = "my-app-build-cache"
+
+	build, err := MakeBuild(source)
+	if err != nil {
+		panic(err)
+	}
+
+	cache := v1alpha1.GetBuildArg(build, v1alpha1.BuildArgCache)
+	fmt.Println("Arg Count:", len(build.Spec.Template.Arguments))
+	fmt.Println("Cache:", cache)
+	for _, volume := range build.Spec.Volumes {
+		if volume.Name == cache {
+			fmt.Println("Claim:", volume.PersistentVolumeClaim.ClaimName)
+		}
+	}
+
+	// Output: Arg Count: 4
+	// Cache: build-cache
+	// Claim: my-app-build-cache
+}
+
+func ExampleMakeBuild_timeout() {
+	source := &v1alpha1.Source{}
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Spec.Timeout = &metav1.Duration{Duration: 30 * time.Minute}
+
+	build, err := MakeBuild(source)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Timeout:", build.Spec.Timeout.Duration)
+
+	// Output: Timeout: 30m0s
+}
+
+func ExampleMakeBuild_dockerfile() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Namespace = "my-namespace"
+	source.Generation = 5
+	source.Spec.Dockerfile.Source = "some-source"
+	source.Spec.Dockerfile.Path = "docker/Dockerfile"
+	source.Spec.Dockerfile.Registry = "some-registry"
+	source.Spec.Dockerfile.BuildArgs = []corev1.EnvVar{
+		{Name: "VERSION", Value: "1.0"},
+		{Name: "DEBUG", Value: "false"},
+	}
+
+	build, err := MakeBuild(source)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Template:", build.Spec.Template.Name)
+	fmt.Println("Source:", build.Spec.Source.Custom.Image)
+	fmt.Println("Output Image:", v1alpha1.GetBuildArg(build, v1alpha1.BuildArgImage))
+	fmt.Println("Dockerfile:", v1alpha1.GetBuildArg(build, v1alpha1.BuildArgDockerfile))
+	fmt.Println("Build Args:", v1alpha1.GetBuildArg(build, v1alpha1.BuildArgBuildArgs))
+	fmt.Println("Env:", build.Spec.Template.Env[0].Name, "=", build.Spec.Template.Env[0].Value)
+
+	// Output: Template: dockerfile
+	// Source: some-source
+	// Output Image: some-registry/app-my-namespace-my-source:5
+	// Dockerfile: docker/Dockerfile
+	// Build Args: VERSION DEBUG
+	// Env: VERSION = 1.0
+}
+
+func ExampleMakeBuild_rebase() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Namespace = "my-namespace"
+	source.Generation = 5
+	source.Spec.Rebase.Image = "some-registry/app-my-namespace-old-source:1"
+	source.Spec.Rebase.Stack = "gcr.io/my-registry/run:cflinuxfs3"
+	source.Spec.Rebase.BuildpackBuilder = "some-builder"
+	source.Spec.Rebase.Registry = "some-registry"
+
+	build, err := MakeBuild(source)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Template:", build.Spec.Template.Name)
+	fmt.Println("Has Source:", build.Spec.Source != nil)
+	fmt.Println("Source Image:", v1alpha1.GetBuildArg(build, v1alpha1.BuildArgSourceImage))
+	fmt.Println("Output Image:", v1alpha1.GetBuildArg(build, v1alpha1.BuildArgImage))
+	fmt.Println("Run Image:", v1alpha1.GetBuildArg(build, v1alpha1.BuildArgRunImage))
+
+	// Output: Template: rebase
+	// Has Source: false
+	// Source Image: some-registry/app-my-namespace-old-source:1
+	// Output Image: some-registry/app-my-namespace-my-source:5
+	// Run Image: gcr.io/my-registry/run:cflinuxfs3
+}
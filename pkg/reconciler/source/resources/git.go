@@ -0,0 +1,122 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	build "github.com/knative/build/pkg/apis/build/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	gitSourceImage        = "alpine/git"
+	gitCredentialsVolume  = "git-credentials"
+	gitCredentialsPath    = "/var/run/kf/git-credentials"
+	gitDefaultRevision    = "HEAD"
+	gitCredentialsKeyMode = int32(0400)
+
+	// gitCloneScript clones a single revision of a repository and copies the
+	// requested subdirectory into the workspace. The commit that was checked
+	// out is written to the termination message so it can be recorded on
+	// the Source. Servers only serve full commit SHAs by name, so if the
+	// shallow fetch fails the whole repository is fetched and the revision is
+	// resolved locally.
+	gitCloneScript = `set -e
+if [ -f "${GIT_CREDENTIALS}/ssh-privatekey" ]; then
+  if [ ! -f "${GIT_CREDENTIALS}/known_hosts" ]; then
+    echo "SSH Git credentials must include a known_hosts key to verify the host" >&2
+    exit 1
+  fi
+  export GIT_SSH_COMMAND="ssh -i ${GIT_CREDENTIALS}/ssh-privatekey -o UserKnownHostsFile=${GIT_CREDENTIALS}/known_hosts -o StrictHostKeyChecking=yes"
+fi
+if [ -f "${GIT_CREDENTIALS}/password" ]; then
+  git config --global credential.helper '!f() { echo "username=$(cat ${GIT_CREDENTIALS}/username)"; echo "password=$(cat ${GIT_CREDENTIALS}/password)"; }; f'
+fi
+git init -q /tmp/repo
+cd /tmp/repo
+git remote add origin "${GIT_URL}"
+if git fetch -q --depth=1 origin "${GIT_REVISION}"; then
+  git checkout -q FETCH_HEAD
+else
+  git fetch -q --tags origin
+  git checkout -q "$(git rev-parse --verify "${GIT_REVISION}^{commit}")"
+fi
+printf '%s%s' "${GIT_COMMIT_PREFIX}" "$(git rev-parse HEAD)" > /dev/termination-log
+cp -R "/tmp/repo/${GIT_SUBDIRECTORY}/." /workspace/
+`
+)
+
+// makeBuildSource creates the source of a Build from either a container image
+// holding the source code or a Git repository. The returned volumes must be
+// added to the Build.
+//
+// Git repositories are cloned with a custom source rather than the Build's
+// built-in git source because the built-in source only reads credentials
+// from Secrets annotated on the Build's ServiceAccount, which is shared by
+// every App in the Space, and doesn't report the commit it checked out.
+func makeBuildSource(sourceImage string, git *v1alpha1.SourceSpecGit) (*build.SourceSpec, []corev1.Volume) {
+	if git == nil {
+		return &build.SourceSpec{
+			Custom: &corev1.Container{
+				Image: sourceImage,
+			},
+		}, nil
+	}
+
+	revision := git.Revision
+	if revision == "" {
+		revision = gitDefaultRevision
+	}
+
+	container := &corev1.Container{
+		Image:   gitSourceImage,
+		Command: []string{"/bin/sh"},
+		Args:    []string{"-c", gitCloneScript},
+		Env: []corev1.EnvVar{
+			{Name: "GIT_URL", Value: git.URL},
+			{Name: "GIT_REVISION", Value: revision},
+			{Name: "GIT_SUBDIRECTORY", Value: git.Subdirectory},
+			{Name: "GIT_CREDENTIALS", Value: gitCredentialsPath},
+			{Name: "GIT_COMMIT_PREFIX", Value: v1alpha1.GitCommitMessagePrefix},
+		},
+	}
+
+	if git.SecretName == "" {
+		return &build.SourceSpec{Custom: container}, nil
+	}
+
+	container.VolumeMounts = []corev1.VolumeMount{
+		{
+			Name:      gitCredentialsVolume,
+			MountPath: gitCredentialsPath,
+			ReadOnly:  true,
+		},
+	}
+
+	mode := gitCredentialsKeyMode
+	volumes := []corev1.Volume{
+		{
+			Name: gitCredentialsVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  git.SecretName,
+					DefaultMode: &mode,
+				},
+			},
+		},
+	}
+
+	return &build.SourceSpec{Custom: container}, volumes
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+)
+
+func ExampleMakeBuild_git() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Namespace = "my-namespace"
+	source.Generation = 5
+	source.Spec.BuildpackBuild.Git = &v1alpha1.SourceSpecGit{
+		URL:          "https://github.com/google/kf",
+		Revision:     "abc123",
+		Subdirectory: "samples/apps/helloworld",
+		SecretName:   "git-credentials",
+	}
+	source.Spec.BuildpackBuild.Registry = "some-registry"
+	source.Spec.BuildpackBuild.BuildpackBuilder = "some-buildpack-builder"
+
+	build, err := MakeBuild(source)
+	if err != nil {
+		panic(err)
+	}
+
+	container := build.Spec.Source.Custom
+	fmt.Println("Image:", container.Image)
+	for _, env := range container.Env {
+		fmt.Println("Env:", env.Name, "=", env.Value)
+	}
+	fmt.Println("Mount:", container.VolumeMounts[0].MountPath)
+	fmt.Println("Secret:", build.Spec.Volumes[0].Secret.SecretName)
+
+	// Output: Image: alpine/git
+	// Env: GIT_URL = https://github.com/google/kf
+	// Env: GIT_REVISION = abc123
+	// Env: GIT_SUBDIRECTORY = samples/apps/helloworld
+	// Env: GIT_CREDENTIALS = /var/run/kf/git-credentials
+	// Env: GIT_COMMIT_PREFIX = commit=
+	// Mount: /var/run/kf/git-credentials
+	// Secret: git-credentials
+}
+
+func ExampleMakeBuild_gitDefaults() {
+	source := &v1alpha1.Source{}
+	source.Spec.Dockerfile.Git = &v1alpha1.SourceSpecGit{
+		URL: "https://github.com/google/kf",
+	}
+
+	build, err := MakeBuild(source)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Template:", build.Spec.Template.Name)
+	fmt.Println("Revision:", build.Spec.Source.Custom.Env[1].Value)
+	fmt.Println("Volumes:", len(build.Spec.Volumes))
+
+	// Output: Template: dockerfile
+	// Revision: HEAD
+	// Volumes: 0
+}
@@ -0,0 +1,144 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	taskRunAPIVersion = "tekton.dev/v1alpha1"
+	taskRunKind       = "TaskRun"
+	buildEnvParam     = "BUILD_ENV"
+)
+
+// MakeTaskRun creates a Tekton TaskRun for a Source. The TaskRun references
+// the ClusterTasks in config/tekton which only cover buildpack builds of
+// uploaded source code and container images. Sources that select the Tekton
+// backend are rejected by validation if they need anything else, but Sources
+// that fall back to the controller's default backend are only checked here.
+func MakeTaskRun(source *v1alpha1.Source) (*unstructured.Unstructured, error) {
+	var (
+		taskName string
+		params   []interface{}
+	)
+
+	switch {
+	case source.Spec.IsContainerBuild():
+		taskName = containerImageTemplate
+		params = append(params, makeTaskRunParam(v1alpha1.BuildArgImage, source.Spec.ContainerImage.Image))
+
+	case source.Spec.IsDockerfileBuild():
+		return nil, errors.New("Dockerfile builds aren't supported by the tekton build backend")
+
+	case source.Spec.IsRebase():
+		return nil, errors.New("rebasing isn't supported by the tekton build backend")
+
+	case source.Spec.BuildpackBuild.Git != nil:
+		return nil, errors.New("Git sources aren't supported by the tekton build backend")
+
+	case source.Spec.BuildpackBuild.CacheClaimName != "":
+		return nil, errors.New("build caches aren't supported by the tekton build backend")
+
+	default:
+		buildEnv, err := makeBuildEnvScript(source.Spec.BuildpackBuild.Env)
+		if err != nil {
+			return nil, err
+		}
+
+		taskName = buildpackBuildTemplate
+		imageDestination := JoinRepositoryImage(source.Spec.BuildpackBuild.Registry, AppImageName(source))
+		params = append(params,
+			makeTaskRunParam(v1alpha1.BuildArgSourceImage, source.Spec.BuildpackBuild.Source),
+			makeTaskRunParam(v1alpha1.BuildArgImage, imageDestination),
+			makeTaskRunParam(v1alpha1.BuildArgBuildpackBuilder, source.Spec.BuildpackBuild.BuildpackBuilder),
+			makeTaskRunParam(v1alpha1.BuildArgBuildpack, source.Spec.BuildpackBuild.Buildpack),
+			makeTaskRunParam(buildEnvParam, buildEnv),
+		)
+
+		if stack := source.Spec.BuildpackBuild.Stack; stack != "" {
+			params = append(params, makeTaskRunParam(v1alpha1.BuildArgRunImage, stack))
+		}
+	}
+
+	taskRun := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"serviceAccount": source.Spec.ServiceAccount,
+				"taskRef": map[string]interface{}{
+					"name": taskName,
+					"kind": "ClusterTask",
+				},
+				"inputs": map[string]interface{}{
+					"params": params,
+				},
+			},
+		},
+	}
+
+	// Tekton fails TaskRuns that run longer than their timeout, its default
+	// is used if the Source doesn't have one.
+	if timeout := source.Spec.Timeout; timeout != nil {
+		unstructured.SetNestedField(taskRun.Object, timeout.Duration.String(), "spec", "timeout")
+	}
+
+	taskRun.SetAPIVersion(taskRunAPIVersion)
+	taskRun.SetKind(taskRunKind)
+	taskRun.SetName(BuildName(source))
+	taskRun.SetNamespace(source.Namespace)
+	taskRun.SetOwnerReferences([]metav1.OwnerReference{
+		*kmeta.NewControllerRef(source),
+	})
+	// Copy labels from the parent
+	taskRun.SetLabels(resources.UnionMaps(
+		source.GetLabels(), map[string]string{
+			managedByLabel: "kf",
+		}))
+
+	return taskRun, nil
+}
+
+func makeTaskRunParam(name, value string) interface{} {
+	return map[string]interface{}{
+		"name":  name,
+		"value": value,
+	}
+}
+
+// makeBuildEnvScript converts the environment variables of a build into shell
+// export statements. ClusterTasks can't have their environment set by a
+// TaskRun so the build step evaluates the script instead.
+func makeBuildEnvScript(env []corev1.EnvVar) (string, error) {
+	var lines []string
+	for _, e := range env {
+		if e.ValueFrom != nil {
+			return "", fmt.Errorf("the environment variable %q can't be read from a reference by the tekton build backend", e.Name)
+		}
+
+		quoted := "'" + strings.Replace(e.Value, "'", `'\''`, -1) + "'"
+		lines = append(lines, fmt.Sprintf("export %s=%s", e.Name, quoted))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
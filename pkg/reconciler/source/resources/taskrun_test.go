@@ -0,0 +1,126 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func ExampleMakeTaskRun() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Namespace = "my-namespace"
+	source.Generation = 5
+	source.Spec.ServiceAccount = "some-account"
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Spec.BuildpackBuild.Registry = "some-registry"
+	source.Spec.BuildpackBuild.BuildpackBuilder = "some-buildpack-builder"
+	source.Spec.BuildpackBuild.Buildpack = "some-buildpack"
+	source.Spec.BuildpackBuild.Env = []corev1.EnvVar{
+		{Name: "GREETING", Value: "it's me"},
+	}
+
+	taskRun, err := MakeTaskRun(source)
+	if err != nil {
+		panic(err)
+	}
+
+	task, _, _ := unstructured.NestedString(taskRun.Object, "spec", "taskRef", "name")
+	account, _, _ := unstructured.NestedString(taskRun.Object, "spec", "serviceAccount")
+	params, _, _ := unstructured.NestedSlice(taskRun.Object, "spec", "inputs", "params")
+
+	fmt.Println("Kind:", taskRun.GetAPIVersion(), taskRun.GetKind())
+	fmt.Println("Name:", taskRun.GetName())
+	fmt.Println("Managed By:", taskRun.GetLabels()[managedByLabel])
+	fmt.Println("Task:", task)
+	fmt.Println("Service Account:", account)
+	for _, param := range params {
+		p := param.(map[string]interface{})
+		fmt.Printf("%s: %s\n", p["name"], p["value"])
+	}
+
+	// Output: Kind: tekton.dev/v1alpha1 TaskRun
+	// Name: my-source-5
+	// Managed By: kf
+	// Task: buildpack
+	// Service Account: some-account
+	// SOURCE_IMAGE: some-source
+	// IMAGE: some-registry/app-my-namespace-my-source:5
+	// BUILDER_IMAGE: some-buildpack-builder
+	// BUILDPACK: some-buildpack
+	// BUILD_ENV: export GREETING='it'\''s me'
+}
+
+func ExampleMakeTaskRun_container() {
+	source := &v1alpha1.Source{}
+	source.Spec.ContainerImage.Image = "gcr.io/my-registry/my-app"
+
+	taskRun, err := MakeTaskRun(source)
+	if err != nil {
+		panic(err)
+	}
+
+	task, _, _ := unstructured.NestedString(taskRun.Object, "spec", "taskRef", "name")
+	params, _, _ := unstructured.NestedSlice(taskRun.Object, "spec", "inputs", "params")
+
+	fmt.Println("Task:", task)
+	fmt.Println("Param Count:", len(params))
+
+	// Output: Task: container
+	// Param Count: 1
+}
+
+func ExampleMakeTaskRun_timeout() {
+	source := &v1alpha1.Source{}
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Spec.Timeout = &metav1.Duration{Duration: time.Hour}
+
+	taskRun, err := MakeTaskRun(source)
+	if err != nil {
+		panic(err)
+	}
+
+	timeout, _, _ := unstructured.NestedString(taskRun.Object, "spec", "timeout")
+	fmt.Println("Timeout:", timeout)
+
+	// Output: Timeout: 1h0m0s
+}
+
+func ExampleMakeTaskRun_unsupported() {
+	source := &v1alpha1.Source{}
+	source.Spec.Dockerfile.Source = "some-source"
+
+	_, err := MakeTaskRun(source)
+	fmt.Println(err)
+
+	// Output: Dockerfile builds aren't supported by the tekton build backend
+}
+
+func ExampleMakeTaskRun_cache() {
+	source := &v1alpha1.Source{}
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Spec.BuildpackBuild.CacheClaimName = "some-cache"
+
+	_, err := MakeTaskRun(source)
+	fmt.Println(err)
+
+	// Output: build caches aren't supported by the tekton build backend
+}
@@ -23,6 +23,11 @@ import (
 	namespaceinformer "knative.dev/pkg/injection/informers/kubeinformers/corev1/namespace"
 	roleinformer "knative.dev/pkg/injection/informers/kubeinformers/rbacv1/role"
 
+	clusterroleinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/clusterrole"
+	clusterrolebindinginformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/clusterrolebinding"
+	networkpolicyinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/networkpolicy"
+	rolebindinginformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/rolebinding"
+
 	// TODO (juliaguo): replace with knative informer pkgs once they are merged in
 	limitrangeinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/limitrange"
 	quotainformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/resourcequota"
@@ -42,17 +47,25 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	nsInformer := namespaceinformer.Get(ctx)
 	spaceInformer := spaceinformer.Get(ctx)
 	roleInformer := roleinformer.Get(ctx)
+	roleBindingInformer := rolebindinginformer.Get(ctx)
+	clusterRoleInformer := clusterroleinformer.Get(ctx)
+	clusterRoleBindingInformer := clusterrolebindinginformer.Get(ctx)
 	quotaInformer := quotainformer.Get(ctx)
 	limitRangeInformer := limitrangeinformer.Get(ctx)
+	networkPolicyInformer := networkpolicyinformer.Get(ctx)
 
 	// Create reconciler
 	c := &Reconciler{
-		Base:                reconciler.NewBase(ctx, "space-controller", cmw),
-		spaceLister:         spaceInformer.Lister(),
-		namespaceLister:     nsInformer.Lister(),
-		roleLister:          roleInformer.Lister(),
-		resourceQuotaLister: quotaInformer.Lister(),
-		limitRangeLister:    limitRangeInformer.Lister(),
+		Base:                     reconciler.NewBase(ctx, "space-controller", cmw),
+		spaceLister:              spaceInformer.Lister(),
+		namespaceLister:          nsInformer.Lister(),
+		roleLister:               roleInformer.Lister(),
+		roleBindingLister:        roleBindingInformer.Lister(),
+		clusterRoleLister:        clusterRoleInformer.Lister(),
+		clusterRoleBindingLister: clusterRoleBindingInformer.Lister(),
+		resourceQuotaLister:      quotaInformer.Lister(),
+		limitRangeLister:         limitRangeInformer.Lister(),
+		networkPolicyLister:      networkPolicyInformer.Lister(),
 	}
 
 	impl := controller.NewImpl(c, logger, "Spaces")
@@ -71,6 +84,21 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	roleBindingInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Space")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	clusterRoleInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Space")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	clusterRoleBindingInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Space")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
 	quotaInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Space")),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
@@ -81,5 +109,10 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	networkPolicyInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Space")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
 	return impl
 }
@@ -25,12 +25,15 @@ import (
 	"github.com/google/kf/pkg/reconciler/space/resources"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	v1listers "k8s.io/client-go/listers/core/v1"
+	networkingv1listers "k8s.io/client-go/listers/networking/v1"
 	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
@@ -43,11 +46,15 @@ type Reconciler struct {
 	*reconciler.Base
 
 	// listers index properties about resources
-	spaceLister         kflisters.SpaceLister
-	namespaceLister     v1listers.NamespaceLister
-	roleLister          rbacv1listers.RoleLister
-	resourceQuotaLister v1listers.ResourceQuotaLister
-	limitRangeLister    v1listers.LimitRangeLister
+	spaceLister              kflisters.SpaceLister
+	namespaceLister          v1listers.NamespaceLister
+	roleLister               rbacv1listers.RoleLister
+	roleBindingLister        rbacv1listers.RoleBindingLister
+	clusterRoleLister        rbacv1listers.ClusterRoleLister
+	clusterRoleBindingLister rbacv1listers.ClusterRoleBindingLister
+	resourceQuotaLister      v1listers.ResourceQuotaLister
+	limitRangeLister         v1listers.LimitRangeLister
+	networkPolicyLister      networkingv1listers.NetworkPolicyLister
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -187,6 +194,101 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, space *v1alpha1.Space) er
 		space.Status.PropagateAuditorRoleStatus(actual)
 	}
 
+	// Sync manager roles
+	{
+		desired, err := resources.MakeManagerRole(space)
+		if err != nil {
+			return err
+		}
+
+		actual, err := r.roleLister.Roles(desired.Namespace).Get(desired.Name)
+		if errors.IsNotFound(err) {
+			actual, err = r.KubeClientSet.RbacV1().Roles(desired.Namespace).Create(desired)
+			if err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		} else if !metav1.IsControlledBy(actual, space) {
+			space.Status.MarkManagerRoleNotOwned(desired.Name)
+			return fmt.Errorf("space: %q does not own role: %q", space.Name, desired.Name)
+		} else if actual, err = r.reconcileGenericRole(desired, actual); err != nil {
+			return err
+		}
+
+		desiredCluster, err := resources.MakeManagerClusterRole(space)
+		if err != nil {
+			return err
+		}
+
+		actualCluster, err := r.clusterRoleLister.Get(desiredCluster.Name)
+		if errors.IsNotFound(err) {
+			actualCluster, err = r.KubeClientSet.RbacV1().ClusterRoles().Create(desiredCluster)
+			if err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		} else if !metav1.IsControlledBy(actualCluster, space) {
+			space.Status.MarkManagerRoleNotOwned(desiredCluster.Name)
+			return fmt.Errorf("space: %q does not own clusterrole: %q", space.Name, desiredCluster.Name)
+		} else if actualCluster, err = r.reconcileClusterRole(desiredCluster, actualCluster); err != nil {
+			return err
+		}
+
+		space.Status.PropagateManagerRoleStatus(actual, actualCluster)
+	}
+
+	// Sync role bindings
+	{
+		var bindings []*rv1.RoleBinding
+		for _, role := range v1alpha1.SpaceRoles {
+			desired, err := resources.MakeRoleBinding(space, role)
+			if err != nil {
+				return err
+			}
+
+			actual, err := r.roleBindingLister.RoleBindings(desired.Namespace).Get(desired.Name)
+			if errors.IsNotFound(err) {
+				actual, err = r.KubeClientSet.RbacV1().RoleBindings(desired.Namespace).Create(desired)
+				if err != nil {
+					return err
+				}
+			} else if err != nil {
+				return err
+			} else if !metav1.IsControlledBy(actual, space) {
+				space.Status.MarkRoleBindingNotOwned(desired.Name)
+				return fmt.Errorf("space: %q does not own rolebinding: %q", space.Name, desired.Name)
+			} else if actual, err = r.reconcileRoleBinding(desired, actual); err != nil {
+				return err
+			}
+
+			bindings = append(bindings, actual)
+		}
+
+		desiredCluster, err := resources.MakeManagerClusterRoleBinding(space)
+		if err != nil {
+			return err
+		}
+
+		actualCluster, err := r.clusterRoleBindingLister.Get(desiredCluster.Name)
+		if errors.IsNotFound(err) {
+			actualCluster, err = r.KubeClientSet.RbacV1().ClusterRoleBindings().Create(desiredCluster)
+			if err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		} else if !metav1.IsControlledBy(actualCluster, space) {
+			space.Status.MarkRoleBindingNotOwned(desiredCluster.Name)
+			return fmt.Errorf("space: %q does not own clusterrolebinding: %q", space.Name, desiredCluster.Name)
+		} else if actualCluster, err = r.reconcileClusterRoleBinding(desiredCluster, actualCluster); err != nil {
+			return err
+		}
+
+		space.Status.PropagateRoleBindingsStatus(bindings, actualCluster)
+	}
+
 	// Sync resource quota
 	{
 		desired, err := resources.MakeResourceQuota(space)
@@ -237,9 +339,82 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, space *v1alpha1.Space) er
 		space.Status.PropagateLimitRangeStatus(actual)
 	}
 
+	// Sync network policies
+	{
+		desired, err := resources.MakeNetworkPolicies(space)
+		if err != nil {
+			return err
+		}
+
+		var policies []*networkingv1.NetworkPolicy
+		desiredNames := make(map[string]bool)
+		for _, policy := range desired {
+			desiredNames[policy.Name] = true
+
+			actual, err := r.networkPolicyLister.NetworkPolicies(policy.Namespace).Get(policy.Name)
+			if errors.IsNotFound(err) {
+				actual, err = r.KubeClientSet.NetworkingV1().NetworkPolicies(policy.Namespace).Create(policy)
+				if err != nil {
+					return err
+				}
+			} else if err != nil {
+				return err
+			} else if !metav1.IsControlledBy(actual, space) {
+				space.Status.MarkNetworkPolicyNotOwned(policy.Name)
+				return fmt.Errorf("space: %q does not own networkpolicy: %q", space.Name, policy.Name)
+			} else if actual, err = r.reconcileNetworkPolicy(policy, actual); err != nil {
+				return err
+			}
+
+			policies = append(policies, actual)
+		}
+
+		// Delete the NetworkPolicies of Apps that are no longer the
+		// destination of a policy.
+		existing, err := r.networkPolicyLister.NetworkPolicies(namespaceName).List(labels.Everything())
+		if err != nil {
+			return err
+		}
+
+		for _, policy := range existing {
+			if desiredNames[policy.Name] || !metav1.IsControlledBy(policy, space) {
+				continue
+			}
+
+			err := r.KubeClientSet.NetworkingV1().NetworkPolicies(policy.Namespace).Delete(policy.Name, &metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+
+		space.Status.PropagateNetworkPoliciesStatus(policies)
+	}
+
 	return nil
 }
 
+func (r *Reconciler) reconcileNetworkPolicy(desired, actual *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	if _, err := kmp.SafeDiff(desired.Spec, actual.Spec); err != nil {
+		return nil, fmt.Errorf("failed to diff NetworkPolicy: %v", err)
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.Spec = desired.Spec
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	return r.KubeClientSet.NetworkingV1().NetworkPolicies(existing.Namespace).Update(existing)
+}
+
 func (r *Reconciler) reconcileNs(desired, actual *v1.Namespace) (*v1.Namespace, error) {
 	// Check for differences, if none we don't need to reconcile.
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
@@ -284,6 +459,74 @@ func (r *Reconciler) reconcileGenericRole(desired, actual *rv1.Role) (*rv1.Role,
 	return r.KubeClientSet.RbacV1().Roles(existing.Namespace).Update(existing)
 }
 
+func (r *Reconciler) reconcileClusterRole(desired, actual *rv1.ClusterRole) (*rv1.ClusterRole, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Rules, actual.Rules)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	if _, err := kmp.SafeDiff(desired.Rules, actual.Rules); err != nil {
+		return nil, fmt.Errorf("failed to diff Rules: %v", err)
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Rules = desired.Rules
+	return r.KubeClientSet.RbacV1().ClusterRoles().Update(existing)
+}
+
+func (r *Reconciler) reconcileRoleBinding(desired, actual *rv1.RoleBinding) (*rv1.RoleBinding, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Subjects, actual.Subjects)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	if _, err := kmp.SafeDiff(desired.Subjects, actual.Subjects); err != nil {
+		return nil, fmt.Errorf("failed to diff Subjects: %v", err)
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	// RoleRef is immutable and derived from the binding name so it's skipped.
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Subjects = desired.Subjects
+	return r.KubeClientSet.RbacV1().RoleBindings(existing.Namespace).Update(existing)
+}
+
+func (r *Reconciler) reconcileClusterRoleBinding(desired, actual *rv1.ClusterRoleBinding) (*rv1.ClusterRoleBinding, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Subjects, actual.Subjects)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	if _, err := kmp.SafeDiff(desired.Subjects, actual.Subjects); err != nil {
+		return nil, fmt.Errorf("failed to diff Subjects: %v", err)
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	// RoleRef is immutable and derived from the binding name so it's skipped.
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Subjects = desired.Subjects
+	return r.KubeClientSet.RbacV1().ClusterRoleBindings().Update(existing)
+}
+
 func (r *Reconciler) reconcileResourceQuota(desired, actual *v1.ResourceQuota) (*v1.ResourceQuota, error) {
 	// Check for differences, if none we don't need to reconcile.
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
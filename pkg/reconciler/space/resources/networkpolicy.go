@@ -0,0 +1,150 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/kmeta"
+)
+
+// appServerComponent is the component label of the Pods that serve an App.
+const appServerComponent = "app-server"
+
+// queueProxyPorts are the ports Knative's queue-proxy sidecar accepts HTTP/1
+// and HTTP/2 traffic on. Knative never sends traffic to the App's own port
+// from outside the Pod, so policies that limit the port must allow these
+// instead.
+var queueProxyPorts = []int{8012, 8013}
+
+// NetworkPolicyName gets the name of the NetworkPolicy that controls the
+// connections to an App.
+func NetworkPolicyName(destinationApp string) string {
+	return fmt.Sprintf("kf-allow-%s", destinationApp)
+}
+
+// MakeNetworkPolicies creates a NetworkPolicy for each App that is the
+// destination of one of the Space's network policies. The destination only
+// accepts connections from the source Apps in the policies and from Pods
+// outside of Kf spaces, such as the ingress gateway and the Knative
+// activator. Apps that aren't the destination of any policy get no
+// NetworkPolicy and stay open to every Pod.
+func MakeNetworkPolicies(space *v1alpha1.Space) ([]*networkingv1.NetworkPolicy, error) {
+	var destinations []string
+	seen := make(map[string]bool)
+	for _, policy := range space.Spec.Security.NetworkPolicies {
+		if !seen[policy.DestinationApp] {
+			seen[policy.DestinationApp] = true
+			destinations = append(destinations, policy.DestinationApp)
+		}
+	}
+	sort.Strings(destinations)
+
+	var out []*networkingv1.NetworkPolicy
+	for _, destination := range destinations {
+		out = append(out, makeNetworkPolicy(space, destination))
+	}
+
+	return out, nil
+}
+
+func makeNetworkPolicy(space *v1alpha1.Space, destinationApp string) *networkingv1.NetworkPolicy {
+	var rules []networkingv1.NetworkPolicyIngressRule
+	for _, policy := range space.Spec.Security.NetworkPoliciesFor(destinationApp) {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{
+				{PodSelector: appServerSelector(policy.SourceApp)},
+			},
+			Ports: makeNetworkPolicyPorts(policy),
+		})
+	}
+
+	// Allow traffic from outside the Kf spaces so the App can still be
+	// reached through the gateways and scaled from zero.
+	rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{
+			{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key:      managedByLabel,
+							Operator: metav1.LabelSelectorOpNotIn,
+							Values:   []string{"kf"},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NetworkPolicyName(destinationApp),
+			Namespace: NamespaceName(space),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(space),
+			},
+			Labels: resources.UnionMaps(space.GetLabels(), map[string]string{
+				managedByLabel: "kf",
+			}),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: *appServerSelector(destinationApp),
+			Ingress:     rules,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
+
+func appServerSelector(appName string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			v1alpha1.NameLabel:      appName,
+			v1alpha1.ComponentLabel: appServerComponent,
+		},
+	}
+}
+
+// makeNetworkPolicyPorts gets the ports a policy allows. The policy's port is
+// the one the App listens on, but every connection to it goes through the
+// queue-proxy so the queue-proxy ports are allowed in its place.
+func makeNetworkPolicyPorts(policy v1alpha1.SpaceNetworkPolicy) []networkingv1.NetworkPolicyPort {
+	protocol := policy.Protocol
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+
+	if policy.Port == 0 {
+		return []networkingv1.NetworkPolicyPort{{Protocol: &protocol}}
+	}
+
+	var ports []networkingv1.NetworkPolicyPort
+	for _, queueProxyPort := range queueProxyPorts {
+		portNumber := intstr.FromInt(queueProxyPort)
+		ports = append(ports, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &portNumber,
+		})
+	}
+
+	return ports
+}
@@ -0,0 +1,95 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func ExampleNetworkPolicyName() {
+	fmt.Println(NetworkPolicyName("backend"))
+
+	// Output: kf-allow-backend
+}
+
+func TestMakeNetworkPolicies(t *testing.T) {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+	space.Spec.Security.NetworkPolicies = []v1alpha1.SpaceNetworkPolicy{
+		{SourceApp: "frontend", DestinationApp: "backend", Port: 8080},
+		{SourceApp: "backend", DestinationApp: "database", Protocol: corev1.ProtocolUDP},
+		{SourceApp: "admin", DestinationApp: "backend"},
+	}
+
+	policies, err := MakeNetworkPolicies(space)
+	testutil.AssertNil(t, "MakeNetworkPolicies err", err)
+	testutil.AssertEqual(t, "policy count", 2, len(policies))
+
+	backend := policies[0]
+	testutil.AssertEqual(t, "name", "kf-allow-backend", backend.Name)
+	testutil.AssertEqual(t, "namespace", "my-space", backend.Namespace)
+	testutil.AssertEqual(t, "managed-by", "kf", backend.Labels[managedByLabel])
+	testutil.AssertEqual(t, "owner", "my-space", backend.OwnerReferences[0].Name)
+	testutil.AssertEqual(t, "pod selector", map[string]string{
+		v1alpha1.NameLabel:      "backend",
+		v1alpha1.ComponentLabel: "app-server",
+	}, backend.Spec.PodSelector.MatchLabels)
+	testutil.AssertEqual(t, "policy types", []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, backend.Spec.PolicyTypes)
+
+	tcp := corev1.ProtocolTCP
+	http1Port := intstr.FromInt(8012)
+	http2Port := intstr.FromInt(8013)
+	testutil.AssertEqual(t, "rule count", 3, len(backend.Spec.Ingress))
+	testutil.AssertEqual(t, "frontend source", map[string]string{
+		v1alpha1.NameLabel:      "frontend",
+		v1alpha1.ComponentLabel: "app-server",
+	}, backend.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+	testutil.AssertEqual(t, "frontend ports", []networkingv1.NetworkPolicyPort{
+		{Protocol: &tcp, Port: &http1Port},
+		{Protocol: &tcp, Port: &http2Port},
+	}, backend.Spec.Ingress[0].Ports)
+	testutil.AssertEqual(t, "admin ports", []networkingv1.NetworkPolicyPort{
+		{Protocol: &tcp},
+	}, backend.Spec.Ingress[1].Ports)
+	testutil.AssertEqual(t, "outside spaces", &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: managedByLabel, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kf"}},
+		},
+	}, backend.Spec.Ingress[2].From[0].NamespaceSelector)
+
+	database := policies[1]
+	udp := corev1.ProtocolUDP
+	testutil.AssertEqual(t, "name", "kf-allow-database", database.Name)
+	testutil.AssertEqual(t, "database ports", []networkingv1.NetworkPolicyPort{
+		{Protocol: &udp},
+	}, database.Spec.Ingress[0].Ports)
+}
+
+func TestMakeNetworkPolicies_none(t *testing.T) {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+
+	policies, err := MakeNetworkPolicies(space)
+	testutil.AssertNil(t, "MakeNetworkPolicies err", err)
+	testutil.AssertEqual(t, "policy count", 0, len(policies))
+}
@@ -66,6 +66,61 @@ func MakeAuditorRole(space *v1alpha1.Space) (*v1.Role, error) {
 	}, nil
 }
 
+// ManagerRoleName gets the name of the manager role given the space.
+func ManagerRoleName(space *v1alpha1.Space) string {
+	return "space-manager"
+}
+
+// MakeManagerRole creates a Role for manager access from a Space object.
+func MakeManagerRole(space *v1alpha1.Space) (*v1.Role, error) {
+	return &v1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ManagerRoleName(space),
+			Namespace: NamespaceName(space),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(space),
+			},
+			Labels: resources.UnionMaps(space.GetLabels(), map[string]string{
+				managedByLabel: "kf",
+			}),
+		},
+		Rules: managerPolicyRules(space),
+	}, nil
+}
+
+// ManagerClusterRoleName gets the name of the ClusterRole that allows
+// managers to modify the space. Spaces are cluster scoped so the role can't
+// live in the space's namespace.
+func ManagerClusterRoleName(space *v1alpha1.Space) string {
+	return "kf-space-manager-" + space.Name
+}
+
+// MakeManagerClusterRole creates a ClusterRole that grants access to modify
+// the Space object itself. RBAC can't scope the grant to individual fields, so
+// the Space validation webhook rejects manager edits to anything other than
+// role assignments.
+func MakeManagerClusterRole(space *v1alpha1.Space) (*v1.ClusterRole, error) {
+	return &v1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ManagerClusterRoleName(space),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(space),
+			},
+			Labels: resources.UnionMaps(space.GetLabels(), map[string]string{
+				managedByLabel: "kf",
+			}),
+		},
+		Rules: []v1.PolicyRule{
+			{
+				APIGroups:     []string{v1alpha1.SchemeGroupVersion.Group},
+				Verbs:         []string{"get", "update", "patch"},
+				Resources:     []string{"spaces"},
+				ResourceNames: []string{space.Name},
+			},
+		},
+	}, nil
+}
+
 func readOnlyVerbs() []string {
 	return []string{"get", "list", "watch"}
 }
@@ -95,6 +150,23 @@ func developerPolicyRules(space *v1alpha1.Space) []v1.PolicyRule {
 			Verbs:     readEditVerbs(),
 			Resources: []string{"secrets"},
 		},
+		// Clear the build caches of apps
+		{
+			APIGroups: []string{""}, // "" is the builtin API group
+			Verbs:     append(readOnlyVerbs(), "delete"),
+			Resources: []string{"persistentvolumeclaims"},
+		},
+		// Cancel running builds
+		{
+			APIGroups: []string{"build.knative.dev"},
+			Verbs:     []string{"update", "patch"},
+			Resources: []string{"builds"},
+		},
+		{
+			APIGroups: []string{"tekton.dev"},
+			Verbs:     []string{"update", "patch"},
+			Resources: []string{"taskruns"},
+		},
 		// Create new services
 		{
 			APIGroups: []string{"serving.knative.dev"},
@@ -117,6 +189,15 @@ func developerPolicyRules(space *v1alpha1.Space) []v1.PolicyRule {
 	return out
 }
 
+func managerPolicyRules(space *v1alpha1.Space) []v1.PolicyRule {
+	return append(developerPolicyRules(space), v1.PolicyRule{
+		// Read access to see who has access to the space.
+		APIGroups: []string{v1.GroupName},
+		Verbs:     readOnlyVerbs(),
+		Resources: []string{"roles", "rolebindings"},
+	})
+}
+
 func auditPolicyRules(space *v1alpha1.Space) []v1.PolicyRule {
 	return []v1.PolicyRule{
 		// Read access to Knative serving.
@@ -131,6 +212,13 @@ func auditPolicyRules(space *v1alpha1.Space) []v1.PolicyRule {
 			Verbs:     readOnlyVerbs(),
 			Resources: []string{"*"},
 		},
+		// Read access to Tekton TaskRuns so builds run by the Tekton backend
+		// can be tailed.
+		{
+			APIGroups: []string{"tekton.dev"},
+			Verbs:     readOnlyVerbs(),
+			Resources: []string{"taskruns"},
+		},
 		// Read access to the Service catalog.
 		{
 			APIGroups: []string{"servicecatalog.k8s.io"},
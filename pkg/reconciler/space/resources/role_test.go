@@ -57,6 +57,7 @@ func TestMakeAuditorRole(t *testing.T) {
 	// TODO(josephlewis42) fill in this table when the apps CRD gets added and all
 	// of the necessary roles get finalized
 	assertAllowed(t, ar, "get", "serving.knative.dev", "services")
+	assertAllowed(t, ar, "watch", "tekton.dev", "taskruns")
 	assertNotAllowed(t, ar, "get", "", "secrets")
 }
 
@@ -69,6 +70,11 @@ func TestMakeDeveloperRole(t *testing.T) {
 			Space: v1alpha1.Space{},
 			Assert: func(t *testing.T, role *v1.Role) {
 				assertNotAllowed(t, role, "get", "", "pods/log")
+				assertAllowed(t, role, "delete", "", "persistentvolumeclaims")
+				assertNotAllowed(t, role, "create", "", "persistentvolumeclaims")
+				assertAllowed(t, role, "update", "build.knative.dev", "builds")
+				assertAllowed(t, role, "update", "tekton.dev", "taskruns")
+				assertNotAllowed(t, role, "delete", "tekton.dev", "taskruns")
 			},
 		},
 		"space allows logs": {
@@ -95,6 +101,53 @@ func TestMakeDeveloperRole(t *testing.T) {
 	}
 }
 
+func ExampleManagerRoleName() {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+
+	fmt.Println(ManagerRoleName(space))
+
+	// Output: space-manager
+}
+
+func ExampleManagerClusterRoleName() {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+
+	fmt.Println(ManagerClusterRoleName(space))
+
+	// Output: kf-space-manager-my-space
+}
+
+func TestMakeManagerRole(t *testing.T) {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+
+	role, err := MakeManagerRole(space)
+	testutil.AssertNil(t, "MakeManagerRole error", err)
+
+	assertAllowed(t, role, "create", "serving.knative.dev", "services")
+	assertAllowed(t, role, "list", "rbac.authorization.k8s.io", "rolebindings")
+	assertNotAllowed(t, role, "create", "rbac.authorization.k8s.io", "rolebindings")
+}
+
+func TestMakeManagerClusterRole(t *testing.T) {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+
+	role, err := MakeManagerClusterRole(space)
+	testutil.AssertNil(t, "MakeManagerClusterRole error", err)
+
+	testutil.AssertEqual(t, "rules", []v1.PolicyRule{
+		{
+			APIGroups:     []string{"kf.dev"},
+			Verbs:         []string{"get", "update", "patch"},
+			Resources:     []string{"spaces"},
+			ResourceNames: []string{"my-space"},
+		},
+	}, role.Rules)
+}
+
 func assertAllowed(t *testing.T, role *v1.Role, verb, group, resource string) {
 	t.Helper()
 
@@ -0,0 +1,112 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// RoleBindingName gets the name of the RoleBinding for the given space role.
+// The binding shares its name with the Role it binds to.
+func RoleBindingName(space *v1alpha1.Space, role v1alpha1.SpaceRole) (string, error) {
+	switch role {
+	case v1alpha1.SpaceRoleDeveloper:
+		return DeveloperRoleName(space), nil
+	case v1alpha1.SpaceRoleAuditor:
+		return AuditorRoleName(space), nil
+	case v1alpha1.SpaceRoleManager:
+		return ManagerRoleName(space), nil
+	default:
+		return "", fmt.Errorf("unknown space role %q", role)
+	}
+}
+
+// MakeRoleBinding creates a RoleBinding that binds the subjects assigned to
+// the role in the Space to the role.
+func MakeRoleBinding(space *v1alpha1.Space, role v1alpha1.SpaceRole) (*v1.RoleBinding, error) {
+	name, err := RoleBindingName(space, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: NamespaceName(space),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(space),
+			},
+			Labels: resources.UnionMaps(space.GetLabels(), map[string]string{
+				managedByLabel: "kf",
+			}),
+		},
+		Subjects: makeSubjects(space, role),
+		RoleRef: v1.RoleRef{
+			APIGroup: v1.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+	}, nil
+}
+
+// MakeManagerClusterRoleBinding creates a ClusterRoleBinding that binds the
+// Space's managers to the manager ClusterRole.
+func MakeManagerClusterRoleBinding(space *v1alpha1.Space) (*v1.ClusterRoleBinding, error) {
+	name := ManagerClusterRoleName(space)
+
+	return &v1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(space),
+			},
+			Labels: resources.UnionMaps(space.GetLabels(), map[string]string{
+				managedByLabel: "kf",
+			}),
+		},
+		Subjects: makeSubjects(space, v1alpha1.SpaceRoleManager),
+		RoleRef: v1.RoleRef{
+			APIGroup: v1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     name,
+		},
+	}, nil
+}
+
+func makeSubjects(space *v1alpha1.Space, role v1alpha1.SpaceRole) []v1.Subject {
+	var subjects []v1.Subject
+	for _, assignment := range space.Spec.Security.RoleAssignmentsFor(role) {
+		subject := v1.Subject{
+			Kind: assignment.Kind,
+			Name: assignment.Name,
+		}
+
+		if assignment.Kind == v1.ServiceAccountKind {
+			subject.Namespace = NamespaceName(space)
+		} else {
+			subject.APIGroup = v1.GroupName
+		}
+
+		subjects = append(subjects, subject)
+	}
+
+	return subjects
+}
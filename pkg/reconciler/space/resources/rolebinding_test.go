@@ -0,0 +1,127 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	v1 "k8s.io/api/rbac/v1"
+)
+
+func ExampleRoleBindingName() {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+
+	name, err := RoleBindingName(space, v1alpha1.SpaceRoleManager)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(name)
+
+	// Output: space-manager
+}
+
+func TestMakeRoleBinding(t *testing.T) {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+	space.Spec.Security.RoleAssignments = []v1alpha1.SpaceRoleAssignment{
+		{Role: v1alpha1.SpaceRoleDeveloper, Kind: "User", Name: "dev@example.com"},
+		{Role: v1alpha1.SpaceRoleDeveloper, Kind: "ServiceAccount", Name: "ci"},
+		{Role: v1alpha1.SpaceRoleAuditor, Kind: "Group", Name: "auditors"},
+	}
+
+	cases := map[string]struct {
+		Role         v1alpha1.SpaceRole
+		ExpectedRef  v1.RoleRef
+		ExpectedSubs []v1.Subject
+		ExpectedErr  error
+	}{
+		"developers": {
+			Role: v1alpha1.SpaceRoleDeveloper,
+			ExpectedRef: v1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "Role",
+				Name:     "space-developer",
+			},
+			ExpectedSubs: []v1.Subject{
+				{Kind: "User", APIGroup: "rbac.authorization.k8s.io", Name: "dev@example.com"},
+				{Kind: "ServiceAccount", Name: "ci", Namespace: "my-space"},
+			},
+		},
+		"auditors": {
+			Role: v1alpha1.SpaceRoleAuditor,
+			ExpectedRef: v1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "Role",
+				Name:     "space-auditor",
+			},
+			ExpectedSubs: []v1.Subject{
+				{Kind: "Group", APIGroup: "rbac.authorization.k8s.io", Name: "auditors"},
+			},
+		},
+		"no managers": {
+			Role: v1alpha1.SpaceRoleManager,
+			ExpectedRef: v1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "Role",
+				Name:     "space-manager",
+			},
+		},
+		"unknown role": {
+			Role:        "owner",
+			ExpectedErr: fmt.Errorf(`unknown space role "owner"`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			binding, err := MakeRoleBinding(space, tc.Role)
+			testutil.AssertErrorsEqual(t, tc.ExpectedErr, err)
+			if err != nil {
+				return
+			}
+
+			testutil.AssertEqual(t, "namespace", "my-space", binding.Namespace)
+			testutil.AssertEqual(t, "roleRef", tc.ExpectedRef, binding.RoleRef)
+			testutil.AssertEqual(t, "subjects", tc.ExpectedSubs, binding.Subjects)
+		})
+	}
+}
+
+func TestMakeManagerClusterRoleBinding(t *testing.T) {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+	space.Spec.Security.RoleAssignments = []v1alpha1.SpaceRoleAssignment{
+		{Role: v1alpha1.SpaceRoleManager, Kind: "User", Name: "manager@example.com"},
+		{Role: v1alpha1.SpaceRoleDeveloper, Kind: "User", Name: "dev@example.com"},
+	}
+
+	binding, err := MakeManagerClusterRoleBinding(space)
+	testutil.AssertNil(t, "MakeManagerClusterRoleBinding error", err)
+
+	testutil.AssertEqual(t, "name", "kf-space-manager-my-space", binding.Name)
+	testutil.AssertEqual(t, "roleRef", v1.RoleRef{
+		APIGroup: "rbac.authorization.k8s.io",
+		Kind:     "ClusterRole",
+		Name:     "kf-space-manager-my-space",
+	}, binding.RoleRef)
+	testutil.AssertEqual(t, "subjects", []v1.Subject{
+		{Kind: "User", APIGroup: "rbac.authorization.k8s.io", Name: "manager@example.com"},
+	}, binding.Subjects)
+}
@@ -0,0 +1,49 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"github.com/google/kf/pkg/kf/secrets"
+	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
+	"github.com/google/kf/pkg/kf/systemenvinjector"
+	svccatcv1beta1 "github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// NewSystemEnvInjector creates a SystemEnvInjector for reconcilers that need
+// to compute the VCAP_* environment variables of an App.
+//
+// TODO(#397): replace all of this code which eventually gets the
+// systemEnvInjector with informers once service-binding creation is server
+// side.
+func NewSystemEnvInjector(logger *zap.SugaredLogger) systemenvinjector.SystemEnvInjectorInterface {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		logger.Fatalf("Error getting config: %s", err.Error())
+	}
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		logger.Fatalf("Error building kubernetes clientset: %s", err.Error())
+	}
+	svccatClient, err := svccatcv1beta1.NewForConfig(config)
+	if err != nil {
+		logger.Fatalf("Error building service-catalog client: %s", err.Error())
+	}
+	secretsClient := secrets.NewClient(kubeClient)
+	bindingsClient := servicebindings.NewClient(svccatClient, secretsClient)
+	return systemenvinjector.NewSystemEnvInjector(bindingsClient)
+}
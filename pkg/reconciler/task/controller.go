@@ -0,0 +1,87 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	appinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/app"
+	spaceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/space"
+	taskinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/task"
+	jobinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/job"
+	"github.com/google/kf/pkg/reconciler"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// NewController creates a new controller capable of reconciling Kf Tasks.
+func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	logger := logging.FromContext(ctx)
+
+	// Get informers off context
+	taskInformer := taskinformer.Get(ctx)
+	appInformer := appinformer.Get(ctx)
+	spaceInformer := spaceinformer.Get(ctx)
+	jobInformer := jobinformer.Get(ctx)
+
+	// Create reconciler
+	c := &Reconciler{
+		Base:              reconciler.NewBase(ctx, "task-controller", cmw),
+		taskLister:        taskInformer.Lister(),
+		appLister:         appInformer.Lister(),
+		spaceLister:       spaceInformer.Lister(),
+		jobLister:         jobInformer.Lister(),
+		systemEnvInjector: reconciler.NewSystemEnvInjector(logger),
+	}
+
+	impl := controller.NewImpl(c, logger, "Tasks")
+
+	c.Logger.Info("Setting up event handlers")
+
+	// Watch for changes in sub-resources so we can sync accordingly
+	taskInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	jobInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Task")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Tasks waiting on an App to get an image need to be notified when it
+	// does.
+	appInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		app, ok := obj.(*v1alpha1.App)
+		if !ok {
+			return
+		}
+
+		tasks, err := c.taskLister.Tasks(app.Namespace).List(labels.Everything())
+		if err != nil {
+			c.Logger.Warnf("Couldn't list Tasks for App %q: %s", app.Name, err)
+			return
+		}
+
+		for _, task := range tasks {
+			if task.Spec.AppName == app.Name && !task.Status.IsDone() {
+				impl.Enqueue(task)
+			}
+		}
+	}))
+
+	return impl
+}
@@ -0,0 +1,234 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	kflisters "github.com/google/kf/pkg/client/listers/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/systemenvinjector"
+	"github.com/google/kf/pkg/reconciler"
+	"github.com/google/kf/pkg/reconciler/task/resources"
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// Reconciler reconciles a Task object with the K8s cluster.
+type Reconciler struct {
+	*reconciler.Base
+
+	// listers index properties about resources
+	taskLister        kflisters.TaskLister
+	appLister         kflisters.AppLister
+	spaceLister       kflisters.SpaceLister
+	jobLister         batchlisters.JobLister
+	systemEnvInjector systemenvinjector.SystemEnvInjectorInterface
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile is called by Kubernetes.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	logger := logging.FromContext(ctx)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	original, err := r.taskLister.Tasks(namespace).Get(name)
+	switch {
+	case apierrs.IsNotFound(err):
+		logger.Errorf("task %q no longer exists\n", name)
+		return nil
+
+	case err != nil:
+		return err
+
+	case original.GetDeletionTimestamp() != nil:
+		return nil
+	}
+
+	// Don't modify the informers copy
+	toReconcile := original.DeepCopy()
+
+	// Reconcile this copy of the task and then write back any status
+	// updates regardless of whether the reconciliation errored out.
+	reconcileErr := r.ApplyChanges(ctx, toReconcile)
+	if equality.Semantic.DeepEqual(original.Status, toReconcile.Status) {
+		// If we didn't change anything then don't call updateStatus.
+		// This is important because the copy we loaded from the informer's
+		// cache may be stale and we don't want to overwrite a prior update
+		// to status with this stale state.
+
+	} else if _, uErr := r.updateStatus(namespace, toReconcile); uErr != nil {
+		logger.Warnw("Failed to update Task status", zap.Error(uErr))
+		return uErr
+	}
+
+	return reconcileErr
+}
+
+// ApplyChanges updates the linked resources in the cluster with the current
+// status of the Task.
+func (r *Reconciler) ApplyChanges(ctx context.Context, task *v1alpha1.Task) error {
+	task.Status.InitializeConditions()
+
+	// Finished Tasks are never re-run.
+	if task.Status.IsDone() {
+		return nil
+	}
+
+	space, err := r.spaceLister.Get(task.Namespace)
+	switch {
+	case apierrs.IsNotFound(err):
+		space = &v1alpha1.Space{}
+		space.SetDefaults(context.Background())
+	case err != nil:
+		return err
+	}
+
+	// Sync App
+	{
+		app, err := r.appLister.Apps(task.Namespace).Get(task.Spec.AppName)
+		switch {
+		case apierrs.IsNotFound(err):
+			task.Status.MarkAppNotFound(task.Spec.AppName)
+			return nil
+		case err != nil:
+			return err
+		}
+
+		task.Status.PropagateAppStatus(app)
+
+		if task.Status.Image == "" {
+			r.Logger.Info("Waiting for App image; exiting early")
+			return nil
+		}
+
+		// Sync Job
+		condition := task.Status.JobCondition()
+		desired, err := resources.MakeJob(task, app, space, r.systemEnvInjector)
+		if err != nil {
+			return condition.MarkTemplateError(err)
+		}
+
+		actual, err := r.jobLister.Jobs(desired.Namespace).Get(desired.Name)
+		if apierrs.IsNotFound(err) {
+			actual, err = r.KubeClientSet.BatchV1().Jobs(desired.Namespace).Create(desired)
+			if err != nil {
+				return condition.MarkReconciliationError("creating", err)
+			}
+		} else if err != nil {
+			return condition.MarkReconciliationError("getting latest", err)
+		} else if !metav1.IsControlledBy(actual, task) {
+			return condition.MarkChildNotOwned(desired.Name)
+		} else if actual, err = r.reconcileJob(desired, actual); err != nil {
+			return condition.MarkReconciliationError("updating existing", err)
+		}
+
+		task.Status.PropagateJobStatus(actual)
+
+		if task.Spec.Terminated && !task.Status.Succeeded() {
+			task.Status.MarkTerminated()
+		}
+
+		if err := r.syncExitCode(task, actual); err != nil {
+			return err
+		}
+	}
+
+	// Making it to the bottom of the reconciler means we've synchronized.
+	task.Status.ObservedGeneration = task.Generation
+
+	return nil
+}
+
+// syncExitCode reads the exit code of the Task's process off of the Pod the
+// Job ran.
+func (r *Reconciler) syncExitCode(task *v1alpha1.Task, job *batchv1.Job) error {
+	if !task.Status.IsDone() {
+		return nil
+	}
+
+	// NOTE: this code polls the Kubernetes cluster directly rather than
+	// creating an informer because Pods are only needed once per Task.
+	selector := labels.Set{"job-name": job.Name}.String()
+	pods, err := r.KubeClientSet.CoreV1().Pods(job.Namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+
+	// Only the Task's container is read, sidecars added to the Pod have their
+	// own exit codes.
+	var userContainer string
+	if containers := job.Spec.Template.Spec.Containers; len(containers) > 0 {
+		userContainer = containers[0].Name
+	}
+
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != userContainer {
+				continue
+			}
+
+			task.Status.PropagateTerminatedState(status.State.Terminated)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileJob(desired, actual *batchv1.Job) (*batchv1.Job, error) {
+	// The pod template of a Job is immutable so only parallelism, which
+	// is used to terminate the Task, is reconciled.
+	if equality.Semantic.DeepEqual(desired.Spec.Parallelism, actual.Spec.Parallelism) {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Spec.Parallelism = desired.Spec.Parallelism
+	return r.KubeClientSet.BatchV1().Jobs(existing.Namespace).Update(existing)
+}
+
+func (r *Reconciler) updateStatus(namespace string, desired *v1alpha1.Task) (*v1alpha1.Task, error) {
+	actual, err := r.taskLister.Tasks(namespace).Get(desired.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// If there's nothing to update, just return.
+	if reflect.DeepEqual(actual.Status, desired.Status) {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Status = desired.Status
+
+	return r.KfClientSet.KfV1alpha1().Tasks(namespace).UpdateStatus(existing)
+}
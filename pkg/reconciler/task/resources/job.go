@@ -0,0 +1,146 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/internal/envutil"
+	"github.com/google/kf/pkg/kf/systemenvinjector"
+	"github.com/knative/serving/pkg/resources"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// IstioInjectAnnotation controls whether Istio adds its proxy sidecar to a
+// Pod. The sidecar never exits so Pods that have it never complete.
+const IstioInjectAnnotation = "sidecar.istio.io/inject"
+
+// JobName gets the name of a Job given the Task.
+func JobName(task *v1alpha1.Task) string {
+	return task.Name
+}
+
+// MakeJobLabels creates labels that can be used to find the Job and Pods
+// for a Task.
+func MakeJobLabels(task *v1alpha1.Task, app *v1alpha1.App) map[string]string {
+	return resources.UnionMaps(app.ComponentLabels("task"), map[string]string{
+		v1alpha1.TaskNameLabel: task.Name,
+	})
+}
+
+// MakeJob creates a Job that runs the Task using the image, pod template and
+// environment of the App.
+func MakeJob(
+	task *v1alpha1.Task,
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+	systemEnvInjector systemenvinjector.SystemEnvInjectorInterface,
+) (*batchv1.Job, error) {
+
+	image := task.Status.Image
+	if image == "" {
+		return nil, errors.New("waiting for the App to have an image")
+	}
+
+	// don't modify the spec on the app
+	podSpec := app.Spec.Template.Spec.DeepCopy()
+
+	// At this point in the lifecycle there should be exactly one container
+	// if the webhhook is working but create one to avoid panics just in case.
+	if len(podSpec.Containers) == 0 {
+		podSpec.Containers = append(podSpec.Containers, corev1.Container{})
+	}
+
+	container := &podSpec.Containers[0]
+	container.Image = image
+
+	// Use the same container name Knative gives App containers so logs can be
+	// read the same way for both.
+	if container.Name == "" {
+		container.Name = "user-container"
+	}
+
+	// Tasks run to completion rather than serving traffic so probes and ports
+	// from the App don't apply.
+	container.ReadinessProbe = nil
+	container.LivenessProbe = nil
+	container.Ports = nil
+
+	if len(task.Spec.Command) > 0 {
+		container.Command = task.Spec.Command
+	}
+
+	if len(task.Spec.Args) > 0 {
+		container.Args = task.Spec.Args
+	}
+
+	// Execution environment variables come before others because they're built
+	// to be overridden. They're copied so the space in the informer's cache
+	// isn't modified.
+	var env []corev1.EnvVar
+	env = append(env, space.Spec.Execution.Env...)
+	container.Env = append(env, container.Env...)
+
+	computedEnv, err := systemEnvInjector.ComputeSystemEnv(app)
+	if err != nil {
+		return nil, err
+	}
+
+	container.Env = append(container.Env, computedEnv...)
+	container.Env = append(container.Env, task.Spec.Env...)
+	container.Env = envutil.DeduplicateEnvVars(container.Env)
+
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+
+	labels := MakeJobLabels(task, app)
+
+	// Terminated Tasks are stopped by scaling the Job to zero which removes
+	// any running Pods while keeping the Job's history.
+	parallelism := int32(1)
+	if task.Spec.Terminated {
+		parallelism = 0
+	}
+
+	// Tasks are never retried, a failed Task is reported to the user.
+	backoffLimit := int32(0)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName(task),
+			Namespace: task.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(task),
+			},
+			Labels: resources.UnionMaps(task.GetLabels(), labels),
+		},
+		Spec: batchv1.JobSpec{
+			Parallelism:  &parallelism,
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						IstioInjectAnnotation: "false",
+					},
+				},
+				Spec: *podSpec,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/systemenvinjector/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMakeJob(t *testing.T) {
+	t.Parallel()
+
+	exampleApp := func() v1alpha1.App {
+		return v1alpha1.App{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-app",
+				Namespace: "some-space",
+			},
+			Spec: v1alpha1.AppSpec{
+				Template: v1alpha1.AppSpecTemplate{
+					Spec: corev1.PodSpec{
+						ServiceAccountName: "some-sa",
+						Containers: []corev1.Container{{
+							Args:           []string{"serve"},
+							Env:            []corev1.EnvVar{{Name: "APP", Value: "app"}},
+							ReadinessProbe: &corev1.Probe{},
+							Ports:          []corev1.ContainerPort{{ContainerPort: 8080}},
+						}},
+					},
+				},
+			},
+		}
+	}
+
+	exampleTask := func() v1alpha1.Task {
+		return v1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-task",
+				Namespace: "some-space",
+			},
+			Spec: v1alpha1.TaskSpec{
+				AppName: "some-app",
+				Command: []string{"rake"},
+				Args:    []string{"db:migrate"},
+				Env:     []corev1.EnvVar{{Name: "APP", Value: "task"}},
+			},
+			Status: v1alpha1.TaskStatus{
+				Image: "some-image",
+			},
+		}
+	}
+
+	for tn, tc := range map[string]struct {
+		task      v1alpha1.Task
+		setup     func(injector *fake.FakeSystemEnvInjector)
+		wantErr   error
+		assertJob func(t *testing.T, job *batchv1.Job)
+	}{
+		"builds job from app": {
+			task: exampleTask(),
+			setup: func(injector *fake.FakeSystemEnvInjector) {
+				injector.EXPECT().ComputeSystemEnv(gomock.Any()).Return([]corev1.EnvVar{
+					{Name: "VCAP_SERVICES", Value: "{}"},
+				}, nil)
+			},
+			assertJob: func(t *testing.T, job *batchv1.Job) {
+				testutil.AssertEqual(t, "name", "some-task", job.Name)
+				testutil.AssertEqual(t, "namespace", "some-space", job.Namespace)
+				testutil.AssertEqual(t, "task label", "some-task", job.Labels[v1alpha1.TaskNameLabel])
+				testutil.AssertEqual(t, "parallelism", int32(1), *job.Spec.Parallelism)
+				testutil.AssertEqual(t, "backoffLimit", int32(0), *job.Spec.BackoffLimit)
+				testutil.AssertEqual(t, "istio inject", "false", job.Spec.Template.Annotations[IstioInjectAnnotation])
+
+				podSpec := job.Spec.Template.Spec
+				testutil.AssertEqual(t, "restartPolicy", corev1.RestartPolicyNever, podSpec.RestartPolicy)
+				testutil.AssertEqual(t, "serviceAccount", "some-sa", podSpec.ServiceAccountName)
+
+				container := podSpec.Containers[0]
+				testutil.AssertEqual(t, "image", "some-image", container.Image)
+				testutil.AssertEqual(t, "command", []string{"rake"}, container.Command)
+				testutil.AssertEqual(t, "args", []string{"db:migrate"}, container.Args)
+				testutil.AssertEqual(t, "readinessProbe", (*corev1.Probe)(nil), container.ReadinessProbe)
+				testutil.AssertEqual(t, "ports", []corev1.ContainerPort(nil), container.Ports)
+				testutil.AssertEqual(t, "env", []corev1.EnvVar{
+					{Name: "APP", Value: "task"},
+					{Name: "VCAP_SERVICES", Value: "{}"},
+				}, container.Env)
+			},
+		},
+		"terminated task": {
+			task: func() v1alpha1.Task {
+				task := exampleTask()
+				task.Spec.Terminated = true
+				return task
+			}(),
+			setup: func(injector *fake.FakeSystemEnvInjector) {
+				injector.EXPECT().ComputeSystemEnv(gomock.Any())
+			},
+			assertJob: func(t *testing.T, job *batchv1.Job) {
+				testutil.AssertEqual(t, "parallelism", int32(0), *job.Spec.Parallelism)
+			},
+		},
+		"no image": {
+			task: func() v1alpha1.Task {
+				task := exampleTask()
+				task.Status.Image = ""
+				return task
+			}(),
+			wantErr: errors.New("waiting for the App to have an image"),
+		},
+		"env injection fails": {
+			task: exampleTask(),
+			setup: func(injector *fake.FakeSystemEnvInjector) {
+				injector.EXPECT().ComputeSystemEnv(gomock.Any()).Return(nil, errors.New("some-error"))
+			},
+			wantErr: errors.New("some-error"),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			injector := fake.NewFakeSystemEnvInjector(ctrl)
+			if tc.setup != nil {
+				tc.setup(injector)
+			}
+
+			app := exampleApp()
+			job, err := MakeJob(&tc.task, &app, &v1alpha1.Space{}, injector)
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+
+			if tc.assertJob != nil {
+				tc.assertJob(t, job)
+			}
+		})
+	}
+}
+
+func TestMakeJob_doesNotModifySpace(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	injector := fake.NewFakeSystemEnvInjector(ctrl)
+	injector.EXPECT().ComputeSystemEnv(gomock.Any())
+
+	// Extra capacity lets append write into the space's backing array.
+	spaceEnv := make([]corev1.EnvVar, 1, 4)
+	spaceEnv[0] = corev1.EnvVar{Name: "SPACE", Value: "space"}
+
+	space := &v1alpha1.Space{}
+	space.Spec.Execution.Env = spaceEnv
+
+	task := &v1alpha1.Task{}
+	task.Status.Image = "some-image"
+
+	app := &v1alpha1.App{}
+	app.Spec.Template.Spec.Containers = []corev1.Container{{
+		Env: []corev1.EnvVar{{Name: "APP", Value: "app"}},
+	}}
+
+	_, err := MakeJob(task, app, space, injector)
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "backing array", corev1.EnvVar{}, spaceEnv[:2][1])
+}